@@ -23,6 +23,7 @@ import (
 	"github.com/superfly/flyctl/internal/buildinfo"
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyerr"
 	"github.com/superfly/flyctl/internal/flyutil"
 	"github.com/superfly/flyctl/internal/logger"
 	"github.com/superfly/flyctl/internal/sentry"
@@ -43,7 +44,10 @@ func Establish(ctx context.Context, apiClient wireguard.WebClient) (*Client, err
 
 	res, err := c.Ping(ctx)
 	if err != nil {
-		return StartDaemon(ctx)
+		if config.FromContext(ctx).NoAutoAgent {
+			return nil, errAutoAgentDisabled
+		}
+		return startDaemon(ctx)
 	}
 
 	resVer, err := version.Parse(res.Version)
@@ -91,7 +95,15 @@ func Establish(ctx context.Context, apiClient wireguard.WebClient) (*Client, err
 	// this is gross, but we need to wait for the agent to exit
 	pause.For(ctx, time.Second)
 
-	return StartDaemon(ctx)
+	return startDaemon(ctx)
+}
+
+// startDaemon is StartDaemon, swappable in tests.
+var startDaemon = StartDaemon
+
+var errAutoAgentDisabled = flyerr.GenericErr{
+	Err:     "the flyctl agent is not running",
+	Suggest: "Start it with `fly agent start`, or drop --no-auto-agent / FLY_NO_AUTO_AGENT to have it started automatically.",
 }
 
 func newClient(network, addr string) *Client {
@@ -0,0 +1,64 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/fly-go/tokens"
+	"github.com/superfly/flyctl/flyctl"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/state"
+)
+
+type noopWebClient struct{}
+
+func (noopWebClient) ValidateWireGuardPeers(ctx context.Context, peerIPs []string) ([]string, error) {
+	return nil, nil
+}
+
+func newTestContext(t *testing.T, cfg *config.Config) context.Context {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Setenv("FLY_CONFIG_DIR", dir)
+	flyctl.InitConfig()
+
+	cfg.Tokens = tokens.Parse("")
+	ctx := config.NewContext(context.Background(), cfg)
+	return state.WithConfigDirectory(ctx, dir)
+}
+
+func stubStartDaemon(t *testing.T) *int {
+	t.Helper()
+
+	var starts int
+	orig := startDaemon
+	startDaemon = func(ctx context.Context) (*Client, error) {
+		starts++
+		return newClient("unix", PathToSocket()), nil
+	}
+	t.Cleanup(func() { startDaemon = orig })
+
+	return &starts
+}
+
+func TestEstablishStartsAgentWhenNoneRunning(t *testing.T) {
+	ctx := newTestContext(t, &config.Config{})
+	starts := stubStartDaemon(t)
+
+	client, err := Establish(ctx, noopWebClient{})
+	require.NoError(t, err)
+	assert.NotNil(t, client)
+	assert.Equal(t, 1, *starts)
+}
+
+func TestEstablishWithAutoAgentDisabled(t *testing.T) {
+	ctx := newTestContext(t, &config.Config{NoAutoAgent: true})
+	starts := stubStartDaemon(t)
+
+	_, err := Establish(ctx, noopWebClient{})
+	assert.ErrorIs(t, err, errAutoAgentDisabled)
+	assert.Equal(t, 0, *starts)
+}
@@ -23,7 +23,6 @@ import (
 	"github.com/superfly/fly-go/tokens"
 	"github.com/superfly/flyctl/agent"
 	"github.com/superfly/flyctl/agent/internal/proto"
-	"github.com/superfly/flyctl/wg"
 
 	"github.com/superfly/flyctl/internal/buildinfo"
 	"github.com/superfly/flyctl/internal/config"
@@ -290,7 +289,21 @@ func (s *session) resolve(ctx context.Context, args ...string) {
 	s.ok(addr)
 }
 
-func resolve(ctx context.Context, tunnel *wg.Tunnel, addr string) (string, error) {
+// hostResolver is the subset of *wg.Tunnel resolve needs.
+type hostResolver interface {
+	LookupAAAA(ctx context.Context, name string) ([]net.IP, error)
+	LookupA(ctx context.Context, name string) ([]net.IP, error)
+}
+
+// resolve turns addr into an IP address (keeping the port, if any). 6PN
+// addresses are IPv6, so AAAA records are preferred and A records are only
+// used when a host has no AAAA record.
+func resolve(ctx context.Context, tunnel hostResolver, addr string) (string, error) {
+	// a bare IPv6 literal has too many colons for SplitHostPort
+	if n := net.ParseIP(addr); n != nil {
+		return n.String(), nil
+	}
+
 	host, port, err := net.SplitHostPort(addr)
 	if err != nil {
 		if !strings.Contains(err.Error(), "missing port") {
@@ -314,7 +327,13 @@ func resolve(ctx context.Context, tunnel *wg.Tunnel, addr string) (string, error
 	}
 
 	if len(ips) == 0 {
-		return "", agent.ErrNoSuchHost
+		if ips, err = tunnel.LookupA(ctx, host); err != nil {
+			return "", err
+		}
+	}
+
+	if len(ips) == 0 {
+		return "", fmt.Errorf("%s: %w", host, agent.ErrNoSuchHost)
 	}
 
 	addr = ips[0].String()
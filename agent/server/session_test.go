@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/agent"
+)
+
+type fakeResolver struct {
+	aaaa map[string][]net.IP
+	a    map[string][]net.IP
+}
+
+func (f fakeResolver) LookupAAAA(ctx context.Context, name string) ([]net.IP, error) {
+	return f.aaaa[name], nil
+}
+
+func (f fakeResolver) LookupA(ctx context.Context, name string) ([]net.IP, error) {
+	return f.a[name], nil
+}
+
+func TestResolve(t *testing.T) {
+	r := fakeResolver{
+		aaaa: map[string][]net.IP{
+			"both.internal": {net.ParseIP("fdaa:0:1:a7b::2")},
+			"six.internal":  {net.ParseIP("fdaa:0:1:a7b::3")},
+		},
+		a: map[string][]net.IP{
+			"both.internal": {net.ParseIP("10.0.0.2")},
+			"four.internal": {net.ParseIP("10.0.0.4")},
+		},
+	}
+	ctx := context.Background()
+
+	cases := map[string]string{
+		// AAAA wins when both exist
+		"both.internal:5432": "[fdaa:0:1:a7b::2]:5432",
+		"six.internal":       "fdaa:0:1:a7b::3",
+		// A is only used without AAAA
+		"four.internal:80": "10.0.0.4:80",
+		// literals pass through
+		"[fdaa::1]:22": "[fdaa::1]:22",
+		"fdaa::1":      "fdaa::1",
+	}
+	for addr, expected := range cases {
+		got, err := resolve(ctx, r, addr)
+		require.NoError(t, err, addr)
+		assert.Equal(t, expected, got, addr)
+	}
+
+	_, err := resolve(ctx, r, "missing.internal:80")
+	assert.ErrorIs(t, err, agent.ErrNoSuchHost)
+	assert.ErrorContains(t, err, "missing.internal")
+}
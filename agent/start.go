@@ -61,7 +61,7 @@ func StartDaemon(ctx context.Context) (*Client, error) {
 	}
 
 	if logger := logger.MaybeFromContext(ctx); logger != nil {
-		logger.Debugf("started agent process (pid: %d, log: %s)", cmd.Process.Pid, logFile)
+		logger.Infof("started flyctl agent in the background (pid: %d, log: %s)", cmd.Process.Pid, logFile)
 	}
 
 	switch client, err := waitForClient(ctx); {
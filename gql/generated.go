@@ -572,6 +572,26 @@ type AppDataSecretsSecret struct {
 // GetName returns AppDataSecretsSecret.Name, and is useful for accessing the field via an interface.
 func (v *AppDataSecretsSecret) GetName() string { return v.Name }
 
+type BillingStatus string
+
+const (
+	BillingStatusCurrent        BillingStatus = "CURRENT"
+	BillingStatusDelinquent     BillingStatus = "DELINQUENT"
+	BillingStatusPastDue        BillingStatus = "PAST_DUE"
+	BillingStatusSourceRequired BillingStatus = "SOURCE_REQUIRED"
+	BillingStatusTrialActive    BillingStatus = "TRIAL_ACTIVE"
+	BillingStatusTrialEnded     BillingStatus = "TRIAL_ENDED"
+)
+
+var AllBillingStatus = []BillingStatus{
+	BillingStatusCurrent,
+	BillingStatusDelinquent,
+	BillingStatusPastDue,
+	BillingStatusSourceRequired,
+	BillingStatusTrialActive,
+	BillingStatusTrialEnded,
+}
+
 // CreateAddOnCreateAddOnCreateAddOnPayload includes the requested fields of the GraphQL type CreateAddOnPayload.
 // The GraphQL type's documentation follows.
 //
@@ -997,7 +1017,25 @@ func (v *CreateLimitedAccessTokenCreateLimitedAccessTokenCreateLimitedAccessToke
 
 // CreateLimitedAccessTokenCreateLimitedAccessTokenCreateLimitedAccessTokenPayloadLimitedAccessToken includes the requested fields of the GraphQL type LimitedAccessToken.
 type CreateLimitedAccessTokenCreateLimitedAccessTokenCreateLimitedAccessTokenPayloadLimitedAccessToken struct {
-	TokenHeader string `json:"tokenHeader"`
+	Id          string    `json:"id"`
+	Name        string    `json:"name"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+	TokenHeader string    `json:"tokenHeader"`
+}
+
+// GetId returns CreateLimitedAccessTokenCreateLimitedAccessTokenCreateLimitedAccessTokenPayloadLimitedAccessToken.Id, and is useful for accessing the field via an interface.
+func (v *CreateLimitedAccessTokenCreateLimitedAccessTokenCreateLimitedAccessTokenPayloadLimitedAccessToken) GetId() string {
+	return v.Id
+}
+
+// GetName returns CreateLimitedAccessTokenCreateLimitedAccessTokenCreateLimitedAccessTokenPayloadLimitedAccessToken.Name, and is useful for accessing the field via an interface.
+func (v *CreateLimitedAccessTokenCreateLimitedAccessTokenCreateLimitedAccessTokenPayloadLimitedAccessToken) GetName() string {
+	return v.Name
+}
+
+// GetExpiresAt returns CreateLimitedAccessTokenCreateLimitedAccessTokenCreateLimitedAccessTokenPayloadLimitedAccessToken.ExpiresAt, and is useful for accessing the field via an interface.
+func (v *CreateLimitedAccessTokenCreateLimitedAccessTokenCreateLimitedAccessTokenPayloadLimitedAccessToken) GetExpiresAt() time.Time {
+	return v.ExpiresAt
 }
 
 // GetTokenHeader returns CreateLimitedAccessTokenCreateLimitedAccessTokenCreateLimitedAccessTokenPayloadLimitedAccessToken.TokenHeader, and is useful for accessing the field via an interface.
@@ -1062,6 +1100,32 @@ func (v *DeleteAddOnResponse) GetDeleteAddOn() DeleteAddOnDeleteAddOnDeleteAddOn
 	return v.DeleteAddOn
 }
 
+type DeploymentStrategy string
+
+const (
+	// Launch all new instances before shutting down previous instances
+	DeploymentStrategyBluegreen DeploymentStrategy = "BLUEGREEN"
+	// Ensure new instances are healthy before continuing with a rolling deployment
+	DeploymentStrategyCanary DeploymentStrategy = "CANARY"
+	// Deploy new instances all at once
+	DeploymentStrategyImmediate DeploymentStrategy = "IMMEDIATE"
+	// Incrementally replace old instances with new ones
+	DeploymentStrategyRolling DeploymentStrategy = "ROLLING"
+	// Incrementally replace old instances with new ones, 1 by 1
+	DeploymentStrategyRollingOne DeploymentStrategy = "ROLLING_ONE"
+	// Deploy new instances all at once
+	DeploymentStrategySimple DeploymentStrategy = "SIMPLE"
+)
+
+var AllDeploymentStrategy = []DeploymentStrategy{
+	DeploymentStrategyBluegreen,
+	DeploymentStrategyCanary,
+	DeploymentStrategyImmediate,
+	DeploymentStrategyRolling,
+	DeploymentStrategyRollingOne,
+	DeploymentStrategySimple,
+}
+
 // ExtensionData includes the GraphQL fields of AddOn requested by the fragment ExtensionData.
 type ExtensionData struct {
 	// The service name according to the provider
@@ -1927,6 +1991,201 @@ func (v *GetAppApp) __premarshalJSON() (*__premarshalGetAppApp, error) {
 	return &retval, nil
 }
 
+// GetAppReleaseApp includes the requested fields of the GraphQL type App.
+type GetAppReleaseApp struct {
+	// Find a specific release
+	Release *GetAppReleaseAppRelease `json:"release"`
+}
+
+// GetRelease returns GetAppReleaseApp.Release, and is useful for accessing the field via an interface.
+func (v *GetAppReleaseApp) GetRelease() *GetAppReleaseAppRelease { return v.Release }
+
+// GetAppReleaseAppRelease includes the requested fields of the GraphQL type Release.
+type GetAppReleaseAppRelease struct {
+	// The version of the release
+	Version int `json:"version"`
+	// The status of the release
+	Status             string             `json:"status"`
+	DeploymentStrategy DeploymentStrategy `json:"deploymentStrategy"`
+	// A description of the release
+	Description string `json:"description"`
+	// The reason for the release
+	Reason string `json:"reason"`
+	Stable bool   `json:"stable"`
+	// Docker image URI
+	ImageRef  string    `json:"imageRef"`
+	CreatedAt time.Time `json:"createdAt"`
+	// The user who created the release
+	User GetAppReleaseAppReleaseUser `json:"user"`
+}
+
+// GetVersion returns GetAppReleaseAppRelease.Version, and is useful for accessing the field via an interface.
+func (v *GetAppReleaseAppRelease) GetVersion() int { return v.Version }
+
+// GetStatus returns GetAppReleaseAppRelease.Status, and is useful for accessing the field via an interface.
+func (v *GetAppReleaseAppRelease) GetStatus() string { return v.Status }
+
+// GetDeploymentStrategy returns GetAppReleaseAppRelease.DeploymentStrategy, and is useful for accessing the field via an interface.
+func (v *GetAppReleaseAppRelease) GetDeploymentStrategy() DeploymentStrategy {
+	return v.DeploymentStrategy
+}
+
+// GetDescription returns GetAppReleaseAppRelease.Description, and is useful for accessing the field via an interface.
+func (v *GetAppReleaseAppRelease) GetDescription() string { return v.Description }
+
+// GetReason returns GetAppReleaseAppRelease.Reason, and is useful for accessing the field via an interface.
+func (v *GetAppReleaseAppRelease) GetReason() string { return v.Reason }
+
+// GetStable returns GetAppReleaseAppRelease.Stable, and is useful for accessing the field via an interface.
+func (v *GetAppReleaseAppRelease) GetStable() bool { return v.Stable }
+
+// GetImageRef returns GetAppReleaseAppRelease.ImageRef, and is useful for accessing the field via an interface.
+func (v *GetAppReleaseAppRelease) GetImageRef() string { return v.ImageRef }
+
+// GetCreatedAt returns GetAppReleaseAppRelease.CreatedAt, and is useful for accessing the field via an interface.
+func (v *GetAppReleaseAppRelease) GetCreatedAt() time.Time { return v.CreatedAt }
+
+// GetUser returns GetAppReleaseAppRelease.User, and is useful for accessing the field via an interface.
+func (v *GetAppReleaseAppRelease) GetUser() GetAppReleaseAppReleaseUser { return v.User }
+
+// GetAppReleaseAppReleaseUser includes the requested fields of the GraphQL type User.
+type GetAppReleaseAppReleaseUser struct {
+	// Email address for user (private)
+	Email string `json:"email"`
+}
+
+// GetEmail returns GetAppReleaseAppReleaseUser.Email, and is useful for accessing the field via an interface.
+func (v *GetAppReleaseAppReleaseUser) GetEmail() string { return v.Email }
+
+// GetAppReleaseConfigApp includes the requested fields of the GraphQL type App.
+type GetAppReleaseConfigApp struct {
+	// Find a specific release
+	Release *GetAppReleaseConfigAppRelease `json:"release"`
+}
+
+// GetRelease returns GetAppReleaseConfigApp.Release, and is useful for accessing the field via an interface.
+func (v *GetAppReleaseConfigApp) GetRelease() *GetAppReleaseConfigAppRelease { return v.Release }
+
+// GetAppReleaseConfigAppRelease includes the requested fields of the GraphQL type Release.
+type GetAppReleaseConfigAppRelease struct {
+	// The version of the release
+	Version            int                `json:"version"`
+	DeploymentStrategy DeploymentStrategy `json:"deploymentStrategy"`
+	// Docker image URI
+	ImageRef string `json:"imageRef"`
+	// Docker image
+	Image  *GetAppReleaseConfigAppReleaseImage           `json:"image"`
+	Config *GetAppReleaseConfigAppReleaseConfigAppConfig `json:"config"`
+}
+
+// GetVersion returns GetAppReleaseConfigAppRelease.Version, and is useful for accessing the field via an interface.
+func (v *GetAppReleaseConfigAppRelease) GetVersion() int { return v.Version }
+
+// GetDeploymentStrategy returns GetAppReleaseConfigAppRelease.DeploymentStrategy, and is useful for accessing the field via an interface.
+func (v *GetAppReleaseConfigAppRelease) GetDeploymentStrategy() DeploymentStrategy {
+	return v.DeploymentStrategy
+}
+
+// GetImageRef returns GetAppReleaseConfigAppRelease.ImageRef, and is useful for accessing the field via an interface.
+func (v *GetAppReleaseConfigAppRelease) GetImageRef() string { return v.ImageRef }
+
+// GetImage returns GetAppReleaseConfigAppRelease.Image, and is useful for accessing the field via an interface.
+func (v *GetAppReleaseConfigAppRelease) GetImage() *GetAppReleaseConfigAppReleaseImage {
+	return v.Image
+}
+
+// GetConfig returns GetAppReleaseConfigAppRelease.Config, and is useful for accessing the field via an interface.
+func (v *GetAppReleaseConfigAppRelease) GetConfig() *GetAppReleaseConfigAppReleaseConfigAppConfig {
+	return v.Config
+}
+
+// GetAppReleaseConfigAppReleaseConfigAppConfig includes the requested fields of the GraphQL type AppConfig.
+type GetAppReleaseConfigAppReleaseConfigAppConfig struct {
+	Definition interface{} `json:"definition"`
+}
+
+// GetDefinition returns GetAppReleaseConfigAppReleaseConfigAppConfig.Definition, and is useful for accessing the field via an interface.
+func (v *GetAppReleaseConfigAppReleaseConfigAppConfig) GetDefinition() interface{} {
+	return v.Definition
+}
+
+// GetAppReleaseConfigAppReleaseImage includes the requested fields of the GraphQL type Image.
+type GetAppReleaseConfigAppReleaseImage struct {
+	Digest string `json:"digest"`
+}
+
+// GetDigest returns GetAppReleaseConfigAppReleaseImage.Digest, and is useful for accessing the field via an interface.
+func (v *GetAppReleaseConfigAppReleaseImage) GetDigest() string { return v.Digest }
+
+// GetAppReleaseConfigResponse is returned by GetAppReleaseConfig on success.
+type GetAppReleaseConfigResponse struct {
+	// Find an app by name
+	App GetAppReleaseConfigApp `json:"app"`
+}
+
+// GetApp returns GetAppReleaseConfigResponse.App, and is useful for accessing the field via an interface.
+func (v *GetAppReleaseConfigResponse) GetApp() GetAppReleaseConfigApp { return v.App }
+
+// GetAppReleaseResponse is returned by GetAppRelease on success.
+type GetAppReleaseResponse struct {
+	// Find an app by name
+	App GetAppReleaseApp `json:"app"`
+}
+
+// GetApp returns GetAppReleaseResponse.App, and is useful for accessing the field via an interface.
+func (v *GetAppReleaseResponse) GetApp() GetAppReleaseApp { return v.App }
+
+// GetAppReleasesMetadataApp includes the requested fields of the GraphQL type App.
+type GetAppReleasesMetadataApp struct {
+	// Individual releases for this application
+	Releases GetAppReleasesMetadataAppReleasesReleaseConnection `json:"releases"`
+}
+
+// GetReleases returns GetAppReleasesMetadataApp.Releases, and is useful for accessing the field via an interface.
+func (v *GetAppReleasesMetadataApp) GetReleases() GetAppReleasesMetadataAppReleasesReleaseConnection {
+	return v.Releases
+}
+
+// GetAppReleasesMetadataAppReleasesReleaseConnection includes the requested fields of the GraphQL type ReleaseConnection.
+// The GraphQL type's documentation follows.
+//
+// The connection type for Release.
+type GetAppReleasesMetadataAppReleasesReleaseConnection struct {
+	// A list of nodes.
+	Nodes []GetAppReleasesMetadataAppReleasesReleaseConnectionNodesRelease `json:"nodes"`
+}
+
+// GetNodes returns GetAppReleasesMetadataAppReleasesReleaseConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *GetAppReleasesMetadataAppReleasesReleaseConnection) GetNodes() []GetAppReleasesMetadataAppReleasesReleaseConnectionNodesRelease {
+	return v.Nodes
+}
+
+// GetAppReleasesMetadataAppReleasesReleaseConnectionNodesRelease includes the requested fields of the GraphQL type Release.
+type GetAppReleasesMetadataAppReleasesReleaseConnectionNodesRelease struct {
+	// The version of the release
+	Version  int         `json:"version"`
+	Metadata interface{} `json:"metadata"`
+}
+
+// GetVersion returns GetAppReleasesMetadataAppReleasesReleaseConnectionNodesRelease.Version, and is useful for accessing the field via an interface.
+func (v *GetAppReleasesMetadataAppReleasesReleaseConnectionNodesRelease) GetVersion() int {
+	return v.Version
+}
+
+// GetMetadata returns GetAppReleasesMetadataAppReleasesReleaseConnectionNodesRelease.Metadata, and is useful for accessing the field via an interface.
+func (v *GetAppReleasesMetadataAppReleasesReleaseConnectionNodesRelease) GetMetadata() interface{} {
+	return v.Metadata
+}
+
+// GetAppReleasesMetadataResponse is returned by GetAppReleasesMetadata on success.
+type GetAppReleasesMetadataResponse struct {
+	// Find an app by name
+	App GetAppReleasesMetadataApp `json:"app"`
+}
+
+// GetApp returns GetAppReleasesMetadataResponse.App, and is useful for accessing the field via an interface.
+func (v *GetAppReleasesMetadataResponse) GetApp() GetAppReleasesMetadataApp { return v.App }
+
 // GetAppResponse is returned by GetApp on success.
 type GetAppResponse struct {
 	// Find an app by name
@@ -2261,114 +2520,1138 @@ type GetAppsByRoleResponse struct {
 // GetApps returns GetAppsByRoleResponse.Apps, and is useful for accessing the field via an interface.
 func (v *GetAppsByRoleResponse) GetApps() GetAppsByRoleAppsAppConnection { return v.Apps }
 
-// GetExtensionSsoLinkOrganization includes the requested fields of the GraphQL type Organization.
-type GetExtensionSsoLinkOrganization struct {
-	// Single sign-on link for the given extension type
-	ExtensionSsoLink string `json:"extensionSsoLink"`
-}
-
-// GetExtensionSsoLink returns GetExtensionSsoLinkOrganization.ExtensionSsoLink, and is useful for accessing the field via an interface.
-func (v *GetExtensionSsoLinkOrganization) GetExtensionSsoLink() string { return v.ExtensionSsoLink }
-
-// GetExtensionSsoLinkResponse is returned by GetExtensionSsoLink on success.
-type GetExtensionSsoLinkResponse struct {
-	// Find an organization by ID
-	Organization GetExtensionSsoLinkOrganization `json:"organization"`
-}
-
-// GetOrganization returns GetExtensionSsoLinkResponse.Organization, and is useful for accessing the field via an interface.
-func (v *GetExtensionSsoLinkResponse) GetOrganization() GetExtensionSsoLinkOrganization {
-	return v.Organization
-}
-
-// GetNearestRegionNearestRegion includes the requested fields of the GraphQL type Region.
-type GetNearestRegionNearestRegion struct {
-	// The IATA airport code for this region
-	Code string `json:"code"`
-	// The name of this region
-	Name             string `json:"name"`
-	GatewayAvailable bool   `json:"gatewayAvailable"`
-}
-
-// GetCode returns GetNearestRegionNearestRegion.Code, and is useful for accessing the field via an interface.
-func (v *GetNearestRegionNearestRegion) GetCode() string { return v.Code }
-
-// GetName returns GetNearestRegionNearestRegion.Name, and is useful for accessing the field via an interface.
-func (v *GetNearestRegionNearestRegion) GetName() string { return v.Name }
-
-// GetGatewayAvailable returns GetNearestRegionNearestRegion.GatewayAvailable, and is useful for accessing the field via an interface.
-func (v *GetNearestRegionNearestRegion) GetGatewayAvailable() bool { return v.GatewayAvailable }
-
-// GetNearestRegionResponse is returned by GetNearestRegion on success.
-type GetNearestRegionResponse struct {
-	NearestRegion GetNearestRegionNearestRegion `json:"nearestRegion"`
-}
-
-// GetNearestRegion returns GetNearestRegionResponse.NearestRegion, and is useful for accessing the field via an interface.
-func (v *GetNearestRegionResponse) GetNearestRegion() GetNearestRegionNearestRegion {
-	return v.NearestRegion
-}
-
-// GetOrganizationOrganization includes the requested fields of the GraphQL type Organization.
-type GetOrganizationOrganization struct {
-	OrganizationData `json:"-"`
-}
-
-// GetId returns GetOrganizationOrganization.Id, and is useful for accessing the field via an interface.
-func (v *GetOrganizationOrganization) GetId() string { return v.OrganizationData.Id }
-
-// GetSlug returns GetOrganizationOrganization.Slug, and is useful for accessing the field via an interface.
-func (v *GetOrganizationOrganization) GetSlug() string { return v.OrganizationData.Slug }
-
-// GetRawSlug returns GetOrganizationOrganization.RawSlug, and is useful for accessing the field via an interface.
-func (v *GetOrganizationOrganization) GetRawSlug() string { return v.OrganizationData.RawSlug }
-
-// GetPaidPlan returns GetOrganizationOrganization.PaidPlan, and is useful for accessing the field via an interface.
-func (v *GetOrganizationOrganization) GetPaidPlan() bool { return v.OrganizationData.PaidPlan }
-
-// GetAddOnSsoLink returns GetOrganizationOrganization.AddOnSsoLink, and is useful for accessing the field via an interface.
-func (v *GetOrganizationOrganization) GetAddOnSsoLink() string {
-	return v.OrganizationData.AddOnSsoLink
-}
-
-// GetProvisionsBetaExtensions returns GetOrganizationOrganization.ProvisionsBetaExtensions, and is useful for accessing the field via an interface.
-func (v *GetOrganizationOrganization) GetProvisionsBetaExtensions() bool {
-	return v.OrganizationData.ProvisionsBetaExtensions
+// GetBuildLogsNode includes the requested fields of the GraphQL interface Node.
+//
+// GetBuildLogsNode is implemented by the following types:
+// GetBuildLogsNodeAccessToken
+// GetBuildLogsNodeAddOn
+// GetBuildLogsNodeAddOnPlan
+// GetBuildLogsNodeAllocation
+// GetBuildLogsNodeApp
+// GetBuildLogsNodeAppCertificate
+// GetBuildLogsNodeAppChange
+// GetBuildLogsNodeBuild
+// GetBuildLogsNodeCertificate
+// GetBuildLogsNodeCheckHTTPResponse
+// GetBuildLogsNodeCheckJob
+// GetBuildLogsNodeCheckJobRun
+// GetBuildLogsNodeDNSPortal
+// GetBuildLogsNodeDNSPortalSession
+// GetBuildLogsNodeDNSRecord
+// GetBuildLogsNodeDelegatedWireGuardToken
+// GetBuildLogsNodeDomain
+// GetBuildLogsNodeEgressIPAddress
+// GetBuildLogsNodeHost
+// GetBuildLogsNodeIPAddress
+// GetBuildLogsNodeIssue
+// GetBuildLogsNodeLimitedAccessToken
+// GetBuildLogsNodeLoggedCertificate
+// GetBuildLogsNodeMachine
+// GetBuildLogsNodeMachineIP
+// GetBuildLogsNodeNetwork
+// GetBuildLogsNodeOrganization
+// GetBuildLogsNodeOrganizationInvitation
+// GetBuildLogsNodePostgresClusterAttachment
+// GetBuildLogsNodeRelease
+// GetBuildLogsNodeReleaseCommand
+// GetBuildLogsNodeReleaseUnprocessed
+// GetBuildLogsNodeSecret
+// GetBuildLogsNodeTemplateDeployment
+// GetBuildLogsNodeThirdPartyConfiguration
+// GetBuildLogsNodeUser
+// GetBuildLogsNodeUserCoupon
+// GetBuildLogsNodeVM
+// GetBuildLogsNodeVolume
+// GetBuildLogsNodeVolumeSnapshot
+// GetBuildLogsNodeWireGuardPeer
+// The GraphQL type's documentation follows.
+//
+// An object with an ID.
+type GetBuildLogsNode interface {
+	implementsGraphQLInterfaceGetBuildLogsNode()
+	// GetTypename returns the receiver's concrete GraphQL type-name (see interface doc for possible values).
+	GetTypename() string
 }
 
-func (v *GetOrganizationOrganization) UnmarshalJSON(b []byte) error {
-
+func (v *GetBuildLogsNodeAccessToken) implementsGraphQLInterfaceGetBuildLogsNode()               {}
+func (v *GetBuildLogsNodeAddOn) implementsGraphQLInterfaceGetBuildLogsNode()                     {}
+func (v *GetBuildLogsNodeAddOnPlan) implementsGraphQLInterfaceGetBuildLogsNode()                 {}
+func (v *GetBuildLogsNodeAllocation) implementsGraphQLInterfaceGetBuildLogsNode()                {}
+func (v *GetBuildLogsNodeApp) implementsGraphQLInterfaceGetBuildLogsNode()                       {}
+func (v *GetBuildLogsNodeAppCertificate) implementsGraphQLInterfaceGetBuildLogsNode()            {}
+func (v *GetBuildLogsNodeAppChange) implementsGraphQLInterfaceGetBuildLogsNode()                 {}
+func (v *GetBuildLogsNodeBuild) implementsGraphQLInterfaceGetBuildLogsNode()                     {}
+func (v *GetBuildLogsNodeCertificate) implementsGraphQLInterfaceGetBuildLogsNode()               {}
+func (v *GetBuildLogsNodeCheckHTTPResponse) implementsGraphQLInterfaceGetBuildLogsNode()         {}
+func (v *GetBuildLogsNodeCheckJob) implementsGraphQLInterfaceGetBuildLogsNode()                  {}
+func (v *GetBuildLogsNodeCheckJobRun) implementsGraphQLInterfaceGetBuildLogsNode()               {}
+func (v *GetBuildLogsNodeDNSPortal) implementsGraphQLInterfaceGetBuildLogsNode()                 {}
+func (v *GetBuildLogsNodeDNSPortalSession) implementsGraphQLInterfaceGetBuildLogsNode()          {}
+func (v *GetBuildLogsNodeDNSRecord) implementsGraphQLInterfaceGetBuildLogsNode()                 {}
+func (v *GetBuildLogsNodeDelegatedWireGuardToken) implementsGraphQLInterfaceGetBuildLogsNode()   {}
+func (v *GetBuildLogsNodeDomain) implementsGraphQLInterfaceGetBuildLogsNode()                    {}
+func (v *GetBuildLogsNodeEgressIPAddress) implementsGraphQLInterfaceGetBuildLogsNode()           {}
+func (v *GetBuildLogsNodeHost) implementsGraphQLInterfaceGetBuildLogsNode()                      {}
+func (v *GetBuildLogsNodeIPAddress) implementsGraphQLInterfaceGetBuildLogsNode()                 {}
+func (v *GetBuildLogsNodeIssue) implementsGraphQLInterfaceGetBuildLogsNode()                     {}
+func (v *GetBuildLogsNodeLimitedAccessToken) implementsGraphQLInterfaceGetBuildLogsNode()        {}
+func (v *GetBuildLogsNodeLoggedCertificate) implementsGraphQLInterfaceGetBuildLogsNode()         {}
+func (v *GetBuildLogsNodeMachine) implementsGraphQLInterfaceGetBuildLogsNode()                   {}
+func (v *GetBuildLogsNodeMachineIP) implementsGraphQLInterfaceGetBuildLogsNode()                 {}
+func (v *GetBuildLogsNodeNetwork) implementsGraphQLInterfaceGetBuildLogsNode()                   {}
+func (v *GetBuildLogsNodeOrganization) implementsGraphQLInterfaceGetBuildLogsNode()              {}
+func (v *GetBuildLogsNodeOrganizationInvitation) implementsGraphQLInterfaceGetBuildLogsNode()    {}
+func (v *GetBuildLogsNodePostgresClusterAttachment) implementsGraphQLInterfaceGetBuildLogsNode() {}
+func (v *GetBuildLogsNodeRelease) implementsGraphQLInterfaceGetBuildLogsNode()                   {}
+func (v *GetBuildLogsNodeReleaseCommand) implementsGraphQLInterfaceGetBuildLogsNode()            {}
+func (v *GetBuildLogsNodeReleaseUnprocessed) implementsGraphQLInterfaceGetBuildLogsNode()        {}
+func (v *GetBuildLogsNodeSecret) implementsGraphQLInterfaceGetBuildLogsNode()                    {}
+func (v *GetBuildLogsNodeTemplateDeployment) implementsGraphQLInterfaceGetBuildLogsNode()        {}
+func (v *GetBuildLogsNodeThirdPartyConfiguration) implementsGraphQLInterfaceGetBuildLogsNode()   {}
+func (v *GetBuildLogsNodeUser) implementsGraphQLInterfaceGetBuildLogsNode()                      {}
+func (v *GetBuildLogsNodeUserCoupon) implementsGraphQLInterfaceGetBuildLogsNode()                {}
+func (v *GetBuildLogsNodeVM) implementsGraphQLInterfaceGetBuildLogsNode()                        {}
+func (v *GetBuildLogsNodeVolume) implementsGraphQLInterfaceGetBuildLogsNode()                    {}
+func (v *GetBuildLogsNodeVolumeSnapshot) implementsGraphQLInterfaceGetBuildLogsNode()            {}
+func (v *GetBuildLogsNodeWireGuardPeer) implementsGraphQLInterfaceGetBuildLogsNode()             {}
+
+func __unmarshalGetBuildLogsNode(b []byte, v *GetBuildLogsNode) error {
 	if string(b) == "null" {
 		return nil
 	}
 
-	var firstPass struct {
-		*GetOrganizationOrganization
-		graphql.NoUnmarshalJSON
+	var tn struct {
+		TypeName string `json:"__typename"`
 	}
-	firstPass.GetOrganizationOrganization = v
-
-	err := json.Unmarshal(b, &firstPass)
+	err := json.Unmarshal(b, &tn)
 	if err != nil {
 		return err
 	}
 
-	err = json.Unmarshal(
-		b, &v.OrganizationData)
-	if err != nil {
-		return err
+	switch tn.TypeName {
+	case "AccessToken":
+		*v = new(GetBuildLogsNodeAccessToken)
+		return json.Unmarshal(b, *v)
+	case "AddOn":
+		*v = new(GetBuildLogsNodeAddOn)
+		return json.Unmarshal(b, *v)
+	case "AddOnPlan":
+		*v = new(GetBuildLogsNodeAddOnPlan)
+		return json.Unmarshal(b, *v)
+	case "Allocation":
+		*v = new(GetBuildLogsNodeAllocation)
+		return json.Unmarshal(b, *v)
+	case "App":
+		*v = new(GetBuildLogsNodeApp)
+		return json.Unmarshal(b, *v)
+	case "AppCertificate":
+		*v = new(GetBuildLogsNodeAppCertificate)
+		return json.Unmarshal(b, *v)
+	case "AppChange":
+		*v = new(GetBuildLogsNodeAppChange)
+		return json.Unmarshal(b, *v)
+	case "Build":
+		*v = new(GetBuildLogsNodeBuild)
+		return json.Unmarshal(b, *v)
+	case "Certificate":
+		*v = new(GetBuildLogsNodeCertificate)
+		return json.Unmarshal(b, *v)
+	case "CheckHTTPResponse":
+		*v = new(GetBuildLogsNodeCheckHTTPResponse)
+		return json.Unmarshal(b, *v)
+	case "CheckJob":
+		*v = new(GetBuildLogsNodeCheckJob)
+		return json.Unmarshal(b, *v)
+	case "CheckJobRun":
+		*v = new(GetBuildLogsNodeCheckJobRun)
+		return json.Unmarshal(b, *v)
+	case "DNSPortal":
+		*v = new(GetBuildLogsNodeDNSPortal)
+		return json.Unmarshal(b, *v)
+	case "DNSPortalSession":
+		*v = new(GetBuildLogsNodeDNSPortalSession)
+		return json.Unmarshal(b, *v)
+	case "DNSRecord":
+		*v = new(GetBuildLogsNodeDNSRecord)
+		return json.Unmarshal(b, *v)
+	case "DelegatedWireGuardToken":
+		*v = new(GetBuildLogsNodeDelegatedWireGuardToken)
+		return json.Unmarshal(b, *v)
+	case "Domain":
+		*v = new(GetBuildLogsNodeDomain)
+		return json.Unmarshal(b, *v)
+	case "EgressIPAddress":
+		*v = new(GetBuildLogsNodeEgressIPAddress)
+		return json.Unmarshal(b, *v)
+	case "Host":
+		*v = new(GetBuildLogsNodeHost)
+		return json.Unmarshal(b, *v)
+	case "IPAddress":
+		*v = new(GetBuildLogsNodeIPAddress)
+		return json.Unmarshal(b, *v)
+	case "Issue":
+		*v = new(GetBuildLogsNodeIssue)
+		return json.Unmarshal(b, *v)
+	case "LimitedAccessToken":
+		*v = new(GetBuildLogsNodeLimitedAccessToken)
+		return json.Unmarshal(b, *v)
+	case "LoggedCertificate":
+		*v = new(GetBuildLogsNodeLoggedCertificate)
+		return json.Unmarshal(b, *v)
+	case "Machine":
+		*v = new(GetBuildLogsNodeMachine)
+		return json.Unmarshal(b, *v)
+	case "MachineIP":
+		*v = new(GetBuildLogsNodeMachineIP)
+		return json.Unmarshal(b, *v)
+	case "Network":
+		*v = new(GetBuildLogsNodeNetwork)
+		return json.Unmarshal(b, *v)
+	case "Organization":
+		*v = new(GetBuildLogsNodeOrganization)
+		return json.Unmarshal(b, *v)
+	case "OrganizationInvitation":
+		*v = new(GetBuildLogsNodeOrganizationInvitation)
+		return json.Unmarshal(b, *v)
+	case "PostgresClusterAttachment":
+		*v = new(GetBuildLogsNodePostgresClusterAttachment)
+		return json.Unmarshal(b, *v)
+	case "Release":
+		*v = new(GetBuildLogsNodeRelease)
+		return json.Unmarshal(b, *v)
+	case "ReleaseCommand":
+		*v = new(GetBuildLogsNodeReleaseCommand)
+		return json.Unmarshal(b, *v)
+	case "ReleaseUnprocessed":
+		*v = new(GetBuildLogsNodeReleaseUnprocessed)
+		return json.Unmarshal(b, *v)
+	case "Secret":
+		*v = new(GetBuildLogsNodeSecret)
+		return json.Unmarshal(b, *v)
+	case "TemplateDeployment":
+		*v = new(GetBuildLogsNodeTemplateDeployment)
+		return json.Unmarshal(b, *v)
+	case "ThirdPartyConfiguration":
+		*v = new(GetBuildLogsNodeThirdPartyConfiguration)
+		return json.Unmarshal(b, *v)
+	case "User":
+		*v = new(GetBuildLogsNodeUser)
+		return json.Unmarshal(b, *v)
+	case "UserCoupon":
+		*v = new(GetBuildLogsNodeUserCoupon)
+		return json.Unmarshal(b, *v)
+	case "VM":
+		*v = new(GetBuildLogsNodeVM)
+		return json.Unmarshal(b, *v)
+	case "Volume":
+		*v = new(GetBuildLogsNodeVolume)
+		return json.Unmarshal(b, *v)
+	case "VolumeSnapshot":
+		*v = new(GetBuildLogsNodeVolumeSnapshot)
+		return json.Unmarshal(b, *v)
+	case "WireGuardPeer":
+		*v = new(GetBuildLogsNodeWireGuardPeer)
+		return json.Unmarshal(b, *v)
+	case "":
+		return fmt.Errorf(
+			"response was missing Node.__typename")
+	default:
+		return fmt.Errorf(
+			`unexpected concrete type for GetBuildLogsNode: "%v"`, tn.TypeName)
 	}
-	return nil
 }
 
-type __premarshalGetOrganizationOrganization struct {
-	Id string `json:"id"`
-
-	Slug string `json:"slug"`
+func __marshalGetBuildLogsNode(v *GetBuildLogsNode) ([]byte, error) {
 
-	RawSlug string `json:"rawSlug"`
+	var typename string
+	switch v := (*v).(type) {
+	case *GetBuildLogsNodeAccessToken:
+		typename = "AccessToken"
 
-	PaidPlan bool `json:"paidPlan"`
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeAccessToken
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeAddOn:
+		typename = "AddOn"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeAddOn
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeAddOnPlan:
+		typename = "AddOnPlan"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeAddOnPlan
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeAllocation:
+		typename = "Allocation"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeAllocation
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeApp:
+		typename = "App"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeApp
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeAppCertificate:
+		typename = "AppCertificate"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeAppCertificate
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeAppChange:
+		typename = "AppChange"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeAppChange
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeBuild:
+		typename = "Build"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeBuild
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeCertificate:
+		typename = "Certificate"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeCertificate
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeCheckHTTPResponse:
+		typename = "CheckHTTPResponse"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeCheckHTTPResponse
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeCheckJob:
+		typename = "CheckJob"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeCheckJob
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeCheckJobRun:
+		typename = "CheckJobRun"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeCheckJobRun
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeDNSPortal:
+		typename = "DNSPortal"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeDNSPortal
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeDNSPortalSession:
+		typename = "DNSPortalSession"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeDNSPortalSession
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeDNSRecord:
+		typename = "DNSRecord"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeDNSRecord
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeDelegatedWireGuardToken:
+		typename = "DelegatedWireGuardToken"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeDelegatedWireGuardToken
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeDomain:
+		typename = "Domain"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeDomain
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeEgressIPAddress:
+		typename = "EgressIPAddress"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeEgressIPAddress
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeHost:
+		typename = "Host"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeHost
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeIPAddress:
+		typename = "IPAddress"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeIPAddress
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeIssue:
+		typename = "Issue"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeIssue
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeLimitedAccessToken:
+		typename = "LimitedAccessToken"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeLimitedAccessToken
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeLoggedCertificate:
+		typename = "LoggedCertificate"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeLoggedCertificate
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeMachine:
+		typename = "Machine"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeMachine
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeMachineIP:
+		typename = "MachineIP"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeMachineIP
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeNetwork:
+		typename = "Network"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeNetwork
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeOrganization:
+		typename = "Organization"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeOrganization
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeOrganizationInvitation:
+		typename = "OrganizationInvitation"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeOrganizationInvitation
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodePostgresClusterAttachment:
+		typename = "PostgresClusterAttachment"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodePostgresClusterAttachment
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeRelease:
+		typename = "Release"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeRelease
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeReleaseCommand:
+		typename = "ReleaseCommand"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeReleaseCommand
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeReleaseUnprocessed:
+		typename = "ReleaseUnprocessed"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeReleaseUnprocessed
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeSecret:
+		typename = "Secret"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeSecret
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeTemplateDeployment:
+		typename = "TemplateDeployment"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeTemplateDeployment
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeThirdPartyConfiguration:
+		typename = "ThirdPartyConfiguration"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeThirdPartyConfiguration
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeUser:
+		typename = "User"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeUser
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeUserCoupon:
+		typename = "UserCoupon"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeUserCoupon
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeVM:
+		typename = "VM"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeVM
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeVolume:
+		typename = "Volume"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeVolume
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeVolumeSnapshot:
+		typename = "VolumeSnapshot"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeVolumeSnapshot
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetBuildLogsNodeWireGuardPeer:
+		typename = "WireGuardPeer"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetBuildLogsNodeWireGuardPeer
+		}{typename, v}
+		return json.Marshal(result)
+	case nil:
+		return []byte("null"), nil
+	default:
+		return nil, fmt.Errorf(
+			`unexpected concrete type for GetBuildLogsNode: "%T"`, v)
+	}
+}
+
+// GetBuildLogsNodeAccessToken includes the requested fields of the GraphQL type AccessToken.
+type GetBuildLogsNodeAccessToken struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeAccessToken.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeAccessToken) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeAddOn includes the requested fields of the GraphQL type AddOn.
+type GetBuildLogsNodeAddOn struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeAddOn.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeAddOn) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeAddOnPlan includes the requested fields of the GraphQL type AddOnPlan.
+type GetBuildLogsNodeAddOnPlan struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeAddOnPlan.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeAddOnPlan) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeAllocation includes the requested fields of the GraphQL type Allocation.
+type GetBuildLogsNodeAllocation struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeAllocation.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeAllocation) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeApp includes the requested fields of the GraphQL type App.
+type GetBuildLogsNodeApp struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeApp.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeApp) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeAppCertificate includes the requested fields of the GraphQL type AppCertificate.
+type GetBuildLogsNodeAppCertificate struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeAppCertificate.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeAppCertificate) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeAppChange includes the requested fields of the GraphQL type AppChange.
+type GetBuildLogsNodeAppChange struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeAppChange.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeAppChange) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeBuild includes the requested fields of the GraphQL type Build.
+type GetBuildLogsNodeBuild struct {
+	Typename string `json:"__typename"`
+	// Indicates if this build is currently in progress
+	InProgress bool `json:"inProgress"`
+	// Log output
+	Logs string `json:"logs"`
+}
+
+// GetTypename returns GetBuildLogsNodeBuild.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeBuild) GetTypename() string { return v.Typename }
+
+// GetInProgress returns GetBuildLogsNodeBuild.InProgress, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeBuild) GetInProgress() bool { return v.InProgress }
+
+// GetLogs returns GetBuildLogsNodeBuild.Logs, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeBuild) GetLogs() string { return v.Logs }
+
+// GetBuildLogsNodeCertificate includes the requested fields of the GraphQL type Certificate.
+type GetBuildLogsNodeCertificate struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeCertificate.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeCertificate) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeCheckHTTPResponse includes the requested fields of the GraphQL type CheckHTTPResponse.
+// The GraphQL type's documentation follows.
+//
+// check job http response
+type GetBuildLogsNodeCheckHTTPResponse struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeCheckHTTPResponse.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeCheckHTTPResponse) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeCheckJob includes the requested fields of the GraphQL type CheckJob.
+// The GraphQL type's documentation follows.
+//
+// check job
+type GetBuildLogsNodeCheckJob struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeCheckJob.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeCheckJob) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeCheckJobRun includes the requested fields of the GraphQL type CheckJobRun.
+// The GraphQL type's documentation follows.
+//
+// check job run
+type GetBuildLogsNodeCheckJobRun struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeCheckJobRun.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeCheckJobRun) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeDNSPortal includes the requested fields of the GraphQL type DNSPortal.
+type GetBuildLogsNodeDNSPortal struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeDNSPortal.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeDNSPortal) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeDNSPortalSession includes the requested fields of the GraphQL type DNSPortalSession.
+type GetBuildLogsNodeDNSPortalSession struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeDNSPortalSession.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeDNSPortalSession) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeDNSRecord includes the requested fields of the GraphQL type DNSRecord.
+type GetBuildLogsNodeDNSRecord struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeDNSRecord.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeDNSRecord) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeDelegatedWireGuardToken includes the requested fields of the GraphQL type DelegatedWireGuardToken.
+type GetBuildLogsNodeDelegatedWireGuardToken struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeDelegatedWireGuardToken.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeDelegatedWireGuardToken) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeDomain includes the requested fields of the GraphQL type Domain.
+type GetBuildLogsNodeDomain struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeDomain.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeDomain) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeEgressIPAddress includes the requested fields of the GraphQL type EgressIPAddress.
+type GetBuildLogsNodeEgressIPAddress struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeEgressIPAddress.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeEgressIPAddress) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeHost includes the requested fields of the GraphQL type Host.
+type GetBuildLogsNodeHost struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeHost.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeHost) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeIPAddress includes the requested fields of the GraphQL type IPAddress.
+type GetBuildLogsNodeIPAddress struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeIPAddress.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeIPAddress) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeIssue includes the requested fields of the GraphQL type Issue.
+type GetBuildLogsNodeIssue struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeIssue.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeIssue) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeLimitedAccessToken includes the requested fields of the GraphQL type LimitedAccessToken.
+type GetBuildLogsNodeLimitedAccessToken struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeLimitedAccessToken.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeLimitedAccessToken) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeLoggedCertificate includes the requested fields of the GraphQL type LoggedCertificate.
+type GetBuildLogsNodeLoggedCertificate struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeLoggedCertificate.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeLoggedCertificate) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeMachine includes the requested fields of the GraphQL type Machine.
+type GetBuildLogsNodeMachine struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeMachine.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeMachine) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeMachineIP includes the requested fields of the GraphQL type MachineIP.
+type GetBuildLogsNodeMachineIP struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeMachineIP.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeMachineIP) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeNetwork includes the requested fields of the GraphQL type Network.
+type GetBuildLogsNodeNetwork struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeNetwork.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeNetwork) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeOrganization includes the requested fields of the GraphQL type Organization.
+type GetBuildLogsNodeOrganization struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeOrganization.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeOrganization) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeOrganizationInvitation includes the requested fields of the GraphQL type OrganizationInvitation.
+type GetBuildLogsNodeOrganizationInvitation struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeOrganizationInvitation.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeOrganizationInvitation) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodePostgresClusterAttachment includes the requested fields of the GraphQL type PostgresClusterAttachment.
+type GetBuildLogsNodePostgresClusterAttachment struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodePostgresClusterAttachment.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodePostgresClusterAttachment) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeRelease includes the requested fields of the GraphQL type Release.
+type GetBuildLogsNodeRelease struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeRelease.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeRelease) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeReleaseCommand includes the requested fields of the GraphQL type ReleaseCommand.
+type GetBuildLogsNodeReleaseCommand struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeReleaseCommand.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeReleaseCommand) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeReleaseUnprocessed includes the requested fields of the GraphQL type ReleaseUnprocessed.
+type GetBuildLogsNodeReleaseUnprocessed struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeReleaseUnprocessed.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeReleaseUnprocessed) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeSecret includes the requested fields of the GraphQL type Secret.
+type GetBuildLogsNodeSecret struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeSecret.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeSecret) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeTemplateDeployment includes the requested fields of the GraphQL type TemplateDeployment.
+type GetBuildLogsNodeTemplateDeployment struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeTemplateDeployment.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeTemplateDeployment) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeThirdPartyConfiguration includes the requested fields of the GraphQL type ThirdPartyConfiguration.
+// The GraphQL type's documentation follows.
+//
+// Configuration for third-party caveats to be added to user macaroons
+type GetBuildLogsNodeThirdPartyConfiguration struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeThirdPartyConfiguration.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeThirdPartyConfiguration) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeUser includes the requested fields of the GraphQL type User.
+type GetBuildLogsNodeUser struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeUser.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeUser) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeUserCoupon includes the requested fields of the GraphQL type UserCoupon.
+type GetBuildLogsNodeUserCoupon struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeUserCoupon.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeUserCoupon) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeVM includes the requested fields of the GraphQL type VM.
+type GetBuildLogsNodeVM struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeVM.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeVM) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeVolume includes the requested fields of the GraphQL type Volume.
+type GetBuildLogsNodeVolume struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeVolume.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeVolume) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeVolumeSnapshot includes the requested fields of the GraphQL type VolumeSnapshot.
+type GetBuildLogsNodeVolumeSnapshot struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeVolumeSnapshot.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeVolumeSnapshot) GetTypename() string { return v.Typename }
+
+// GetBuildLogsNodeWireGuardPeer includes the requested fields of the GraphQL type WireGuardPeer.
+type GetBuildLogsNodeWireGuardPeer struct {
+	Typename string `json:"__typename"`
+}
+
+// GetTypename returns GetBuildLogsNodeWireGuardPeer.Typename, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsNodeWireGuardPeer) GetTypename() string { return v.Typename }
+
+// GetBuildLogsResponse is returned by GetBuildLogs on success.
+type GetBuildLogsResponse struct {
+	// Fetches an object given its ID.
+	Node GetBuildLogsNode `json:"-"`
+}
+
+// GetNode returns GetBuildLogsResponse.Node, and is useful for accessing the field via an interface.
+func (v *GetBuildLogsResponse) GetNode() GetBuildLogsNode { return v.Node }
+
+func (v *GetBuildLogsResponse) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*GetBuildLogsResponse
+		Node json.RawMessage `json:"node"`
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetBuildLogsResponse = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	{
+		dst := &v.Node
+		src := firstPass.Node
+		if len(src) != 0 && string(src) != "null" {
+			err = __unmarshalGetBuildLogsNode(
+				src, dst)
+			if err != nil {
+				return fmt.Errorf(
+					"unable to unmarshal GetBuildLogsResponse.Node: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+type __premarshalGetBuildLogsResponse struct {
+	Node json.RawMessage `json:"node"`
+}
+
+func (v *GetBuildLogsResponse) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *GetBuildLogsResponse) __premarshalJSON() (*__premarshalGetBuildLogsResponse, error) {
+	var retval __premarshalGetBuildLogsResponse
+
+	{
+
+		dst := &retval.Node
+		src := v.Node
+		var err error
+		*dst, err = __marshalGetBuildLogsNode(
+			&src)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"unable to marshal GetBuildLogsResponse.Node: %w", err)
+		}
+	}
+	return &retval, nil
+}
+
+// GetExtensionSsoLinkOrganization includes the requested fields of the GraphQL type Organization.
+type GetExtensionSsoLinkOrganization struct {
+	// Single sign-on link for the given extension type
+	ExtensionSsoLink string `json:"extensionSsoLink"`
+}
+
+// GetExtensionSsoLink returns GetExtensionSsoLinkOrganization.ExtensionSsoLink, and is useful for accessing the field via an interface.
+func (v *GetExtensionSsoLinkOrganization) GetExtensionSsoLink() string { return v.ExtensionSsoLink }
+
+// GetExtensionSsoLinkResponse is returned by GetExtensionSsoLink on success.
+type GetExtensionSsoLinkResponse struct {
+	// Find an organization by ID
+	Organization GetExtensionSsoLinkOrganization `json:"organization"`
+}
+
+// GetOrganization returns GetExtensionSsoLinkResponse.Organization, and is useful for accessing the field via an interface.
+func (v *GetExtensionSsoLinkResponse) GetOrganization() GetExtensionSsoLinkOrganization {
+	return v.Organization
+}
+
+// GetNearestRegionNearestRegion includes the requested fields of the GraphQL type Region.
+type GetNearestRegionNearestRegion struct {
+	// The IATA airport code for this region
+	Code string `json:"code"`
+	// The name of this region
+	Name             string `json:"name"`
+	GatewayAvailable bool   `json:"gatewayAvailable"`
+}
+
+// GetCode returns GetNearestRegionNearestRegion.Code, and is useful for accessing the field via an interface.
+func (v *GetNearestRegionNearestRegion) GetCode() string { return v.Code }
+
+// GetName returns GetNearestRegionNearestRegion.Name, and is useful for accessing the field via an interface.
+func (v *GetNearestRegionNearestRegion) GetName() string { return v.Name }
+
+// GetGatewayAvailable returns GetNearestRegionNearestRegion.GatewayAvailable, and is useful for accessing the field via an interface.
+func (v *GetNearestRegionNearestRegion) GetGatewayAvailable() bool { return v.GatewayAvailable }
+
+// GetNearestRegionResponse is returned by GetNearestRegion on success.
+type GetNearestRegionResponse struct {
+	NearestRegion GetNearestRegionNearestRegion `json:"nearestRegion"`
+}
+
+// GetNearestRegion returns GetNearestRegionResponse.NearestRegion, and is useful for accessing the field via an interface.
+func (v *GetNearestRegionResponse) GetNearestRegion() GetNearestRegionNearestRegion {
+	return v.NearestRegion
+}
+
+// GetOrganizationBillingStatusOrganization includes the requested fields of the GraphQL type Organization.
+type GetOrganizationBillingStatusOrganization struct {
+	BillingStatus BillingStatus `json:"billingStatus"`
+}
+
+// GetBillingStatus returns GetOrganizationBillingStatusOrganization.BillingStatus, and is useful for accessing the field via an interface.
+func (v *GetOrganizationBillingStatusOrganization) GetBillingStatus() BillingStatus {
+	return v.BillingStatus
+}
+
+// GetOrganizationBillingStatusResponse is returned by GetOrganizationBillingStatus on success.
+type GetOrganizationBillingStatusResponse struct {
+	// Find an organization by ID
+	Organization GetOrganizationBillingStatusOrganization `json:"organization"`
+}
+
+// GetOrganization returns GetOrganizationBillingStatusResponse.Organization, and is useful for accessing the field via an interface.
+func (v *GetOrganizationBillingStatusResponse) GetOrganization() GetOrganizationBillingStatusOrganization {
+	return v.Organization
+}
+
+// GetOrganizationOrganization includes the requested fields of the GraphQL type Organization.
+type GetOrganizationOrganization struct {
+	OrganizationData `json:"-"`
+}
+
+// GetId returns GetOrganizationOrganization.Id, and is useful for accessing the field via an interface.
+func (v *GetOrganizationOrganization) GetId() string { return v.OrganizationData.Id }
+
+// GetSlug returns GetOrganizationOrganization.Slug, and is useful for accessing the field via an interface.
+func (v *GetOrganizationOrganization) GetSlug() string { return v.OrganizationData.Slug }
+
+// GetRawSlug returns GetOrganizationOrganization.RawSlug, and is useful for accessing the field via an interface.
+func (v *GetOrganizationOrganization) GetRawSlug() string { return v.OrganizationData.RawSlug }
+
+// GetPaidPlan returns GetOrganizationOrganization.PaidPlan, and is useful for accessing the field via an interface.
+func (v *GetOrganizationOrganization) GetPaidPlan() bool { return v.OrganizationData.PaidPlan }
+
+// GetAddOnSsoLink returns GetOrganizationOrganization.AddOnSsoLink, and is useful for accessing the field via an interface.
+func (v *GetOrganizationOrganization) GetAddOnSsoLink() string {
+	return v.OrganizationData.AddOnSsoLink
+}
+
+// GetProvisionsBetaExtensions returns GetOrganizationOrganization.ProvisionsBetaExtensions, and is useful for accessing the field via an interface.
+func (v *GetOrganizationOrganization) GetProvisionsBetaExtensions() bool {
+	return v.OrganizationData.ProvisionsBetaExtensions
+}
+
+func (v *GetOrganizationOrganization) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*GetOrganizationOrganization
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetOrganizationOrganization = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.OrganizationData)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalGetOrganizationOrganization struct {
+	Id string `json:"id"`
+
+	Slug string `json:"slug"`
+
+	RawSlug string `json:"rawSlug"`
+
+	PaidPlan bool `json:"paidPlan"`
 
 	AddOnSsoLink string `json:"addOnSsoLink"`
 
@@ -2567,6 +3850,237 @@ type ListAddOnsResponse struct {
 // GetAddOns returns ListAddOnsResponse.AddOns, and is useful for accessing the field via an interface.
 func (v *ListAddOnsResponse) GetAddOns() ListAddOnsAddOnsAddOnConnection { return v.AddOns }
 
+// ListAppBuildsApp includes the requested fields of the GraphQL type App.
+type ListAppBuildsApp struct {
+	// [DEPRECATED] Builds of this application
+	Builds ListAppBuildsAppBuildsBuildConnection `json:"builds"`
+}
+
+// GetBuilds returns ListAppBuildsApp.Builds, and is useful for accessing the field via an interface.
+func (v *ListAppBuildsApp) GetBuilds() ListAppBuildsAppBuildsBuildConnection { return v.Builds }
+
+// ListAppBuildsAppBuildsBuildConnection includes the requested fields of the GraphQL type BuildConnection.
+// The GraphQL type's documentation follows.
+//
+// The connection type for Build.
+type ListAppBuildsAppBuildsBuildConnection struct {
+	// A list of nodes.
+	Nodes []ListAppBuildsAppBuildsBuildConnectionNodesBuild `json:"nodes"`
+}
+
+// GetNodes returns ListAppBuildsAppBuildsBuildConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *ListAppBuildsAppBuildsBuildConnection) GetNodes() []ListAppBuildsAppBuildsBuildConnectionNodesBuild {
+	return v.Nodes
+}
+
+// ListAppBuildsAppBuildsBuildConnectionNodesBuild includes the requested fields of the GraphQL type Build.
+type ListAppBuildsAppBuildsBuildConnectionNodesBuild struct {
+	Id     string `json:"id"`
+	Number int    `json:"number"`
+	// Status of the build
+	Status    string    `json:"status"`
+	Image     string    `json:"image"`
+	CreatedAt time.Time `json:"createdAt"`
+	// The user who initiated the build
+	CreatedBy ListAppBuildsAppBuildsBuildConnectionNodesBuildCreatedByUser `json:"createdBy"`
+}
+
+// GetId returns ListAppBuildsAppBuildsBuildConnectionNodesBuild.Id, and is useful for accessing the field via an interface.
+func (v *ListAppBuildsAppBuildsBuildConnectionNodesBuild) GetId() string { return v.Id }
+
+// GetNumber returns ListAppBuildsAppBuildsBuildConnectionNodesBuild.Number, and is useful for accessing the field via an interface.
+func (v *ListAppBuildsAppBuildsBuildConnectionNodesBuild) GetNumber() int { return v.Number }
+
+// GetStatus returns ListAppBuildsAppBuildsBuildConnectionNodesBuild.Status, and is useful for accessing the field via an interface.
+func (v *ListAppBuildsAppBuildsBuildConnectionNodesBuild) GetStatus() string { return v.Status }
+
+// GetImage returns ListAppBuildsAppBuildsBuildConnectionNodesBuild.Image, and is useful for accessing the field via an interface.
+func (v *ListAppBuildsAppBuildsBuildConnectionNodesBuild) GetImage() string { return v.Image }
+
+// GetCreatedAt returns ListAppBuildsAppBuildsBuildConnectionNodesBuild.CreatedAt, and is useful for accessing the field via an interface.
+func (v *ListAppBuildsAppBuildsBuildConnectionNodesBuild) GetCreatedAt() time.Time {
+	return v.CreatedAt
+}
+
+// GetCreatedBy returns ListAppBuildsAppBuildsBuildConnectionNodesBuild.CreatedBy, and is useful for accessing the field via an interface.
+func (v *ListAppBuildsAppBuildsBuildConnectionNodesBuild) GetCreatedBy() ListAppBuildsAppBuildsBuildConnectionNodesBuildCreatedByUser {
+	return v.CreatedBy
+}
+
+// ListAppBuildsAppBuildsBuildConnectionNodesBuildCreatedByUser includes the requested fields of the GraphQL type User.
+type ListAppBuildsAppBuildsBuildConnectionNodesBuildCreatedByUser struct {
+	// Email address for user (private)
+	Email string `json:"email"`
+}
+
+// GetEmail returns ListAppBuildsAppBuildsBuildConnectionNodesBuildCreatedByUser.Email, and is useful for accessing the field via an interface.
+func (v *ListAppBuildsAppBuildsBuildConnectionNodesBuildCreatedByUser) GetEmail() string {
+	return v.Email
+}
+
+// ListAppBuildsResponse is returned by ListAppBuilds on success.
+type ListAppBuildsResponse struct {
+	// Find an app by name
+	App ListAppBuildsApp `json:"app"`
+}
+
+// GetApp returns ListAppBuildsResponse.App, and is useful for accessing the field via an interface.
+func (v *ListAppBuildsResponse) GetApp() ListAppBuildsApp { return v.App }
+
+// ListAppExtensionsApp includes the requested fields of the GraphQL type App.
+type ListAppExtensionsApp struct {
+	AddOns ListAppExtensionsAppAddOnsAddOnConnection `json:"addOns"`
+}
+
+// GetAddOns returns ListAppExtensionsApp.AddOns, and is useful for accessing the field via an interface.
+func (v *ListAppExtensionsApp) GetAddOns() ListAppExtensionsAppAddOnsAddOnConnection { return v.AddOns }
+
+// ListAppExtensionsAppAddOnsAddOnConnection includes the requested fields of the GraphQL type AddOnConnection.
+// The GraphQL type's documentation follows.
+//
+// The connection type for AddOn.
+type ListAppExtensionsAppAddOnsAddOnConnection struct {
+	// A list of nodes.
+	Nodes []ListAppExtensionsAppAddOnsAddOnConnectionNodesAddOn `json:"nodes"`
+}
+
+// GetNodes returns ListAppExtensionsAppAddOnsAddOnConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *ListAppExtensionsAppAddOnsAddOnConnection) GetNodes() []ListAppExtensionsAppAddOnsAddOnConnectionNodesAddOn {
+	return v.Nodes
+}
+
+// ListAppExtensionsAppAddOnsAddOnConnectionNodesAddOn includes the requested fields of the GraphQL type AddOn.
+type ListAppExtensionsAppAddOnsAddOnConnectionNodesAddOn struct {
+	// The service name according to the provider
+	Name string `json:"name"`
+	// The add-on provider
+	AddOnProvider ListAppExtensionsAppAddOnsAddOnConnectionNodesAddOnAddOnProvider `json:"addOnProvider"`
+}
+
+// GetName returns ListAppExtensionsAppAddOnsAddOnConnectionNodesAddOn.Name, and is useful for accessing the field via an interface.
+func (v *ListAppExtensionsAppAddOnsAddOnConnectionNodesAddOn) GetName() string { return v.Name }
+
+// GetAddOnProvider returns ListAppExtensionsAppAddOnsAddOnConnectionNodesAddOn.AddOnProvider, and is useful for accessing the field via an interface.
+func (v *ListAppExtensionsAppAddOnsAddOnConnectionNodesAddOn) GetAddOnProvider() ListAppExtensionsAppAddOnsAddOnConnectionNodesAddOnAddOnProvider {
+	return v.AddOnProvider
+}
+
+// ListAppExtensionsAppAddOnsAddOnConnectionNodesAddOnAddOnProvider includes the requested fields of the GraphQL type AddOnProvider.
+type ListAppExtensionsAppAddOnsAddOnConnectionNodesAddOnAddOnProvider struct {
+	DisplayName string `json:"displayName"`
+}
+
+// GetDisplayName returns ListAppExtensionsAppAddOnsAddOnConnectionNodesAddOnAddOnProvider.DisplayName, and is useful for accessing the field via an interface.
+func (v *ListAppExtensionsAppAddOnsAddOnConnectionNodesAddOnAddOnProvider) GetDisplayName() string {
+	return v.DisplayName
+}
+
+// ListAppExtensionsResponse is returned by ListAppExtensions on success.
+type ListAppExtensionsResponse struct {
+	// Find an app by name
+	App ListAppExtensionsApp `json:"app"`
+}
+
+// GetApp returns ListAppExtensionsResponse.App, and is useful for accessing the field via an interface.
+func (v *ListAppExtensionsResponse) GetApp() ListAppExtensionsApp { return v.App }
+
+// ListOrganizationsOrganizationsOrganizationConnection includes the requested fields of the GraphQL type OrganizationConnection.
+// The GraphQL type's documentation follows.
+//
+// The connection type for Organization.
+type ListOrganizationsOrganizationsOrganizationConnection struct {
+	// A list of nodes.
+	Nodes []ListOrganizationsOrganizationsOrganizationConnectionNodesOrganization `json:"nodes"`
+}
+
+// GetNodes returns ListOrganizationsOrganizationsOrganizationConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *ListOrganizationsOrganizationsOrganizationConnection) GetNodes() []ListOrganizationsOrganizationsOrganizationConnectionNodesOrganization {
+	return v.Nodes
+}
+
+// ListOrganizationsOrganizationsOrganizationConnectionNodesOrganization includes the requested fields of the GraphQL type Organization.
+type ListOrganizationsOrganizationsOrganizationConnectionNodesOrganization struct {
+	// Unique organization slug
+	Slug string `json:"slug"`
+	// Organization name
+	Name string `json:"name"`
+	// The type of organization
+	Type OrganizationType `json:"type"`
+	// The current user's role in the org
+	ViewerRole string `json:"viewerRole"`
+}
+
+// GetSlug returns ListOrganizationsOrganizationsOrganizationConnectionNodesOrganization.Slug, and is useful for accessing the field via an interface.
+func (v *ListOrganizationsOrganizationsOrganizationConnectionNodesOrganization) GetSlug() string {
+	return v.Slug
+}
+
+// GetName returns ListOrganizationsOrganizationsOrganizationConnectionNodesOrganization.Name, and is useful for accessing the field via an interface.
+func (v *ListOrganizationsOrganizationsOrganizationConnectionNodesOrganization) GetName() string {
+	return v.Name
+}
+
+// GetType returns ListOrganizationsOrganizationsOrganizationConnectionNodesOrganization.Type, and is useful for accessing the field via an interface.
+func (v *ListOrganizationsOrganizationsOrganizationConnectionNodesOrganization) GetType() OrganizationType {
+	return v.Type
+}
+
+// GetViewerRole returns ListOrganizationsOrganizationsOrganizationConnectionNodesOrganization.ViewerRole, and is useful for accessing the field via an interface.
+func (v *ListOrganizationsOrganizationsOrganizationConnectionNodesOrganization) GetViewerRole() string {
+	return v.ViewerRole
+}
+
+// ListOrganizationsResponse is returned by ListOrganizations on success.
+type ListOrganizationsResponse struct {
+	Organizations ListOrganizationsOrganizationsOrganizationConnection `json:"organizations"`
+}
+
+// GetOrganizations returns ListOrganizationsResponse.Organizations, and is useful for accessing the field via an interface.
+func (v *ListOrganizationsResponse) GetOrganizations() ListOrganizationsOrganizationsOrganizationConnection {
+	return v.Organizations
+}
+
+// ListPostgresAttachmentsPostgresAttachmentsPostgresClusterAttachmentConnection includes the requested fields of the GraphQL type PostgresClusterAttachmentConnection.
+// The GraphQL type's documentation follows.
+//
+// The connection type for PostgresClusterAttachment.
+type ListPostgresAttachmentsPostgresAttachmentsPostgresClusterAttachmentConnection struct {
+	// A list of nodes.
+	Nodes []ListPostgresAttachmentsPostgresAttachmentsPostgresClusterAttachmentConnectionNodesPostgresClusterAttachment `json:"nodes"`
+}
+
+// GetNodes returns ListPostgresAttachmentsPostgresAttachmentsPostgresClusterAttachmentConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *ListPostgresAttachmentsPostgresAttachmentsPostgresClusterAttachmentConnection) GetNodes() []ListPostgresAttachmentsPostgresAttachmentsPostgresClusterAttachmentConnectionNodesPostgresClusterAttachment {
+	return v.Nodes
+}
+
+// ListPostgresAttachmentsPostgresAttachmentsPostgresClusterAttachmentConnectionNodesPostgresClusterAttachment includes the requested fields of the GraphQL type PostgresClusterAttachment.
+type ListPostgresAttachmentsPostgresAttachmentsPostgresClusterAttachmentConnectionNodesPostgresClusterAttachment struct {
+	DatabaseName string `json:"databaseName"`
+	DatabaseUser string `json:"databaseUser"`
+}
+
+// GetDatabaseName returns ListPostgresAttachmentsPostgresAttachmentsPostgresClusterAttachmentConnectionNodesPostgresClusterAttachment.DatabaseName, and is useful for accessing the field via an interface.
+func (v *ListPostgresAttachmentsPostgresAttachmentsPostgresClusterAttachmentConnectionNodesPostgresClusterAttachment) GetDatabaseName() string {
+	return v.DatabaseName
+}
+
+// GetDatabaseUser returns ListPostgresAttachmentsPostgresAttachmentsPostgresClusterAttachmentConnectionNodesPostgresClusterAttachment.DatabaseUser, and is useful for accessing the field via an interface.
+func (v *ListPostgresAttachmentsPostgresAttachmentsPostgresClusterAttachmentConnectionNodesPostgresClusterAttachment) GetDatabaseUser() string {
+	return v.DatabaseUser
+}
+
+// ListPostgresAttachmentsResponse is returned by ListPostgresAttachments on success.
+type ListPostgresAttachmentsResponse struct {
+	// List postgres attachments
+	PostgresAttachments ListPostgresAttachmentsPostgresAttachmentsPostgresClusterAttachmentConnection `json:"postgresAttachments"`
+}
+
+// GetPostgresAttachments returns ListPostgresAttachmentsResponse.PostgresAttachments, and is useful for accessing the field via an interface.
+func (v *ListPostgresAttachmentsResponse) GetPostgresAttachments() ListPostgresAttachmentsPostgresAttachmentsPostgresClusterAttachmentConnection {
+	return v.PostgresAttachments
+}
+
 // LogOutLogOutLogOutPayload includes the requested fields of the GraphQL type LogOutPayload.
 // The GraphQL type's documentation follows.
 //
@@ -2618,6 +4132,20 @@ func (v *OrganizationData) GetAddOnSsoLink() string { return v.AddOnSsoLink }
 // GetProvisionsBetaExtensions returns OrganizationData.ProvisionsBetaExtensions, and is useful for accessing the field via an interface.
 func (v *OrganizationData) GetProvisionsBetaExtensions() bool { return v.ProvisionsBetaExtensions }
 
+type OrganizationType string
+
+const (
+	// A user's personal organization
+	OrganizationTypePersonal OrganizationType = "PERSONAL"
+	// An organization shared between one or more users
+	OrganizationTypeShared OrganizationType = "SHARED"
+)
+
+var AllOrganizationType = []OrganizationType{
+	OrganizationTypePersonal,
+	OrganizationTypeShared,
+}
+
 type PlatformVersionEnum string
 
 const (
@@ -3043,6 +4571,42 @@ type __GetAppInput struct {
 // GetName returns __GetAppInput.Name, and is useful for accessing the field via an interface.
 func (v *__GetAppInput) GetName() string { return v.Name }
 
+// __GetAppReleaseConfigInput is used internally by genqlient
+type __GetAppReleaseConfigInput struct {
+	AppName string `json:"appName"`
+	Version int    `json:"version"`
+}
+
+// GetAppName returns __GetAppReleaseConfigInput.AppName, and is useful for accessing the field via an interface.
+func (v *__GetAppReleaseConfigInput) GetAppName() string { return v.AppName }
+
+// GetVersion returns __GetAppReleaseConfigInput.Version, and is useful for accessing the field via an interface.
+func (v *__GetAppReleaseConfigInput) GetVersion() int { return v.Version }
+
+// __GetAppReleaseInput is used internally by genqlient
+type __GetAppReleaseInput struct {
+	AppName string `json:"appName"`
+	Version int    `json:"version"`
+}
+
+// GetAppName returns __GetAppReleaseInput.AppName, and is useful for accessing the field via an interface.
+func (v *__GetAppReleaseInput) GetAppName() string { return v.AppName }
+
+// GetVersion returns __GetAppReleaseInput.Version, and is useful for accessing the field via an interface.
+func (v *__GetAppReleaseInput) GetVersion() int { return v.Version }
+
+// __GetAppReleasesMetadataInput is used internally by genqlient
+type __GetAppReleasesMetadataInput struct {
+	AppName string `json:"appName"`
+	Limit   int    `json:"limit"`
+}
+
+// GetAppName returns __GetAppReleasesMetadataInput.AppName, and is useful for accessing the field via an interface.
+func (v *__GetAppReleasesMetadataInput) GetAppName() string { return v.AppName }
+
+// GetLimit returns __GetAppReleasesMetadataInput.Limit, and is useful for accessing the field via an interface.
+func (v *__GetAppReleasesMetadataInput) GetLimit() int { return v.Limit }
+
 // __GetAppWithAddonsInput is used internally by genqlient
 type __GetAppWithAddonsInput struct {
 	Name      string    `json:"name"`
@@ -3067,6 +4631,14 @@ func (v *__GetAppsByRoleInput) GetRole() string { return v.Role }
 // GetOrganizationId returns __GetAppsByRoleInput.OrganizationId, and is useful for accessing the field via an interface.
 func (v *__GetAppsByRoleInput) GetOrganizationId() string { return v.OrganizationId }
 
+// __GetBuildLogsInput is used internally by genqlient
+type __GetBuildLogsInput struct {
+	Id string `json:"id"`
+}
+
+// GetId returns __GetBuildLogsInput.Id, and is useful for accessing the field via an interface.
+func (v *__GetBuildLogsInput) GetId() string { return v.Id }
+
 // __GetExtensionSsoLinkInput is used internally by genqlient
 type __GetExtensionSsoLinkInput struct {
 	OrgSlug  string `json:"orgSlug"`
@@ -3079,6 +4651,14 @@ func (v *__GetExtensionSsoLinkInput) GetOrgSlug() string { return v.OrgSlug }
 // GetProvider returns __GetExtensionSsoLinkInput.Provider, and is useful for accessing the field via an interface.
 func (v *__GetExtensionSsoLinkInput) GetProvider() string { return v.Provider }
 
+// __GetOrganizationBillingStatusInput is used internally by genqlient
+type __GetOrganizationBillingStatusInput struct {
+	Slug string `json:"slug"`
+}
+
+// GetSlug returns __GetOrganizationBillingStatusInput.Slug, and is useful for accessing the field via an interface.
+func (v *__GetOrganizationBillingStatusInput) GetSlug() string { return v.Slug }
+
 // __GetOrganizationInput is used internally by genqlient
 type __GetOrganizationInput struct {
 	Slug string `json:"slug"`
@@ -3103,6 +4683,34 @@ type __ListAddOnsInput struct {
 // GetAddOnType returns __ListAddOnsInput.AddOnType, and is useful for accessing the field via an interface.
 func (v *__ListAddOnsInput) GetAddOnType() AddOnType { return v.AddOnType }
 
+// __ListAppBuildsInput is used internally by genqlient
+type __ListAppBuildsInput struct {
+	AppName string `json:"appName"`
+	Limit   int    `json:"limit"`
+}
+
+// GetAppName returns __ListAppBuildsInput.AppName, and is useful for accessing the field via an interface.
+func (v *__ListAppBuildsInput) GetAppName() string { return v.AppName }
+
+// GetLimit returns __ListAppBuildsInput.Limit, and is useful for accessing the field via an interface.
+func (v *__ListAppBuildsInput) GetLimit() int { return v.Limit }
+
+// __ListAppExtensionsInput is used internally by genqlient
+type __ListAppExtensionsInput struct {
+	AppName string `json:"appName"`
+}
+
+// GetAppName returns __ListAppExtensionsInput.AppName, and is useful for accessing the field via an interface.
+func (v *__ListAppExtensionsInput) GetAppName() string { return v.AppName }
+
+// __ListPostgresAttachmentsInput is used internally by genqlient
+type __ListPostgresAttachmentsInput struct {
+	PostgresAppName string `json:"postgresAppName"`
+}
+
+// GetPostgresAppName returns __ListPostgresAttachmentsInput.PostgresAppName, and is useful for accessing the field via an interface.
+func (v *__ListPostgresAttachmentsInput) GetPostgresAppName() string { return v.PostgresAppName }
+
 // __ResetAddOnPasswordInput is used internally by genqlient
 type __ResetAddOnPasswordInput struct {
 	Name string `json:"name"`
@@ -3434,6 +5042,9 @@ const CreateLimitedAccessToken_Operation = `
 mutation CreateLimitedAccessToken ($name: String!, $organizationId: ID!, $profile: String!, $profileParams: JSON, $expiry: String!) {
 	createLimitedAccessToken(input: {name:$name,organizationId:$organizationId,profile:$profile,profileParams:$profileParams,expiry:$expiry}) {
 		limitedAccessToken {
+			id
+			name
+			expiresAt
 			tokenHeader
 		}
 	}
@@ -3797,6 +5408,141 @@ func GetApp(
 	return data_, err_
 }
 
+// The query executed by GetAppRelease.
+const GetAppRelease_Operation = `
+query GetAppRelease ($appName: String!, $version: Int!) {
+	app(name: $appName) {
+		release(version: $version) {
+			version
+			status
+			deploymentStrategy
+			description
+			reason
+			stable
+			imageRef
+			createdAt
+			user {
+				email
+			}
+		}
+	}
+}
+`
+
+func GetAppRelease(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	appName string,
+	version int,
+) (data_ *GetAppReleaseResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "GetAppRelease",
+		Query:  GetAppRelease_Operation,
+		Variables: &__GetAppReleaseInput{
+			AppName: appName,
+			Version: version,
+		},
+	}
+
+	data_ = &GetAppReleaseResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by GetAppReleaseConfig.
+const GetAppReleaseConfig_Operation = `
+query GetAppReleaseConfig ($appName: String!, $version: Int!) {
+	app(name: $appName) {
+		release(version: $version) {
+			version
+			deploymentStrategy
+			imageRef
+			image {
+				digest
+			}
+			config {
+				definition
+			}
+		}
+	}
+}
+`
+
+func GetAppReleaseConfig(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	appName string,
+	version int,
+) (data_ *GetAppReleaseConfigResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "GetAppReleaseConfig",
+		Query:  GetAppReleaseConfig_Operation,
+		Variables: &__GetAppReleaseConfigInput{
+			AppName: appName,
+			Version: version,
+		},
+	}
+
+	data_ = &GetAppReleaseConfigResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by GetAppReleasesMetadata.
+const GetAppReleasesMetadata_Operation = `
+query GetAppReleasesMetadata ($appName: String!, $limit: Int!) {
+	app(name: $appName) {
+		releases(first: $limit) {
+			nodes {
+				version
+				metadata
+			}
+		}
+	}
+}
+`
+
+func GetAppReleasesMetadata(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	appName string,
+	limit int,
+) (data_ *GetAppReleasesMetadataResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "GetAppReleasesMetadata",
+		Query:  GetAppReleasesMetadata_Operation,
+		Variables: &__GetAppReleasesMetadataInput{
+			AppName: appName,
+			Limit:   limit,
+		},
+	}
+
+	data_ = &GetAppReleasesMetadataResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
 // The query executed by GetAppWithAddons.
 const GetAppWithAddons_Operation = `
 query GetAppWithAddons ($name: String!, $addOnType: AddOnType!) {
@@ -3925,6 +5671,44 @@ func GetAppsByRole(
 	return data_, err_
 }
 
+// The query executed by GetBuildLogs.
+const GetBuildLogs_Operation = `
+query GetBuildLogs ($id: ID!) {
+	node(id: $id) {
+		__typename
+		... on Build {
+			inProgress
+			logs
+		}
+	}
+}
+`
+
+func GetBuildLogs(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+) (data_ *GetBuildLogsResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "GetBuildLogs",
+		Query:  GetBuildLogs_Operation,
+		Variables: &__GetBuildLogsInput{
+			Id: id,
+		},
+	}
+
+	data_ = &GetBuildLogsResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
 // The query executed by GetExtensionSsoLink.
 const GetExtensionSsoLink_Operation = `
 query GetExtensionSsoLink ($orgSlug: String!, $provider: String!) {
@@ -4035,6 +5819,40 @@ func GetOrganization(
 	return data_, err_
 }
 
+// The query executed by GetOrganizationBillingStatus.
+const GetOrganizationBillingStatus_Operation = `
+query GetOrganizationBillingStatus ($slug: String!) {
+	organization(slug: $slug) {
+		billingStatus
+	}
+}
+`
+
+func GetOrganizationBillingStatus(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	slug string,
+) (data_ *GetOrganizationBillingStatusResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "GetOrganizationBillingStatus",
+		Query:  GetOrganizationBillingStatus_Operation,
+		Variables: &__GetOrganizationBillingStatusInput{
+			Slug: slug,
+		},
+	}
+
+	data_ = &GetOrganizationBillingStatusResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
 // The query executed by ListAddOnPlans.
 const ListAddOnPlans_Operation = `
 query ListAddOnPlans ($addOnType: AddOnType!) {
@@ -4125,6 +5943,166 @@ func ListAddOns(
 	return data_, err_
 }
 
+// The query executed by ListAppBuilds.
+const ListAppBuilds_Operation = `
+query ListAppBuilds ($appName: String!, $limit: Int!) {
+	app(name: $appName) {
+		builds(first: $limit) {
+			nodes {
+				id
+				number
+				status
+				image
+				createdAt
+				createdBy {
+					email
+				}
+			}
+		}
+	}
+}
+`
+
+func ListAppBuilds(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	appName string,
+	limit int,
+) (data_ *ListAppBuildsResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "ListAppBuilds",
+		Query:  ListAppBuilds_Operation,
+		Variables: &__ListAppBuildsInput{
+			AppName: appName,
+			Limit:   limit,
+		},
+	}
+
+	data_ = &ListAppBuildsResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by ListAppExtensions.
+const ListAppExtensions_Operation = `
+query ListAppExtensions ($appName: String!) {
+	app(name: $appName) {
+		addOns {
+			nodes {
+				name
+				addOnProvider {
+					displayName
+				}
+			}
+		}
+	}
+}
+`
+
+func ListAppExtensions(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	appName string,
+) (data_ *ListAppExtensionsResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "ListAppExtensions",
+		Query:  ListAppExtensions_Operation,
+		Variables: &__ListAppExtensionsInput{
+			AppName: appName,
+		},
+	}
+
+	data_ = &ListAppExtensionsResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by ListOrganizations.
+const ListOrganizations_Operation = `
+query ListOrganizations {
+	organizations {
+		nodes {
+			slug
+			name
+			type
+			viewerRole
+		}
+	}
+}
+`
+
+func ListOrganizations(
+	ctx_ context.Context,
+	client_ graphql.Client,
+) (data_ *ListOrganizationsResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "ListOrganizations",
+		Query:  ListOrganizations_Operation,
+	}
+
+	data_ = &ListOrganizationsResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by ListPostgresAttachments.
+const ListPostgresAttachments_Operation = `
+query ListPostgresAttachments ($postgresAppName: String!) {
+	postgresAttachments(postgresAppName: $postgresAppName) {
+		nodes {
+			databaseName
+			databaseUser
+		}
+	}
+}
+`
+
+func ListPostgresAttachments(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	postgresAppName string,
+) (data_ *ListPostgresAttachmentsResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "ListPostgresAttachments",
+		Query:  ListPostgresAttachments_Operation,
+		Variables: &__ListPostgresAttachmentsInput{
+			PostgresAppName: postgresAppName,
+		},
+	}
+
+	data_ = &ListPostgresAttachmentsResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
 // The mutation executed by LogOut.
 const LogOut_Operation = `
 mutation LogOut {
@@ -74,6 +74,9 @@ type Config struct {
 	// Path to application configuration file, usually fly.toml.
 	configFilePath string
 
+	// Path to the overlay merged over the config file, if any.
+	overlayFilePath string
+
 	// Set when it fails to unmarshal fly.toml into Config
 	v2UnmarshalError error
 
@@ -0,0 +1,100 @@
+package appconfig
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// SettingChange is a setting that differs between two configs, by its dotted
+// path, with its JSON value in each. Old is empty for an added setting, and
+// New for a removed one.
+type SettingChange struct {
+	Setting string
+	Old     string `json:",omitempty"`
+	New     string `json:",omitempty"`
+}
+
+// Added reports whether the setting is only set in the new config.
+func (c SettingChange) Added() bool { return c.Old == "" }
+
+// Removed reports whether the setting is only set in the old config.
+func (c SettingChange) Removed() bool { return c.New == "" }
+
+// Diff lists the settings that differ between the old and new configs,
+// sorted by setting. Lists are compared as a whole.
+func Diff(old, new *Config) ([]SettingChange, error) {
+	oldSettings, err := flattenConfig(old)
+	if err != nil {
+		return nil, err
+	}
+	newSettings, err := flattenConfig(new)
+	if err != nil {
+		return nil, err
+	}
+
+	union := maps.Clone(oldSettings)
+	maps.Copy(union, newSettings)
+
+	var changes []SettingChange
+	for _, setting := range slices.Sorted(maps.Keys(union)) {
+		if o, n := oldSettings[setting], newSettings[setting]; o != n {
+			changes = append(changes, SettingChange{Setting: setting, Old: o, New: n})
+		}
+	}
+	return changes, nil
+}
+
+// TOMLDiff returns a line by line diff of the fly.toml of the old and new
+// configs, with additions in green and deletions in red, or "" when they
+// are the same.
+func TOMLDiff(old, new *Config, colorize *iostreams.ColorScheme) (string, error) {
+	oldTOML, err := old.marshalTOML()
+	if err != nil {
+		return "", err
+	}
+	newTOML, err := new.marshalTOML()
+	if err != nil {
+		return "", err
+	}
+	if string(oldTOML) == string(newTOML) {
+		return "", nil
+	}
+	return prettyDiff(string(oldTOML), string(newTOML), colorize), nil
+}
+
+// flattenConfig returns the settings of cfg by their dotted path, with their
+// JSON value.
+func flattenConfig(cfg *Config) (map[string]string, error) {
+	definition, err := cfg.ToDefinition()
+	if err != nil {
+		return nil, err
+	}
+	settings := map[string]string{}
+	for key, value := range *definition {
+		if err := flattenSetting(settings, key, value); err != nil {
+			return nil, err
+		}
+	}
+	return settings, nil
+}
+
+func flattenSetting(settings map[string]string, path string, value any) error {
+	if m, ok := value.(map[string]any); ok {
+		for key, v := range m {
+			if err := flattenSetting(settings, path+"."+key, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	settings[path] = string(b)
+	return nil
+}
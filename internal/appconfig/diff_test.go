@@ -0,0 +1,42 @@
+package appconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestDiff(t *testing.T) {
+	old := NewConfig()
+	old.PrimaryRegion = "ord"
+	old.Env = map[string]string{"A": "1", "B": "2"}
+
+	new := NewConfig()
+	new.PrimaryRegion = "ams"
+	new.Env = map[string]string{"B": "2", "C": "3"}
+
+	changes, err := Diff(old, new)
+	require.NoError(t, err)
+	assert.Equal(t, []SettingChange{
+		{Setting: "env.A", Old: `"1"`},
+		{Setting: "env.C", New: `"3"`},
+		{Setting: "primary_region", Old: `"ord"`, New: `"ams"`},
+	}, changes)
+	assert.True(t, changes[0].Removed())
+	assert.True(t, changes[1].Added())
+	assert.False(t, changes[2].Added() || changes[2].Removed())
+
+	changes, err = Diff(old, old)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	ios, _, _, _ := iostreams.Test()
+	diff, err := TOMLDiff(old, old, ios.ColorScheme())
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+	diff, err = TOMLDiff(old, new, ios.ColorScheme())
+	require.NoError(t, err)
+	assert.Contains(t, diff, "primary_region = 'ams'")
+}
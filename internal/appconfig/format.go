@@ -0,0 +1,156 @@
+package appconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format returns the config file contents buf, in the format implied by the
+// extension of path, in their canonical form: the config is parsed, the
+// migration patches are applied, and it is marshaled back with a stable key
+// order and indentation. The comment lines at the top of the file are kept.
+// Marshaling would drop any other comment, so files with more comments
+// aren't formatted.
+func Format(path string, buf []byte) ([]byte, error) {
+	var (
+		cfg    *Config
+		err    error
+		format = strings.TrimLeft(strings.ToLower(filepath.Ext(path)), ".")
+	)
+	switch format {
+	case "json":
+		cfg, err = unmarshalJSON(buf)
+	case "yaml":
+		cfg, err = unmarshalYAML(buf)
+	default:
+		format = "toml"
+		cfg, err = unmarshalTOML(buf)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cfg.v2UnmarshalError != nil {
+		return nil, fmt.Errorf("can't format an invalid config: %w", cfg.v2UnmarshalError)
+	}
+	if format != "json" && hasComments(format, buf[len(leadingComments(buf)):]) {
+		return nil, fmt.Errorf("can't format a config with comments other than the ones at the top of the file, they would be dropped")
+	}
+
+	var out bytes.Buffer
+	switch format {
+	case "json":
+		b, err := json.MarshalIndent(cfg, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := prettyPrintJSONandYAML(&out, b); err != nil {
+			return nil, err
+		}
+	case "yaml":
+		b, err := cfg.MarshalAsYAML()
+		if err != nil {
+			return nil, err
+		}
+		out.Write(leadingComments(buf))
+		if _, err := prettyPrintJSONandYAML(&out, b); err != nil {
+			return nil, err
+		}
+	default:
+		b, err := cfg.marshalTOML()
+		if err != nil {
+			return nil, err
+		}
+		out.Write(leadingComments(buf))
+		out.Write(b)
+	}
+	return out.Bytes(), nil
+}
+
+// leadingComments returns the comment lines at the top of a TOML or YAML
+// file, like the header fly.toml files are generated with, and the blank
+// lines following them.
+func leadingComments(buf []byte) []byte {
+	var (
+		end      int
+		comments bool
+	)
+	for rest := buf; len(rest) > 0; {
+		line, next, found := bytes.Cut(rest, []byte("\n"))
+		trimmed := bytes.TrimSpace(line)
+		switch {
+		case bytes.HasPrefix(trimmed, []byte("#")):
+			comments = true
+		case len(trimmed) != 0:
+			if !comments {
+				return nil
+			}
+			return buf[:end]
+		}
+		end = len(buf) - len(next)
+		if !found {
+			end = len(buf)
+		}
+		rest = next
+	}
+	if !comments {
+		return nil
+	}
+	return buf[:end]
+}
+
+// hasComments reports whether the TOML or YAML document buf has comments.
+func hasComments(format string, buf []byte) bool {
+	if format == "yaml" {
+		var doc yaml.Node
+		return yaml.Unmarshal(buf, &doc) == nil && yamlNodeHasComments(&doc)
+	}
+	return tomlHasComments(buf)
+}
+
+func yamlNodeHasComments(n *yaml.Node) bool {
+	if n.HeadComment != "" || n.LineComment != "" || n.FootComment != "" {
+		return true
+	}
+	return slices.ContainsFunc(n.Content, yamlNodeHasComments)
+}
+
+// tomlHasComments scans buf for a "#" outside of strings. The TOML parser
+// doesn't report comments trailing a key/value or a table header.
+func tomlHasComments(buf []byte) bool {
+	for i := 0; i < len(buf); i++ {
+		switch {
+		case buf[i] == '#':
+			return true
+		case bytes.HasPrefix(buf[i:], []byte(`"""`)):
+			i = skipTOMLString(buf, i+3, `"""`, true)
+		case bytes.HasPrefix(buf[i:], []byte("'''")):
+			i = skipTOMLString(buf, i+3, "'''", false)
+		case buf[i] == '"':
+			i = skipTOMLString(buf, i+1, `"`, true)
+		case buf[i] == '\'':
+			i = skipTOMLString(buf, i+1, "'", false)
+		}
+	}
+	return false
+}
+
+// skipTOMLString returns the index of the last byte of the string starting
+// at buf[start:] and ending with delim, honoring backslash escapes in basic
+// strings.
+func skipTOMLString(buf []byte, start int, delim string, escapes bool) int {
+	for i := start; i < len(buf); i++ {
+		switch {
+		case escapes && buf[i] == '\\':
+			i++
+		case bytes.HasPrefix(buf[i:], []byte(delim)):
+			return i + len(delim) - 1
+		}
+	}
+	return len(buf)
+}
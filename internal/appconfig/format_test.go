@@ -0,0 +1,131 @@
+package appconfig
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatIsStable(t *testing.T) {
+	paths, err := filepath.Glob("./testdata/*.toml")
+	require.NoError(t, err)
+
+	for _, path := range paths {
+		if filepath.Base(path) == "always-invalid-v2.toml" {
+			continue
+		}
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			buf, err := os.ReadFile(path)
+			require.NoError(t, err)
+			buf = stripTOMLComments(buf)
+
+			formatted, err := Format(path, buf)
+			require.NoError(t, err)
+			again, err := Format(path, formatted)
+			require.NoError(t, err)
+			assert.Equal(t, string(formatted), string(again))
+
+			before, err := unmarshalTOML(buf)
+			require.NoError(t, err)
+			after, err := unmarshalTOML(formatted)
+			require.NoError(t, err)
+			beforeJSON, err := json.Marshal(before)
+			require.NoError(t, err)
+			afterJSON, err := json.Marshal(after)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(beforeJSON), string(afterJSON))
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	const config = `# fly.toml app configuration file
+#
+
+primary_region = "ord"
+  app = "my-app"
+
+[http_service]
+internal_port = 8080
+  force_https = true
+
+[env]
+  B = "2"
+  A = "1"
+`
+	formatted, err := Format("fly.toml", []byte(config))
+	require.NoError(t, err)
+	assert.Equal(t, `# fly.toml app configuration file
+#
+
+app = 'my-app'
+primary_region = 'ord'
+
+[env]
+  A = '1'
+  B = '2'
+
+[http_service]
+  internal_port = 8080
+  force_https = true
+`, string(formatted))
+
+	_, err = Format("fly.toml", []byte("# header\napp = \"my-app\" # trailing\n"))
+	assert.ErrorContains(t, err, "comments other than the ones at the top of the file")
+	_, err = Format("fly.yaml", []byte("# header\napp: my-app\n# env\nprimary_region: ord\n"))
+	assert.ErrorContains(t, err, "comments other than the ones at the top of the file")
+	formatted, err = Format("fly.yaml", []byte("# header\napp: my-app\nprimary_region: \"#ord\"\n"))
+	require.NoError(t, err)
+	assert.Contains(t, string(formatted), "# header\n")
+
+	_, err = Format("fly.toml", []byte("app = \"my-app\"\n[[services]]\ninternal_port = \"not a number\"\n"))
+	assert.ErrorContains(t, err, "can't format an invalid config")
+
+	formatted, err = Format("fly.json", []byte(`{"primary_region": "ord", "app": "my-app"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"app\": \"my-app\",\n  \"primary_region\": \"ord\"\n}\n", string(formatted))
+}
+
+func TestTOMLHasComments(t *testing.T) {
+	for buf, want := range map[string]bool{
+		"app = 'x'\n":                       false,
+		"app = 'x' # comment\n":             true,
+		"[env] # comment\n":                 true,
+		"# comment\napp = 'x'\n":            true,
+		`app = "#x"`:                        false,
+		"app = '#x'":                        false,
+		`app = "\"#x"`:                      false,
+		"cmd = \"\"\"\n# not one\n\"\"\"\n": false,
+		"cmd = '''\n# not one\n'''\n":       false,
+		"cmd = '''x'''\n# one\n":            true,
+	} {
+		assert.Equal(t, want, tomlHasComments([]byte(buf)), buf)
+	}
+}
+
+// stripTOMLComments removes the comments of the config files in testdata,
+// which only have comment lines and comments trailing a value.
+func stripTOMLComments(buf []byte) []byte {
+	var out []byte
+	for _, line := range strings.SplitAfter(string(buf), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		if tomlHasComments([]byte(line)) {
+			line = line[:strings.LastIndex(line, " #")] + "\n"
+		}
+		out = append(out, line...)
+	}
+	return out
+}
+
+func TestLeadingComments(t *testing.T) {
+	assert.Equal(t, "# a\n# b\n\n", string(leadingComments([]byte("# a\n# b\n\napp = 'x'\n# c\n"))))
+	assert.Equal(t, "", string(leadingComments([]byte("app = 'x'\n# c\n"))))
+	assert.Equal(t, "# only", string(leadingComments([]byte("# only"))))
+}
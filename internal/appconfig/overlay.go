@@ -0,0 +1,102 @@
+package appconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v2"
+)
+
+// OverlayPath returns the path of the overlay for env that sits next to the
+// config at path, e.g. fly.staging.toml for fly.toml.
+func OverlayPath(path, env string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + env + ext
+}
+
+// LoadConfigWithOverlay loads the config at path and deep merges the overlay
+// for env over it. Scalars in the overlay replace the base values, tables
+// are merged key by key and arrays replace the base arrays as a whole. The
+// merged config can't be written back to either file.
+func LoadConfigWithOverlay(path, env string) (*Config, error) {
+	base, err := readConfigMap(path)
+	if err != nil {
+		return nil, err
+	}
+
+	overlayPath := OverlayPath(path, env)
+	overlay, err := readConfigMap(overlayPath)
+	if err != nil {
+		// Not wrapped: a missing overlay must not read as a missing config.
+		return nil, fmt.Errorf("failed loading %s config overlay: %v", env, err)
+	}
+
+	cfg, err := applyPatches(mergeConfigMaps(base, overlay))
+	// In case of parsing error fallback to bare compatibility, like LoadConfig
+	if err != nil {
+		// Read again due to in-place map updates performed by patches
+		raw, rerr := readConfigMap(path)
+		if rerr != nil {
+			return nil, rerr
+		}
+		rawOverlay, rerr := readConfigMap(overlayPath)
+		if rerr != nil {
+			return nil, rerr
+		}
+		raw = mergeConfigMaps(raw, rawOverlay)
+		cfg = &Config{v2UnmarshalError: err}
+		if name, ok := (raw["app"]).(string); ok {
+			cfg.AppName = name
+		}
+	}
+
+	cfg.configFilePath = path
+	cfg.overlayFilePath = overlayPath
+	return cfg, nil
+}
+
+// readConfigMap decodes the config file at path without interpreting it.
+func readConfigMap(path string) (map[string]any, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfgMap := map[string]any{}
+	switch {
+	case strings.HasSuffix(path, ".json"):
+		err = json.Unmarshal(buf, &cfgMap)
+	case strings.HasSuffix(path, ".yaml"):
+		if err = yaml.Unmarshal(buf, &cfgMap); err == nil {
+			stringifyYAMLMapKeys(cfgMap)
+		}
+	default:
+		err = toml.Unmarshal(buf, &cfgMap)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing %s: %w", path, err)
+	}
+	return cfgMap, nil
+}
+
+// mergeConfigMaps merges overlay into base, recursing into tables present in
+// both. Anything else in overlay, arrays included, replaces the base value.
+func mergeConfigMaps(base, overlay map[string]any) map[string]any {
+	for k, v := range overlay {
+		overlayTable, ok := v.(map[string]any)
+		if !ok {
+			base[k] = v
+			continue
+		}
+		if baseTable, ok := base[k].(map[string]any); ok {
+			base[k] = mergeConfigMaps(baseTable, overlayTable)
+		} else {
+			base[k] = v
+		}
+	}
+	return base
+}
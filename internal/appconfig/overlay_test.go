@@ -0,0 +1,87 @@
+package appconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverlayPath(t *testing.T) {
+	assert.Equal(t, "fly.staging.toml", OverlayPath("fly.toml", "staging"))
+	assert.Equal(t, "/app/fly.prod.yaml", OverlayPath("/app/fly.yaml", "prod"))
+}
+
+func TestLoadConfigWithOverlay(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fly.toml")
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+app = "my-app"
+primary_region = "ord"
+
+[env]
+  LOG_LEVEL = "info"
+  PORT = "8080"
+
+[http_service]
+  internal_port = 8080
+  force_https = true
+
+[[vm]]
+  size = "shared-cpu-1x"
+
+[[vm]]
+  size = "shared-cpu-2x"
+`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "fly.staging.toml"), []byte(`
+app = "my-app-staging"
+
+[env]
+  LOG_LEVEL = "debug"
+
+[http_service]
+  force_https = false
+
+[[vm]]
+  size = "performance-1x"
+`), 0o644))
+
+	cfg, err := LoadConfigWithOverlay(path, "staging")
+	require.NoError(t, err)
+
+	assert.Equal(t, "my-app-staging", cfg.AppName)
+	assert.Equal(t, "ord", cfg.PrimaryRegion)
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "debug", "PORT": "8080"}, cfg.Env)
+	require.NotNil(t, cfg.HTTPService)
+	assert.Equal(t, 8080, cfg.HTTPService.InternalPort)
+	assert.False(t, cfg.HTTPService.ForceHTTPS)
+	require.Len(t, cfg.Compute, 1)
+	assert.Equal(t, "performance-1x", cfg.Compute[0].Size)
+	assert.Equal(t, path, cfg.ConfigFilePath())
+
+	assert.ErrorContains(t, cfg.WriteToFile(path), "loaded with the overlay")
+	assert.ErrorContains(t, cfg.WriteToFile(filepath.Join(dir, "fly.staging.toml")), "loaded with the overlay")
+	require.NoError(t, cfg.WriteToFile(filepath.Join(dir, "merged.toml")))
+
+	_, err = LoadConfigWithOverlay(path, "production")
+	assert.ErrorContains(t, err, "failed loading production config overlay")
+	assert.NotErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestLoadConfigWithOverlayInvalidV2(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fly.toml")
+	buf, err := os.ReadFile("./testdata/always-invalid-v2.toml")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, buf, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "fly.staging.toml"), []byte(`app = "unsupported-staging"`), 0o644))
+
+	cfg, err := LoadConfigWithOverlay(path, "staging")
+	require.NoError(t, err, "the base loads like it does without an overlay")
+	assert.Error(t, cfg.v2UnmarshalError)
+	assert.Equal(t, "unsupported-staging", cfg.AppName)
+	assert.Equal(t, path, cfg.ConfigFilePath())
+}
@@ -596,10 +596,10 @@ func ensureArrayOfMap(raw any) ([]map[string]any, error) {
 	out := []map[string]any{}
 	switch cast := raw.(type) {
 	case []any:
-		for _, rawItem := range cast {
+		for idx, rawItem := range cast {
 			item, ok := rawItem.(map[string]any)
 			if !ok {
-				return nil, fmt.Errorf("Can not cast '%s' of type '%t' as map[string]any", rawItem, rawItem)
+				return nil, fmt.Errorf("Can not cast item at index %d '%v' of type '%T' as map[string]any", idx, rawItem, rawItem)
 			}
 			out = append(out, item)
 		}
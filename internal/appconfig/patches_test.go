@@ -0,0 +1,19 @@
+package appconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureArrayOfMapScalarItem(t *testing.T) {
+	raw := []any{
+		map[string]any{"internal_port": 8080},
+		"http",
+	}
+
+	_, err := ensureArrayOfMap(raw)
+	require.Error(t, err)
+	assert.Equal(t, "Can not cast item at index 1 'http' of type 'string' as map[string]any", err.Error())
+}
@@ -29,6 +29,17 @@ func FromRemoteApp(ctx context.Context, appName string) (*Config, error) {
 	return cfg, nil
 }
 
+// FromCurrentRelease returns the config of the app's current release, or nil
+// if the app has no release with a config yet.
+func FromCurrentRelease(ctx context.Context, appName string) (*Config, error) {
+	cfg, err := getAppV2ConfigFromReleases(ctx, flyutil.ClientFromContext(ctx), appName)
+	if cfg == nil || err != nil {
+		return nil, err
+	}
+	cfg.AppName = appName
+	return cfg, nil
+}
+
 func getAppV2ConfigFromMachines(ctx context.Context, appName string) (*Config, error) {
 	flapsClient := flapsutil.ClientFromContext(ctx)
 	io := iostreams.FromContext(ctx)
@@ -85,28 +85,85 @@ func (c *Config) WriteTo(w io.Writer, format string) (int64, error) {
 	}
 }
 
+// WriteToFile writes the config to filename, in the format implied by its
+// extension. The file is replaced atomically, so a failed write leaves any
+// existing file untouched. A config loaded with an overlay can't be written
+// to its config file or overlay, which would bake the merge into them.
 func (c *Config) WriteToFile(filename string) (err error) {
+	if c.overlayFilePath != "" && (samePath(filename, c.configFilePath) || samePath(filename, c.overlayFilePath)) {
+		return fmt.Errorf("can't write the config to %s: it was loaded with the overlay %s merged in", filename, c.overlayFilePath)
+	}
 	if err = helpers.MkdirAll(filename); err != nil {
 		return
 	}
 
-	var file *os.File
-	if file, err = os.Create(filename); err != nil {
-		return
+	format := strings.TrimLeft(strings.ToLower(filepath.Ext(filename)), ".")
+	return writeFileAtomic(filename, func(w io.Writer) error {
+		_, err := c.WriteTo(w, format)
+		return err
+	})
+}
+
+// WriteFileAtomic replaces filename with data atomically, keeping the mode of
+// the existing file.
+func WriteFileAtomic(filename string, data []byte) error {
+	return writeFileAtomic(filename, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// samePath reports whether a and b name the same file path.
+func samePath(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return absA == absB
+}
+
+// writeFileAtomic writes to a temporary file next to filename and renames it
+// into place once write has succeeded. If filename is a symlink, the file it
+// points to is replaced instead, and the existing file's mode is kept.
+func writeFileAtomic(filename string, write func(io.Writer) error) (err error) {
+	if resolved, err := filepath.EvalSymlinks(filename); err == nil {
+		filename = resolved
+	}
+
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(filename); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".*")
+	if err != nil {
+		return err
 	}
 	defer func() {
-		if e := file.Close(); err == nil {
-			err = e
+		if err != nil {
+			file.Close()
+			os.Remove(file.Name())
 		}
 	}()
 
-	_, err = c.WriteTo(file, strings.TrimLeft(strings.ToLower(filepath.Ext(filename)), "."))
-	return
+	if err = write(file); err != nil {
+		return err
+	}
+	if err = file.Chmod(mode); err != nil {
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), filename)
 }
 
 func (c *Config) WriteToDisk(ctx context.Context, path string) (err error) {
 	io := iostreams.FromContext(ctx)
-	err = c.WriteToFile(path)
+	if err = c.WriteToFile(path); err != nil {
+		return
+	}
 	fmt.Fprintf(io.Out, "Wrote config file %s\n", helpers.PathRelativeToCWD(path))
 	return
 }
@@ -1,7 +1,9 @@
 package appconfig
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
@@ -694,6 +696,70 @@ func TestYAMLPrettyPrint(t *testing.T) {
 	assert.Contains(t, string(buf), "\n    processes:\n      - web\n")
 }
 
+func TestWriteFileAtomicFailureKeepsOriginal(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fly.toml")
+	require.NoError(t, os.WriteFile(path, []byte("app = \"original\"\n"), 0o600))
+
+	err := writeFileAtomic(path, func(w io.Writer) error {
+		if _, err := w.Write([]byte("app = \"part")); err != nil {
+			return err
+		}
+		return errors.New("boom")
+	})
+	require.ErrorContains(t, err, "boom")
+
+	buf, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "app = \"original\"\n", string(buf))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary file should be removed")
+}
+
+func TestWriteToFileKeepsPermissions(t *testing.T) {
+	cfg, err := LoadConfig("./testdata/full-reference.toml")
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "fly.toml")
+	require.NoError(t, os.WriteFile(path, []byte("app = \"original\"\n"), 0o600))
+	require.NoError(t, cfg.WriteToFile(path))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	newCfg, err := LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, "foo", newCfg.AppName)
+}
+
+func TestWriteToFileFollowsSymlinks(t *testing.T) {
+	cfg, err := LoadConfig("./testdata/full-reference.toml")
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "configs", "fly.production.toml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(target), 0o755))
+	require.NoError(t, os.WriteFile(target, []byte("app = \"original\"\n"), 0o640))
+	link := filepath.Join(dir, "fly.toml")
+	require.NoError(t, os.Symlink(filepath.Join("configs", "fly.production.toml"), link))
+
+	require.NoError(t, cfg.WriteToFile(link))
+
+	info, err := os.Lstat(link)
+	require.NoError(t, err)
+	assert.Equal(t, os.ModeSymlink, info.Mode().Type(), "the symlink is kept")
+	info, err = os.Stat(target)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+
+	newCfg, err := LoadConfig(target)
+	require.NoError(t, err)
+	assert.Equal(t, "foo", newCfg.AppName)
+}
+
 func UintPointer(v uint32) *uint32 {
 	return &v
 }
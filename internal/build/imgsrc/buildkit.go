@@ -7,9 +7,12 @@ import (
 	"strconv"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/registry"
 	dockerclient "github.com/docker/docker/client"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/session/auth"
+	"github.com/moby/buildkit/util/progress/progressui"
+	"github.com/superfly/flyctl/iostreams"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -31,14 +34,25 @@ func buildkitEnabled(docker *dockerclient.Client) (buildkitEnabled bool, err err
 	return buildkitEnabled, nil
 }
 
-func newBuildkitAuthProvider(token string) session.Attachable {
+// progressDisplayMode picks how BuildKit renders build progress. Quiet mode
+// hides the progress display entirely.
+func progressDisplayMode(streams *iostreams.IOStreams) progressui.DisplayMode {
+	if streams.IsQuiet() {
+		return progressui.QuietMode
+	}
+	return progressui.AutoMode
+}
+
+func newBuildkitAuthProvider(token string, extra *registry.AuthConfig) session.Attachable {
 	return &buildkitAuthProvider{
 		token: token,
+		extra: extra,
 	}
 }
 
 type buildkitAuthProvider struct {
 	token string
+	extra *registry.AuthConfig
 }
 
 func (ap *buildkitAuthProvider) Register(server *grpc.Server) {
@@ -46,7 +60,7 @@ func (ap *buildkitAuthProvider) Register(server *grpc.Server) {
 }
 
 func (ap *buildkitAuthProvider) Credentials(ctx context.Context, req *auth.CredentialsRequest) (*auth.CredentialsResponse, error) {
-	auths := authConfigs(ap.token)
+	auths := withRegistryAuth(authConfigs(ap.token), ap.extra)
 	res := &auth.CredentialsResponse{}
 	if a, ok := auths[req.Host]; ok {
 		res.Username = a.Username
@@ -8,6 +8,7 @@ import (
 
 	packclient "github.com/buildpacks/pack/pkg/client"
 	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/pkg/errors"
 	"github.com/superfly/flyctl/internal/cmdfmt"
 	"github.com/superfly/flyctl/internal/metrics"
@@ -24,6 +25,15 @@ func (*buildpacksBuilder) Name() string {
 	return "Buildpacks"
 }
 
+// validateBuilderReference makes sure the configured CNB builder is a usable
+// image reference before we spin up a docker client for it.
+func validateBuilderReference(builder string) error {
+	if _, err := name.ParseReference(builder); err != nil {
+		return fmt.Errorf("invalid buildpacks builder %q: %w", builder, err)
+	}
+	return nil
+}
+
 func returnTrue(s string) bool {
 	return true
 }
@@ -50,6 +60,10 @@ func (*buildpacksBuilder) Run(ctx context.Context, dockerFactory *dockerClientFa
 	}
 
 	builder := opts.Builder
+	if err := validateBuilderReference(builder); err != nil {
+		build.BuildFinish()
+		return nil, "", err
+	}
 	buildpacks := opts.Buildpacks
 
 	span.SetAttributes(attribute.StringSlice("buildpacks", buildpacks))
@@ -75,6 +89,8 @@ func (*buildpacksBuilder) Run(ctx context.Context, dockerFactory *dockerClientFa
 	}
 	build.BuilderInitFinish()
 
+	warnIgnoredBuildSecrets(streams, opts, "buildpacks builds")
+
 	build.ImageBuildStart()
 	serverInfo, err := docker.Info(ctx)
 	if err != nil {
@@ -158,7 +174,7 @@ func (*buildpacksBuilder) Run(ctx context.Context, dockerFactory *dockerClientFa
 		build.PushStart()
 		cmdfmt.PrintBegin(streams.ErrOut, "Pushing image to fly")
 
-		if err := pushToFly(ctx, docker, streams, opts.Tag); err != nil {
+		if err := pushToFly(ctx, docker, streams, opts.Tag, opts.RegistryAuth); err != nil {
 			build.PushFinish()
 			return nil, "", err
 		}
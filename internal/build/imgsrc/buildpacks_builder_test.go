@@ -0,0 +1,52 @@
+package imgsrc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	dockerclient "github.com/docker/docker/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateBuilderReference(t *testing.T) {
+	assert.NoError(t, validateBuilderReference("heroku/builder:24"))
+	assert.NoError(t, validateBuilderReference("paketobuildpacks/builder-jammy-base"))
+	assert.NoError(t, validateBuilderReference("registry.example.com:5000/team/builder@sha256:0000000000000000000000000000000000000000000000000000000000000000"))
+
+	assert.Error(t, validateBuilderReference("Heroku/Builder"))
+	assert.Error(t, validateBuilderReference("builder:bad tag"))
+}
+
+func TestBuildpacksBuilderUsesConfiguredBuilder(t *testing.T) {
+	errDocker := errors.New("no docker here")
+
+	var calls int
+	factory := &dockerClientFactory{
+		mode: DockerDaemonTypeLocal,
+		buildFn: func(ctx context.Context, build *build) (*dockerclient.Client, error) {
+			calls++
+			return nil, errDocker
+		},
+	}
+
+	bp := &buildpacksBuilder{}
+
+	// An invalid builder is rejected before a docker client is requested.
+	_, _, err := bp.Run(context.Background(), factory, nil, ImageOptions{Builder: "Not A Builder"}, newBuild("", false))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid buildpacks builder "Not A Builder"`)
+	assert.Equal(t, 0, calls)
+
+	// A valid builder makes it through to the docker client.
+	_, _, err = bp.Run(context.Background(), factory, nil, ImageOptions{Builder: "heroku/builder:24"}, newBuild("", false))
+	assert.ErrorIs(t, err, errDocker)
+	assert.Equal(t, 1, calls)
+
+	// Without a builder the strategy is skipped.
+	img, note, err := bp.Run(context.Background(), factory, nil, ImageOptions{}, newBuild("", false))
+	assert.NoError(t, err)
+	assert.Nil(t, img)
+	assert.Equal(t, "no buildpack builder configured, skipping", note)
+}
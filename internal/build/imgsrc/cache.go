@@ -0,0 +1,88 @@
+package imgsrc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moby/buildkit/client"
+	dockerparser "github.com/novln/docker-parser"
+)
+
+// parseCacheOptions converts --cache-from/--cache-to values into BuildKit
+// cache entries. A value is either a bare image reference, which is treated
+// as a registry cache, or a comma-separated list of key=value attributes as
+// accepted by `docker buildx build`, e.g. "type=registry,ref=repo/app:cache,mode=max".
+func parseCacheOptions(values []string) ([]client.CacheOptionsEntry, error) {
+	var entries []client.CacheOptionsEntry
+
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+
+		entry := client.CacheOptionsEntry{Attrs: map[string]string{}}
+
+		if !strings.Contains(value, "=") {
+			entry.Type = "registry"
+			entry.Attrs["ref"] = value
+		} else {
+			for _, field := range strings.Split(value, ",") {
+				k, v, ok := strings.Cut(field, "=")
+				if !ok {
+					return nil, fmt.Errorf("invalid cache option %q: %q must be in the form key=value", value, field)
+				}
+				if k == "type" {
+					entry.Type = v
+				} else {
+					entry.Attrs[k] = v
+				}
+			}
+		}
+
+		switch entry.Type {
+		case "":
+			return nil, fmt.Errorf("invalid cache option %q: missing type", value)
+		case "registry":
+			ref, ok := entry.Attrs["ref"]
+			if !ok {
+				return nil, fmt.Errorf("invalid cache option %q: registry cache requires a ref", value)
+			}
+			if _, err := dockerparser.Parse(ref); err != nil {
+				return nil, fmt.Errorf("invalid cache option %q: %w", value, err)
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// ValidateCacheOptions checks --cache-from and --cache-to values, so that a
+// mistake is reported before a builder is provisioned.
+func ValidateCacheOptions(cacheFrom, cacheTo []string) error {
+	if _, err := parseCacheOptions(cacheFrom); err != nil {
+		return fmt.Errorf("--cache-from: %w", err)
+	}
+	if _, err := parseCacheOptions(cacheTo); err != nil {
+		return fmt.Errorf("--cache-to: %w", err)
+	}
+	return nil
+}
+
+// applyCacheOptions sets the cache imports and exports requested in opts on
+// the given solve options.
+func applyCacheOptions(solveOpt *client.SolveOpt, opts ImageOptions) error {
+	imports, err := parseCacheOptions(opts.CacheFrom)
+	if err != nil {
+		return err
+	}
+	exports, err := parseCacheOptions(opts.CacheTo)
+	if err != nil {
+		return err
+	}
+
+	solveOpt.CacheImports = imports
+	solveOpt.CacheExports = exports
+	return nil
+}
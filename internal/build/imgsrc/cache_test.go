@@ -0,0 +1,42 @@
+package imgsrc
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCacheOptions(t *testing.T) {
+	entries, err := parseCacheOptions([]string{
+		"registry.fly.io/my-app:cache",
+		"type=registry,ref=registry.fly.io/my-app:cache,mode=max",
+		"type=gha",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []client.CacheOptionsEntry{
+		{Type: "registry", Attrs: map[string]string{"ref": "registry.fly.io/my-app:cache"}},
+		{Type: "registry", Attrs: map[string]string{"ref": "registry.fly.io/my-app:cache", "mode": "max"}},
+		{Type: "gha", Attrs: map[string]string{}},
+	}, entries)
+
+	entries, err = parseCacheOptions(nil)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	_, err = parseCacheOptions([]string{"ref=registry.fly.io/my-app:cache"})
+	assert.ErrorContains(t, err, "missing type")
+
+	_, err = parseCacheOptions([]string{"type=registry,mode=max"})
+	assert.ErrorContains(t, err, "requires a ref")
+
+	_, err = parseCacheOptions([]string{"type=registry,ref=registry.fly.io/my-app:cache,max"})
+	assert.ErrorContains(t, err, "must be in the form key=value")
+}
+
+func TestValidateCacheOptions(t *testing.T) {
+	assert.NoError(t, ValidateCacheOptions([]string{"registry.fly.io/my-app:cache"}, nil))
+	assert.ErrorContains(t, ValidateCacheOptions([]string{"type=registry"}, nil), "--cache-from: ")
+	assert.ErrorContains(t, ValidateCacheOptions(nil, []string{"mode=max"}), "--cache-to: ")
+}
@@ -126,14 +126,7 @@ func depotBuild(ctx context.Context, streams *iostreams.IOStreams, opts ImageOpt
 	buildState.BuilderInitStart()
 	buildState.SetBuilderMetaPart1(depotBuilderType, "", "")
 
-	{
-		msg := "Waiting for depot builder...\n"
-		if streams.IsInteractive() {
-			streams.StartProgressIndicatorMsg(msg)
-		} else {
-			fmt.Fprintln(streams.ErrOut, msg)
-		}
-	}
+	streams.StartProgressIndicatorOrLog("Waiting for depot builder...")
 
 	buildkit, build, buildErr := initBuilder(ctx, buildState, opts.AppName, streams, scope)
 	if buildErr != nil {
@@ -281,6 +274,9 @@ func buildImage(ctx context.Context, buildkitClient *client.Client, opts ImageOp
 		if opts.NoCache {
 			solverOptions.FrontendAttrs["no-cache"] = ""
 		}
+		if err := applyCacheOptions(&solverOptions, opts); err != nil {
+			return err
+		}
 		for k, v := range opts.Label {
 			solverOptions.FrontendAttrs["label:"+k] = v
 		}
@@ -294,7 +290,7 @@ func buildImage(ctx context.Context, buildkitClient *client.Client, opts ImageOp
 		}
 		solverOptions.Session = append(
 			solverOptions.Session,
-			newBuildkitAuthProvider(config.Tokens(ctx).Docker()),
+			newBuildkitAuthProvider(config.Tokens(ctx).Docker(), opts.RegistryAuth),
 			secretsprovider.FromMap(secrets),
 		)
 
@@ -303,7 +299,7 @@ func buildImage(ctx context.Context, buildkitClient *client.Client, opts ImageOp
 	})
 
 	eg.Go(func() error {
-		display, err := progressui.NewDisplay(os.Stderr, progressui.AutoMode)
+		display, err := progressui.NewDisplay(os.Stderr, progressDisplayMode(iostreams.FromContext(ctx)))
 		if err != nil {
 			return err
 		}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/azazeal/pause"
+	"github.com/docker/cli/cli/connhelper"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
@@ -196,24 +197,60 @@ func (t DockerDaemonType) PrefersLocal() bool {
 	return (t & DockerDaemonTypePrefersLocal) != 0
 }
 
+// NewLocalDockerClient returns a client for the daemon configured through the
+// standard Docker environment variables. This is usually the local daemon, but
+// DOCKER_HOST may also point at a daemon reachable over ssh://.
 func NewLocalDockerClient() (*dockerclient.Client, error) {
-	c, err := dockerclient.NewClientWithOpts(
+	host := os.Getenv(dockerclient.EnvOverrideHost)
+
+	sshOpts, err := sshDockerClientOpts(host)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []dockerclient.Opt{
 		dockerclient.FromEnv,
 		dockerclient.WithAPIVersionNegotiation(),
-	)
+	}
+	c, err := dockerclient.NewClientWithOpts(append(opts, sshOpts...)...)
 	if err != nil {
 		return nil, err
 	}
 
 	if _, err = c.Ping(context.TODO()); err != nil {
+		if sshOpts != nil {
+			return nil, fmt.Errorf("docker daemon at %s is unreachable over ssh: %w", host, err)
+		}
 		return nil, err
 	}
 
 	return c, nil
 }
 
+// getConnectionHelper returns the helper that dials a daemon over ssh by
+// running the ssh binary. Tests replace it to avoid doing so.
+var getConnectionHelper = connhelper.GetConnectionHelper
+
+// sshDockerClientOpts returns the client options needed to reach a daemon over
+// ssh when host is an ssh:// URL, and nil otherwise.
+func sshDockerClientOpts(host string) ([]dockerclient.Opt, error) {
+	if !strings.HasPrefix(host, "ssh://") {
+		return nil, nil
+	}
+
+	helper, err := getConnectionHelper(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %w", dockerclient.EnvOverrideHost, host, err)
+	}
+
+	return []dockerclient.Opt{
+		dockerclient.WithHost(helper.Host),
+		dockerclient.WithDialContext(helper.Dialer),
+	}, nil
+}
+
 func logClearLinesAbove(streams *iostreams.IOStreams, count int) {
-	if streams.IsInteractive() {
+	if streams.ProgressIndicatorEnabled() {
 		builder := aec.EmptyBuilder
 		str := builder.Up(uint(count)).EraseLine(aec.EraseModes.All).ANSI
 		fmt.Fprint(streams.Out, str.String())
@@ -308,11 +345,7 @@ func newRemoteDockerClient(ctx context.Context, apiClient flyutil.Client, appNam
 
 	build.SetBuilderMetaPart1(remoteBuilderType, remoteBuilderAppName, machine.ID)
 
-	if msg := fmt.Sprintf("Waiting for remote builder %s...\n", remoteBuilderAppName); streams.IsInteractive() {
-		streams.StartProgressIndicatorMsg(msg)
-	} else {
-		fmt.Fprintln(streams.ErrOut, msg)
-	}
+	streams.StartProgressIndicatorOrLog(fmt.Sprintf("Waiting for remote builder %s...", remoteBuilderAppName))
 
 	captureError := func(err error) {
 		// ignore cancelled errors
@@ -448,11 +481,7 @@ func newRemoteDockerClient(ctx context.Context, apiClient flyutil.Client, appNam
 
 		return nil, err
 	default:
-		if msg := fmt.Sprintf("Remote builder %s ready", remoteBuilderAppName); streams.IsInteractive() {
-			streams.StopProgressIndicatorMsg(msg)
-		} else {
-			fmt.Fprintln(streams.ErrOut, msg)
-		}
+		streams.StopProgressIndicatorOrLog(fmt.Sprintf("Remote builder %s ready", remoteBuilderAppName))
 	}
 
 	return cachedClient, nil
@@ -666,11 +695,10 @@ func authConfigs(token string) map[string]registry.AuthConfig {
 	return authConfigs
 }
 
-func flyRegistryAuth(token string) string {
-	authConfig := registryAuth(token)
+func encodeRegistryAuth(authConfig registry.AuthConfig) string {
 	encodedJSON, err := json.Marshal(authConfig)
 	if err != nil {
-		terminal.Warn("Error encoding fly registry credentials", err)
+		terminal.Warn("Error encoding registry credentials", err)
 		return ""
 	}
 	return base64.URLEncoding.EncodeToString(encodedJSON)
@@ -678,7 +706,9 @@ func flyRegistryAuth(token string) string {
 
 // NewDeploymentTag generates a Docker image reference including the current registry,
 // the app name, and a timestamp: registry.fly.io/appname:deployment-$timestamp
-func NewDeploymentTag(appName string, label string) string {
+// When process is set, it is appended to the tag so that images built for
+// different process groups don't collide: registry.fly.io/appname:deployment-$timestamp-$process
+func NewDeploymentTag(appName string, label string, process string) string {
 	// MD: this was used by remote builders long ago to set a precomputed ref for deployment.
 	// flyd now sets this to the current image in machine env.
 	// stop using it in flyctl and if nobody has a problem remove it by 2022-11-01
@@ -689,6 +719,9 @@ func NewDeploymentTag(appName string, label string) string {
 	if label == "" {
 		label = fmt.Sprintf("deployment-%s", ulid.Make())
 	}
+	if process != "" {
+		label = fmt.Sprintf("%s-%s", label, process)
+	}
 
 	registry := viper.GetString(flyctl.ConfigRegistryHost)
 
@@ -1,9 +1,15 @@
 package imgsrc
 
 import (
+	"context"
+	"errors"
+	"net"
 	"testing"
 
+	"github.com/docker/cli/cli/connhelper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/iostreams"
 )
 
 func TestAllowedDockerDaemonMode(t *testing.T) {
@@ -31,3 +37,46 @@ func TestAllowedDockerDaemonMode(t *testing.T) {
 		assert.Equal(t, test.expected, m)
 	}
 }
+
+func TestLogClearLinesAboveWithoutTTY(t *testing.T) {
+	streams, _, out, errOut := iostreams.Test()
+
+	logClearLinesAbove(streams, 1)
+
+	assert.Empty(t, out.String())
+	assert.Empty(t, errOut.String())
+}
+
+func TestSSHDockerClientOpts(t *testing.T) {
+	opts, err := sshDockerClientOpts("")
+	assert.NoError(t, err)
+	assert.Nil(t, opts)
+
+	opts, err = sshDockerClientOpts("unix:///var/run/docker.sock")
+	assert.NoError(t, err)
+	assert.Nil(t, opts)
+
+	opts, err = sshDockerClientOpts("ssh://builder@build.example.com:2222")
+	assert.NoError(t, err)
+	assert.Len(t, opts, 2)
+
+	_, err = sshDockerClientOpts("ssh://builder@build.example.com/?q=1")
+	assert.ErrorContains(t, err, "invalid DOCKER_HOST")
+}
+
+func TestNewLocalDockerClientUnreachableSSH(t *testing.T) {
+	defer func(orig func(string) (*connhelper.ConnectionHelper, error)) { getConnectionHelper = orig }(getConnectionHelper)
+	getConnectionHelper = func(host string) (*connhelper.ConnectionHelper, error) {
+		return &connhelper.ConnectionHelper{
+			Host: "http://docker.example.com",
+			Dialer: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				return nil, errors.New("ssh: connect to host 127.0.0.1 port 1: Connection refused")
+			},
+		}, nil
+	}
+	t.Setenv("DOCKER_HOST", "ssh://nobody@127.0.0.1:1")
+
+	_, err := NewLocalDockerClient()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "docker daemon at ssh://nobody@127.0.0.1:1 is unreachable over ssh")
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/api/types/system"
 	dockerclient "github.com/docker/docker/client"
 	"github.com/docker/docker/pkg/jsonmessage"
@@ -274,7 +275,7 @@ func (*dockerfileBuilder) Run(ctx context.Context, dockerFactory *dockerClientFa
 	if opts.Publish {
 		build.PushStart()
 		tb := render.NewTextBlock(ctx, "Pushing image to fly")
-		if err := pushToFly(ctx, docker, streams, opts.Tag); err != nil {
+		if err := pushToFly(ctx, docker, streams, opts.Tag, opts.RegistryAuth); err != nil {
 			build.PushFinish()
 			return nil, "", err
 		}
@@ -393,6 +394,15 @@ func normalizeBuildArgsForDocker(buildArgs map[string]string) (map[string]*strin
 	return out, nil
 }
 
+// warnIgnoredBuildSecrets warns that the build secrets, if any, are not
+// passed to a build that can't mount them.
+func warnIgnoredBuildSecrets(streams *iostreams.IOStreams, opts ImageOptions, builder string) {
+	if len(opts.BuildSecrets) == 0 {
+		return
+	}
+	fmt.Fprintf(streams.ErrOut, "%s Ignoring --build-secret: %s don't support build secrets\n", streams.ColorScheme().WarningIcon(), builder)
+}
+
 func runClassicBuild(ctx context.Context, streams *iostreams.IOStreams, docker *dockerclient.Client, r io.ReadCloser, opts ImageOptions, dockerfilePath string, buildArgs map[string]*string) (imageID string, err error) {
 	ctx, span := tracing.GetTracer().Start(ctx, "build_image",
 		trace.WithAttributes(opts.ToSpanAttributes()...),
@@ -400,10 +410,12 @@ func runClassicBuild(ctx context.Context, streams *iostreams.IOStreams, docker *
 	)
 	defer span.End()
 
+	warnIgnoredBuildSecrets(streams, opts, "Docker builds without BuildKit")
+
 	options := types.ImageBuildOptions{
 		Tags:        []string{opts.Tag},
 		BuildArgs:   buildArgs,
-		AuthConfigs: authConfigs(config.Tokens(ctx).Docker()),
+		AuthConfigs: withRegistryAuth(authConfigs(config.Tokens(ctx).Docker()), opts.RegistryAuth),
 		Platform:    "linux/amd64",
 		Dockerfile:  dockerfilePath,
 		Target:      opts.Target,
@@ -495,7 +507,7 @@ func runBuildKitBuild(ctx context.Context, docker *dockerclient.Client, opts Ima
 	eg.Go(func() error {
 		var err error
 
-		display, err := progressui.NewDisplay(os.Stderr, "auto")
+		display, err := progressui.NewDisplay(os.Stderr, progressDisplayMode(iostreams.FromContext(ctx)))
 		if err != nil {
 			return err
 		}
@@ -508,6 +520,9 @@ func runBuildKitBuild(ctx context.Context, docker *dockerclient.Client, opts Ima
 	var res *client.SolveResponse
 	eg.Go(func() error {
 		options := solveOptFromImageOptions(opts, dockerfilePath, buildArgs)
+		if err := applyCacheOptions(&options, opts); err != nil {
+			return err
+		}
 		secrets := make(map[string][]byte)
 		for k, v := range opts.BuildSecrets {
 			secrets[k] = []byte(v)
@@ -516,7 +531,7 @@ func runBuildKitBuild(ctx context.Context, docker *dockerclient.Client, opts Ima
 			options.Session,
 			// To pull images from local Docker Engine with Fly's access token,
 			// we need to pass the provider. Remote builders don't need that.
-			newBuildkitAuthProvider(config.Tokens(ctx).Docker()),
+			newBuildkitAuthProvider(config.Tokens(ctx).Docker(), opts.RegistryAuth),
 			secretsprovider.FromMap(secrets),
 		)
 
@@ -534,7 +549,7 @@ func runBuildKitBuild(ctx context.Context, docker *dockerclient.Client, opts Ima
 	return res.ExporterResponse[exptypes.ExporterImageDigestKey], nil
 }
 
-func pushToFly(ctx context.Context, docker *dockerclient.Client, streams *iostreams.IOStreams, tag string) (err error) {
+func pushToFly(ctx context.Context, docker *dockerclient.Client, streams *iostreams.IOStreams, tag string, auth *registry.AuthConfig) (err error) {
 	ctx, span := tracing.GetTracer().Start(ctx, "push_image_to_registry", trace.WithAttributes(attribute.String("tag", tag)))
 	defer span.End()
 
@@ -548,7 +563,7 @@ func pushToFly(ctx context.Context, docker *dockerclient.Client, streams *iostre
 	sendImgPushMetrics := metrics.StartTiming(ctx, "image_push/duration")
 
 	pushResp, err := docker.ImagePush(ctx, tag, image.PushOptions{
-		RegistryAuth: flyRegistryAuth(config.Tokens(ctx).Docker()),
+		RegistryAuth: encodeRegistryAuth(pushAuth(ctx, auth)),
 	})
 	metrics.Status(ctx, "image_push", err == nil)
 
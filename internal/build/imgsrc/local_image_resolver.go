@@ -37,7 +37,7 @@ func (*localImageResolver) Run(ctx context.Context, dockerFactory *dockerClientF
 	}
 
 	if opts.Tag == "" {
-		opts.Tag = NewDeploymentTag(opts.AppName, opts.ImageLabel)
+		opts.Tag = NewDeploymentTag(opts.AppName, opts.ImageLabel, "")
 	}
 
 	span.SetAttributes(opts.ToSpanAttributes()...)
@@ -96,7 +96,7 @@ func (*localImageResolver) Run(ctx context.Context, dockerFactory *dockerClientF
 
 		cmdfmt.PrintBegin(streams.ErrOut, "Pushing image to fly")
 
-		if err := pushToFly(ctx, docker, streams, opts.Tag); err != nil {
+		if err := pushToFly(ctx, docker, streams, opts.Tag, nil); err != nil {
 			build.PushFinish()
 			return nil, "", err
 		}
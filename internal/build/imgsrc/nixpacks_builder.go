@@ -188,6 +188,8 @@ func (*nixpacksBuilder) Run(ctx context.Context, dockerFactory *dockerClientFact
 	defer clearDeploymentTags(ctx, docker, opts.Tag)
 	build.BuilderInitFinish()
 
+	warnIgnoredBuildSecrets(streams, opts, "nixpacks builds")
+
 	build.ImageBuildStart()
 	confDir := flyctl.ConfigDir()
 	nixpacksPath := filepath.Join(confDir, "bin", "nixpacks")
@@ -216,7 +218,7 @@ func (*nixpacksBuilder) Run(ctx context.Context, dockerFactory *dockerClientFact
 	build.BuildFinish()
 
 	build.PushStart()
-	if err := pushToFly(ctx, docker, streams, opts.Tag); err != nil {
+	if err := pushToFly(ctx, docker, streams, opts.Tag, opts.RegistryAuth); err != nil {
 		build.PushFinish()
 		return nil, "", err
 	}
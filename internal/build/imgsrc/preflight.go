@@ -0,0 +1,87 @@
+package imgsrc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/superfly/flyctl/internal/config"
+)
+
+// CheckRegistryAuth verifies that the Fly registry grants the user's token
+// push access to appName's repository. Only a token is requested; nothing
+// is uploaded.
+func CheckRegistryAuth(ctx context.Context, appName string) error {
+	auth := registryAuth(config.Tokens(ctx).Docker())
+
+	repo, err := name.NewRepository(auth.ServerAddress + "/" + appName)
+	if err != nil {
+		return fmt.Errorf("invalid repository for app %s: %w", appName, err)
+	}
+
+	authenticator := authn.FromConfig(authn.AuthConfig{Username: auth.Username, Password: auth.Password})
+	scopes := []string{repo.Scope(transport.PushScope)}
+	if _, err := transport.NewWithContext(ctx, repo.Registry, authenticator, http.DefaultTransport, scopes); err != nil {
+		return fmt.Errorf("registry %s rejected push access to %s: %w", auth.ServerAddress, repo, err)
+	}
+	return nil
+}
+
+// CheckImageResolvable verifies that ref exists in its registry. Images in
+// the Fly registry are looked up with the user's token, others with the
+// local docker credentials.
+func CheckImageResolvable(ctx context.Context, ref string) error {
+	r, err := name.ParseReference(ref)
+	if err != nil {
+		return fmt.Errorf("invalid image reference %q: %w", ref, err)
+	}
+
+	if _, err := remote.Head(r, remote.WithAuthFromKeychain(imageKeychain(ctx, r)), remote.WithContext(ctx)); err != nil {
+		return fmt.Errorf("could not resolve image %s: %w", ref, err)
+	}
+	return nil
+}
+
+// ImageDefaultCommand returns the entrypoint and command ref's image config
+// runs when a machine doesn't override them.
+func ImageDefaultCommand(ctx context.Context, ref string) (entrypoint, cmd []string, err error) {
+	r, err := name.ParseReference(ref)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid image reference %q: %w", ref, err)
+	}
+
+	img, err := remote.Image(r, remote.WithAuthFromKeychain(imageKeychain(ctx, r)), remote.WithContext(ctx))
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not fetch image %s: %w", ref, err)
+	}
+	cfg, err := img.ConfigFile()
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not read the config of image %s: %w", ref, err)
+	}
+	return cfg.Config.Entrypoint, cfg.Config.Cmd, nil
+}
+
+// imageKeychain returns the credentials to pull r with: the user's token for
+// the Fly registry, the local docker credentials otherwise.
+func imageKeychain(ctx context.Context, r name.Reference) authn.Keychain {
+	if flyAuth := registryAuth(config.Tokens(ctx).Docker()); r.Context().RegistryStr() == flyAuth.ServerAddress {
+		return staticKeychain{auth: authn.FromConfig(authn.AuthConfig{Username: flyAuth.Username, Password: flyAuth.Password})}
+	}
+	return authn.DefaultKeychain
+}
+
+// CheckLocalDaemon verifies that the local docker daemon is reachable.
+func CheckLocalDaemon(ctx context.Context) error {
+	client, err := NewLocalDockerClient()
+	if err != nil {
+		return fmt.Errorf("local docker daemon is unavailable: %w", err)
+	}
+	defer client.Close()
+
+	_, err = clientPing(ctx, client)
+	return err
+}
@@ -0,0 +1,131 @@
+package imgsrc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/docker/docker/api/types/registry"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/spf13/viper"
+	"github.com/superfly/flyctl/flyctl"
+	"github.com/superfly/flyctl/internal/config"
+)
+
+// pushAuth returns the credentials used to push tag: the ones supplied in
+// auth when set, the Fly registry token otherwise.
+func pushAuth(ctx context.Context, auth *registry.AuthConfig) registry.AuthConfig {
+	if auth != nil {
+		return *auth
+	}
+	return registryAuth(config.Tokens(ctx).Docker())
+}
+
+// RegistryAuthForTag returns the credentials for pushing tag, taken from the
+// local Docker config as stored by `docker login`. It returns nil when tag is
+// in the Fly registry, which uses the Fly token instead.
+func RegistryAuthForTag(tag string) (*registry.AuthConfig, error) {
+	ref, err := name.ParseReference(tag)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image tag %q: %w", tag, err)
+	}
+	host := ref.Context().RegistryStr()
+	if host == normalizeRegistryHost(viper.GetString(flyctl.ConfigRegistryHost)) {
+		return nil, nil
+	}
+
+	authenticator, err := authn.DefaultKeychain.Resolve(ref.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the credentials for registry %s: %w", host, err)
+	}
+	if authenticator == authn.Anonymous {
+		return nil, fmt.Errorf("no credentials for registry %s, run `docker login %s` first", host, host)
+	}
+	cfg, err := authenticator.Authorization()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the credentials for registry %s: %w", host, err)
+	}
+	return &registry.AuthConfig{
+		ServerAddress: host,
+		Username:      cfg.Username,
+		Password:      cfg.Password,
+		Auth:          cfg.Auth,
+		IdentityToken: cfg.IdentityToken,
+		RegistryToken: cfg.RegistryToken,
+	}, nil
+}
+
+// normalizeRegistryHost returns the canonical name of a registry given as a
+// host, host:port or URL, so that docker.io and index.docker.io, or
+// https://ghcr.io/ and ghcr.io, compare equal.
+func normalizeRegistryHost(server string) string {
+	host := server
+	if _, rest, ok := strings.Cut(host, "://"); ok {
+		host = rest
+	}
+	host, _, _ = strings.Cut(host, "/")
+	if host == "registry-1.docker.io" {
+		host = name.DefaultRegistry
+	}
+	reg, err := name.NewRegistry(host)
+	if err != nil {
+		return host
+	}
+	return reg.RegistryStr()
+}
+
+// withRegistryAuth adds auth, if set, to the credentials handed to builders,
+// under every name builders may look its registry up by.
+func withRegistryAuth(auths map[string]registry.AuthConfig, auth *registry.AuthConfig) map[string]registry.AuthConfig {
+	if auth == nil {
+		return auths
+	}
+	host := normalizeRegistryHost(auth.ServerAddress)
+	auths[host] = *auth
+	if host == name.DefaultRegistry {
+		for _, alias := range []string{"docker.io", "registry-1.docker.io", "https://index.docker.io/v1/"} {
+			auths[alias] = *auth
+		}
+	}
+	return auths
+}
+
+// validateRegistryAuth checks, before anything is built, that auth allows
+// pushing tag, so bad credentials for a third-party registry fail fast
+// instead of after a full build.
+func validateRegistryAuth(ctx context.Context, tag string, auth *registry.AuthConfig) error {
+	if auth == nil {
+		return nil
+	}
+
+	ref, err := name.ParseReference(tag)
+	if err != nil {
+		return fmt.Errorf("invalid image tag %q: %w", tag, err)
+	}
+	if host := ref.Context().RegistryStr(); host != normalizeRegistryHost(auth.ServerAddress) {
+		return fmt.Errorf("image tag %q is not in registry %s the credentials are for", tag, auth.ServerAddress)
+	}
+
+	keychain := staticKeychain{auth: authn.FromConfig(authn.AuthConfig{
+		Username:      auth.Username,
+		Password:      auth.Password,
+		Auth:          auth.Auth,
+		IdentityToken: auth.IdentityToken,
+		RegistryToken: auth.RegistryToken,
+	})}
+	if err := remote.CheckPushPermission(ref, keychain, http.DefaultTransport); err != nil {
+		return fmt.Errorf("credentials for registry %s can't push %q: %w", auth.ServerAddress, tag, err)
+	}
+	return nil
+}
+
+type staticKeychain struct {
+	auth authn.Authenticator
+}
+
+func (k staticKeychain) Resolve(authn.Resource) (authn.Authenticator, error) {
+	return k.auth, nil
+}
@@ -0,0 +1,92 @@
+package imgsrc
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/registry"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/flyctl"
+)
+
+func TestValidateRegistryAuth(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	auth := &registry.AuthConfig{ServerAddress: host, Username: "user", Password: "wrong"}
+
+	require.NoError(t, validateRegistryAuth(ctx, "registry.fly.io/my-app:v1", nil))
+
+	err := validateRegistryAuth(ctx, "registry.fly.io/my-app:v1", auth)
+	assert.ErrorContains(t, err, "is not in registry")
+
+	err = validateRegistryAuth(ctx, host+"/my-app:v1", auth)
+	assert.ErrorContains(t, err, "can't push")
+}
+
+func TestWithRegistryAuth(t *testing.T) {
+	auths := map[string]registry.AuthConfig{"registry.fly.io": {Username: "x"}}
+
+	assert.Len(t, withRegistryAuth(auths, nil), 1)
+
+	auths = withRegistryAuth(auths, &registry.AuthConfig{ServerAddress: "https://ghcr.io/", Username: "me"})
+	assert.Equal(t, "me", auths["ghcr.io"].Username)
+	assert.Equal(t, "x", auths["registry.fly.io"].Username)
+
+	auths = withRegistryAuth(auths, &registry.AuthConfig{ServerAddress: "docker.io", Username: "hub"})
+	for _, host := range []string{"index.docker.io", "docker.io", "registry-1.docker.io", "https://index.docker.io/v1/"} {
+		assert.Equal(t, "hub", auths[host].Username, host)
+	}
+}
+
+func TestNormalizeRegistryHost(t *testing.T) {
+	for server, want := range map[string]string{
+		"ghcr.io":                     "ghcr.io",
+		"https://ghcr.io/":            "ghcr.io",
+		"docker.io":                   "index.docker.io",
+		"index.docker.io":             "index.docker.io",
+		"registry-1.docker.io":        "index.docker.io",
+		"https://index.docker.io/v1/": "index.docker.io",
+		"localhost:5000":              "localhost:5000",
+		"http://localhost:5000/v2/":   "localhost:5000",
+	} {
+		assert.Equal(t, want, normalizeRegistryHost(server), server)
+	}
+}
+
+func TestRegistryAuthForTag(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", dir)
+	viper.Set(flyctl.ConfigRegistryHost, "registry.fly.io")
+	t.Cleanup(func() { viper.Set(flyctl.ConfigRegistryHost, nil) })
+
+	config := `{"auths": {"https://index.docker.io/v1/": {"auth": "` + base64.StdEncoding.EncodeToString([]byte("me:secret")) + `"}}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte(config), 0o600))
+
+	auth, err := RegistryAuthForTag("registry.fly.io/my-app:v1")
+	require.NoError(t, err)
+	assert.Nil(t, auth)
+
+	auth, err = RegistryAuthForTag("docker.io/me/my-app:v1")
+	require.NoError(t, err)
+	assert.Equal(t, "index.docker.io", auth.ServerAddress)
+	assert.Equal(t, "me", auth.Username)
+	assert.Equal(t, "secret", auth.Password)
+
+	_, err = RegistryAuthForTag("ghcr.io/me/my-app:v1")
+	assert.ErrorContains(t, err, "no credentials for registry ghcr.io")
+}
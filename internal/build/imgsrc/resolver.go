@@ -18,6 +18,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/docker/docker/api/types/registry"
 	dockerclient "github.com/docker/docker/client"
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/flyctl/agent"
@@ -42,6 +43,7 @@ type ImageOptions struct {
 	ExtraBuildArgs       map[string]string
 	BuildSecrets         map[string]string
 	ImageLabel           string
+	Process              string
 	Publish              bool
 	Tag                  string
 	Target               string
@@ -55,6 +57,11 @@ type ImageOptions struct {
 	BuildpacksVolumes    []string
 	UseOverlaybd         bool
 	UseZstd              bool
+	CacheFrom            []string
+	CacheTo              []string
+	// RegistryAuth holds credentials for pushing to a registry other than
+	// the Fly registry. Tag must then reference an image in that registry.
+	RegistryAuth *registry.AuthConfig
 }
 
 func (io ImageOptions) ToSpanAttributes() []attribute.KeyValue {
@@ -65,6 +72,7 @@ func (io ImageOptions) ToSpanAttributes() []attribute.KeyValue {
 		attribute.String("imageoptions.ignorefile_path", io.IgnorefilePath),
 		attribute.String("imageoptions.image.ref", io.ImageRef),
 		attribute.String("imageoptions.image.label", io.ImageLabel),
+		attribute.String("imageoptions.process", io.Process),
 		attribute.Bool("imageoptions.publish", io.Publish),
 		attribute.String("imageoptions.tag", io.Tag),
 		attribute.Bool("imageoptions.nocache", io.NoCache),
@@ -73,6 +81,8 @@ func (io ImageOptions) ToSpanAttributes() []attribute.KeyValue {
 		attribute.String("imageoptions.buildpacks_docker_host", io.BuildpacksDockerHost),
 		attribute.StringSlice("imageoptions.buildpacks", io.Buildpacks),
 		attribute.StringSlice("imageoptions.buildpacks_volumes", io.BuildpacksVolumes),
+		attribute.StringSlice("imageoptions.cache_from", io.CacheFrom),
+		attribute.StringSlice("imageoptions.cache_to", io.CacheTo),
 		attribute.Bool("imageoptions.use_zstd", io.UseZstd),
 	}
 
@@ -124,6 +134,8 @@ type DeploymentImage struct {
 	Size    int64
 	BuildID string
 	Labels  map[string]string
+	// Timings holds the breakdown of the build that produced the image, if any.
+	Timings *fly.BuildTimingsInput
 }
 
 func (image *DeploymentImage) String() string {
@@ -226,11 +238,18 @@ func (r *Resolver) BuildImage(ctx context.Context, streams *iostreams.IOStreams,
 	}
 
 	if opts.Tag == "" {
-		opts.Tag = NewDeploymentTag(opts.AppName, opts.ImageLabel)
+		opts.Tag = NewDeploymentTag(opts.AppName, opts.ImageLabel, opts.Process)
 	}
 
 	span.SetAttributes(attribute.String("tag", opts.Tag))
 
+	if opts.Publish {
+		if err := validateRegistryAuth(ctx, opts.Tag, opts.RegistryAuth); err != nil {
+			tracing.RecordError(span, err, "invalid registry credentials")
+			return nil, err
+		}
+	}
+
 	strategies := []imageBuilder{}
 
 	var builderScope depotBuilderScope
@@ -285,6 +304,7 @@ func (r *Resolver) BuildImage(ctx context.Context, streams *iostreams.IOStreams,
 		if img != nil {
 			bld.BuildAndPushFinish()
 			bld.FinishStrategy(s, false /* success */, nil, note)
+			img.Timings = bld.Timings
 			buildResult, err := r.finishBuild(ctx, bld, false /* completed */, "", img)
 			if err == nil && buildResult != nil {
 				// we should only set the image's buildID if we push the build info to web
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/docker/docker/client"
@@ -158,3 +159,14 @@ func TestStartHeartbeatWError(t *testing.T) {
 	_, err = resolver.StartHeartbeat(ctx)
 	assert.Error(t, err)
 }
+
+func TestNewDeploymentTag(t *testing.T) {
+	tag := NewDeploymentTag("my-app", "v1", "")
+	assert.True(t, strings.HasSuffix(tag, "/my-app:v1"), tag)
+
+	tag = NewDeploymentTag("my-app", "v1", "worker")
+	assert.True(t, strings.HasSuffix(tag, "/my-app:v1-worker"), tag)
+
+	tag = NewDeploymentTag("my-app", "", "worker")
+	assert.Regexp(t, `/my-app:deployment-[0-9A-Z]{26}-worker$`, tag)
+}
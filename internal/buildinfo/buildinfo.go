@@ -64,17 +64,19 @@ type info struct {
 	BuildDate    time.Time
 	OS           string
 	Architecture string
+	GoVersion    string
 	Environment  string
 }
 
 func (i info) String() string {
-	res := fmt.Sprintf("%s v%s %s/%s Commit: %s BuildDate: %s",
+	res := fmt.Sprintf("%s v%s %s/%s Commit: %s BuildDate: %s GoVersion: %s",
 		i.Name,
 		i.Version,
 		i.OS,
 		i.Architecture,
 		i.Commit,
-		i.BuildDate.Format(time.RFC3339))
+		i.BuildDate.Format(time.RFC3339),
+		i.GoVersion)
 	if i.BranchName != "" {
 		res += fmt.Sprintf(" BranchName: %s", i.BranchName)
 	}
@@ -90,6 +92,7 @@ func Info() info {
 		BuildDate:    BuildTime(),
 		OS:           OS(),
 		Architecture: Arch(),
+		GoVersion:    GoVersion(),
 		Environment:  Environment(),
 	}
 }
@@ -102,6 +105,10 @@ func Arch() string {
 	return runtime.GOARCH
 }
 
+func GoVersion() string {
+	return runtime.Version()
+}
+
 func BranchName() string {
 	return branchName
 }
@@ -116,6 +116,10 @@ func Run(ctx context.Context, io *iostreams.IOStreams, args ...string) int {
 	default:
 		printError(io, cs, cmd, err)
 
+		if code, ok := flyerr.GetErrorExitCode(err); ok {
+			return code
+		}
+
 		_, _, e := cmd.Find(args)
 		if e != nil {
 			fmt.Printf("Run '%v --help' for usage.\n", cmd.CommandPath())
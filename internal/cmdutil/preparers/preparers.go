@@ -16,6 +16,7 @@ import (
 	"github.com/superfly/flyctl/internal/instrument"
 	"github.com/superfly/flyctl/internal/logger"
 	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
@@ -41,18 +42,26 @@ func LoadConfig(ctx context.Context) (context.Context, error) {
 }
 
 func InitClient(ctx context.Context) (context.Context, error) {
-	logger := logger.FromContext(ctx)
+	log := logger.FromContext(ctx)
 	cfg := config.FromContext(ctx)
 
 	// TODO: refactor so that api package does NOT depend on global state
 	fly.SetBaseURL(cfg.APIBaseURL)
 	fly.SetErrorLog(cfg.LogGQLErrors)
 	fly.SetInstrumenter(instrument.ApiAdapter)
-	fly.SetTransport(otelhttp.NewTransport(http.DefaultTransport))
+	// The client retries 502s, 503s and temporary errors itself; this only
+	// adds retries of idempotent requests on 504s and refused connections.
+	fly.SetTransport(flyutil.NewRetryTransport(otelhttp.NewTransport(http.DefaultTransport), flyutil.MaxRetries()))
 
 	if flyutil.ClientFromContext(ctx) == nil {
-		client := flyutil.NewClientFromOptions(ctx, fly.ClientOptions{Tokens: cfg.Tokens})
-		logger.Debug("client initialized.")
+		opts := fly.ClientOptions{Tokens: cfg.Tokens}
+		if cfg.VerboseOutput {
+			// --verbose shows API requests and responses regardless of LOG_LEVEL
+			io := iostreams.FromContext(ctx)
+			opts.Logger = logger.New(io.ErrOut, logger.Debug, io.ColorEnabled()).AndLogToFile()
+		}
+		client := flyutil.NewClientFromOptions(ctx, opts)
+		log.Debug("client initialized.")
 		ctx = flyutil.NewContextWithClient(ctx, client)
 	}
 
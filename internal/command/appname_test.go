@@ -0,0 +1,47 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/flagnames"
+)
+
+func TestCheckStrictAppName(t *testing.T) {
+	newContext := func(strict bool, configApp string, args ...string) context.Context {
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		fs.StringP(flagnames.App, "a", "", "")
+		require.NoError(t, fs.Parse(args))
+
+		ctx := flag.NewContext(context.Background(), fs)
+		ctx = config.NewContext(ctx, &config.Config{StrictApp: strict})
+		if configApp != "" {
+			cfg := appconfig.NewConfig()
+			cfg.AppName = configApp
+			ctx = appconfig.WithConfig(ctx, cfg)
+		}
+		return ctx
+	}
+
+	t.Setenv("FLY_APP", "")
+
+	assert.NoError(t, checkStrictAppName(newContext(false, "my-app", "--app", "other-app")))
+	assert.NoError(t, checkStrictAppName(newContext(true, "", "--app", "other-app")))
+	assert.NoError(t, checkStrictAppName(newContext(true, "my-app")))
+
+	err := checkStrictAppName(newContext(true, "my-app", "-a", "my-app"))
+	assert.ErrorContains(t, err, "--app can't be used in strict app mode")
+
+	t.Setenv("FLY_APP", "my-app")
+	assert.NoError(t, checkStrictAppName(newContext(true, "my-app")))
+
+	t.Setenv("FLY_APP", "other-app")
+	err = checkStrictAppName(newContext(true, "my-app"))
+	assert.ErrorContains(t, err, "FLY_APP is set to other-app")
+}
@@ -3,6 +3,7 @@ package apps
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/flyerr"
 	"github.com/superfly/flyctl/internal/flyutil"
 	"github.com/superfly/flyctl/internal/prompt"
 	"github.com/superfly/flyctl/internal/render"
@@ -91,6 +93,16 @@ func RunCreate(ctx context.Context) (err error) {
 		}
 	}
 
+	if name != "" {
+		available, err := apiClient.AppNameAvailable(ctx, name)
+		if err != nil {
+			return err
+		}
+		if !available {
+			return appNameTakenErr(name)
+		}
+	}
+
 	org, err := prompt.Org(ctx)
 	if err != nil {
 		return
@@ -108,6 +120,10 @@ func RunCreate(ctx context.Context) (err error) {
 
 	app, err := apiClient.CreateApp(ctx, input)
 	if err != nil {
+		// The name can still be claimed between the check above and here.
+		if name != "" && isAppNameTakenErr(err) {
+			return appNameTakenErr(name)
+		}
 		return err
 	}
 
@@ -125,3 +141,15 @@ func RunCreate(ctx context.Context) (err error) {
 	fmt.Fprintf(io.Out, "New app created: %s\n", app.Name)
 	return nil
 }
+
+func appNameTakenErr(name string) error {
+	return flyerr.GenericErr{
+		Err:      fmt.Sprintf("app name %s is already taken", name),
+		Descript: "each Fly.io app must have a unique name",
+		Suggest:  "Please specify a different app name, or use --generate-name",
+	}
+}
+
+func isAppNameTakenErr(err error) bool {
+	return strings.Contains(err.Error(), "Name has already been taken")
+}
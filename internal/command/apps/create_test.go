@@ -0,0 +1,69 @@
+package apps
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyerr"
+	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/internal/mock"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func runCreate(t *testing.T, client *mock.Client, args ...string) error {
+	t.Helper()
+
+	cmd := newCreate()
+	require.NoError(t, cmd.ParseFlags(args))
+
+	ios, _, _, _ := iostreams.Test()
+	ctx := iostreams.NewContext(context.Background(), ios)
+	ctx = config.NewContext(ctx, &config.Config{Organization: "personal"})
+	ctx = flyutil.NewContextWithClient(ctx, client)
+	ctx = flag.NewContext(ctx, cmd.Flags())
+
+	return RunCreate(ctx)
+}
+
+func TestCreateNameTaken(t *testing.T) {
+	client := &mock.Client{
+		AppNameAvailableFunc: func(ctx context.Context, appName string) (bool, error) {
+			return false, nil
+		},
+		CreateAppFunc: func(ctx context.Context, input fly.CreateAppInput) (*fly.App, error) {
+			t.Fatal("CreateApp should not be called for a taken name")
+			return nil, nil
+		},
+	}
+
+	err := runCreate(t, client, "my-app")
+	require.Error(t, err)
+	assert.Equal(t, "app name my-app is already taken", err.Error())
+	assert.Equal(t, "Please specify a different app name, or use --generate-name", flyerr.GetErrorSuggestion(err))
+}
+
+func TestCreateNameTakenConcurrently(t *testing.T) {
+	client := &mock.Client{
+		AppNameAvailableFunc: func(ctx context.Context, appName string) (bool, error) {
+			return true, nil
+		},
+		GetOrganizationsFunc: func(ctx context.Context, filters ...fly.OrganizationFilter) ([]fly.Organization, error) {
+			return []fly.Organization{{ID: "org1", Slug: "personal", Type: "PERSONAL"}}, nil
+		},
+		CreateAppFunc: func(ctx context.Context, input fly.CreateAppInput) (*fly.App, error) {
+			assert.Equal(t, "org1", input.OrganizationID)
+			return nil, errors.New("Validation failed: Name has already been taken")
+		},
+	}
+
+	err := runCreate(t, client, "my-app")
+	require.Error(t, err)
+	assert.Equal(t, "app name my-app is already taken", err.Error())
+	assert.Equal(t, "Please specify a different app name, or use --generate-name", flyerr.GetErrorSuggestion(err))
+}
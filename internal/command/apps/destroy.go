@@ -2,13 +2,19 @@ package apps
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
 	"github.com/superfly/flyctl/gql"
 	"github.com/superfly/flyctl/internal/command/deploy/statics"
+	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag/completion"
+	"github.com/superfly/flyctl/internal/flapsutil"
 	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/internal/render"
 
 	"github.com/superfly/flyctl/iostreams"
 
@@ -19,7 +25,10 @@ import (
 
 func newDestroy() *cobra.Command {
 	const (
-		long = "Delete one or more applications from the Fly platform."
+		long = `Delete one or more applications from the Fly platform.
+Volumes, IP addresses, certificates, extensions and, for Postgres apps,
+database attachments of each app are listed before it's destroyed. Unless
+--yes is given, the app name must be typed to confirm the deletion.`
 
 		short = "Permanently destroy one or more apps."
 		usage = "destroy <app name(s)>"
@@ -32,6 +41,7 @@ func newDestroy() *cobra.Command {
 
 	flag.Add(destroy,
 		flag.Yes(),
+		flag.JSONOutput(),
 	)
 
 	destroy.ValidArgsFunction = completion.Adapt(completion.CompleteApps)
@@ -40,27 +50,56 @@ func newDestroy() *cobra.Command {
 	return destroy
 }
 
+// destroyedApp describes an app removed by RunDestroy, along with the
+// resources that were attached to it.
+type destroyedApp struct {
+	Name                string   `json:"name"`
+	Volumes             []string `json:"volumes"`
+	IPAddresses         []string `json:"ip_addresses"`
+	Certificates        []string `json:"certificates"`
+	Extensions          []string `json:"extensions"`
+	PostgresAttachments []string `json:"postgres_attachments"`
+	StaticsBucket       string   `json:"statics_bucket,omitempty"`
+}
+
 // TODO: make internal once the destroy package is removed
 func RunDestroy(ctx context.Context) error {
 	io := iostreams.FromContext(ctx)
 	colorize := io.ColorScheme()
 	apps := flag.Args(ctx)
 	client := flyutil.ClientFromContext(ctx)
+	jsonOutput := config.FromContext(ctx).JSONOutput
 
 	if len(apps) == 0 {
 		return fmt.Errorf("no app names provided")
 	}
 
+	var destroyed []destroyedApp
+
 	for _, appName := range apps {
+		app, err := client.GetApp(ctx, appName)
+		if err != nil {
+			return err
+		}
 
-		if !flag.GetYes(ctx) {
-			const msg = "Destroying an app is not reversible."
-			fmt.Fprintln(io.ErrOut, colorize.Red(msg))
+		// Listing the attached resources is informational, it doesn't stop
+		// the app from being destroyed.
+		result, err := attachedResources(ctx, app)
+		if err != nil {
+			fmt.Fprintf(io.ErrOut, "Warning: could not list all the resources attached to %s: %v\n", appName, err)
+		}
 
-			switch confirmed, err := prompt.Confirmf(ctx, "Destroy app %s?", appName); {
+		// Both go to stderr, so they're shown with --yes too without getting
+		// in the way of --json.
+		fmt.Fprintln(io.ErrOut, colorize.Red("Destroying an app is not reversible."))
+		printAttachedResources(io, result)
+
+		if !flag.GetYes(ctx) {
+			var typed string
+			switch err := prompt.String(ctx, &typed, fmt.Sprintf("Type the app name (%s) to confirm:", appName), "", true); {
 			case err == nil:
-				if !confirmed {
-					return nil
+				if typed != appName {
+					return fmt.Errorf("confirmation %q does not match app name %s, not destroying", typed, appName)
 				}
 			case prompt.IsNonInteractive(err):
 				return prompt.NonInteractiveError("yes flag must be specified when not running interactively")
@@ -69,10 +108,6 @@ func RunDestroy(ctx context.Context) error {
 			}
 		}
 
-		app, err := client.GetApp(ctx, appName)
-		if err != nil {
-			return err
-		}
 		org, err := client.GetOrganizationBySlug(ctx, app.Organization.Slug)
 		if err != nil {
 			return err
@@ -88,15 +123,140 @@ func RunDestroy(ctx context.Context) error {
 			if err != nil {
 				return err
 			}
-			fmt.Fprintf(io.Out, "Destroyed statics bucket %s\n", bucket.Name)
+			result.StaticsBucket = bucket.Name
+			if !jsonOutput {
+				fmt.Fprintf(io.Out, "Destroyed statics bucket %s\n", bucket.Name)
+			}
 		}
 
 		if err := client.DeleteApp(ctx, appName); err != nil {
 			return err
 		}
 
-		fmt.Fprintf(io.Out, "Destroyed app %s\n", appName)
+		destroyed = append(destroyed, *result)
+		if !jsonOutput {
+			fmt.Fprintf(io.Out, "Destroyed app %s\n", appName)
+		}
+	}
+
+	if jsonOutput {
+		return render.JSON(io.Out, destroyed)
 	}
 
 	return nil
 }
+
+// attachedResources collects the volumes, IP addresses, certificates,
+// extensions and, for a Postgres app, the database attachments that will go
+// away along with app. The result is always set; the error reports the kinds
+// of resources that couldn't be listed.
+func attachedResources(ctx context.Context, app *fly.App) (*destroyedApp, error) {
+	client := flyutil.ClientFromContext(ctx)
+	appName := app.Name
+	result := &destroyedApp{
+		Name:                appName,
+		Volumes:             []string{},
+		IPAddresses:         []string{},
+		Certificates:        []string{},
+		Extensions:          []string{},
+		PostgresAttachments: []string{},
+	}
+
+	var errs []error
+
+	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{AppName: appName})
+	if err == nil {
+		var volumes []fly.Volume
+		if volumes, err = flapsClient.GetVolumes(ctx); err == nil {
+			for _, v := range volumes {
+				result.Volumes = append(result.Volumes, fmt.Sprintf("%s (%s, %s)", v.ID, v.Name, v.Region))
+			}
+		}
+	}
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed retrieving volumes: %w", err))
+	}
+
+	if ips, err := client.GetIPAddresses(ctx, appName); err != nil {
+		errs = append(errs, fmt.Errorf("failed retrieving IP addresses: %w", err))
+	} else {
+		for _, ip := range ips {
+			result.IPAddresses = append(result.IPAddresses, ip.Address)
+		}
+	}
+
+	if certs, err := client.GetAppCertificates(ctx, appName); err != nil {
+		errs = append(errs, fmt.Errorf("failed retrieving certificates: %w", err))
+	} else {
+		for _, cert := range certs {
+			result.Certificates = append(result.Certificates, cert.Hostname)
+		}
+	}
+
+	_ = `# @genqlient
+	query ListAppExtensions($appName: String!) {
+		app(name: $appName) {
+			addOns {
+				nodes {
+					name
+					addOnProvider {
+						displayName
+					}
+				}
+			}
+		}
+	}
+	`
+	if resp, err := gql.ListAppExtensions(ctx, client.GenqClient(), appName); err != nil {
+		errs = append(errs, fmt.Errorf("failed retrieving extensions: %w", err))
+	} else {
+		for _, ext := range resp.App.AddOns.Nodes {
+			result.Extensions = append(result.Extensions, fmt.Sprintf("%s (%s)", ext.Name, ext.AddOnProvider.DisplayName))
+		}
+	}
+
+	if app.PostgresAppRole != nil {
+		_ = `# @genqlient
+		query ListPostgresAttachments($postgresAppName: String!) {
+			postgresAttachments(postgresAppName: $postgresAppName) {
+				nodes {
+					databaseName
+					databaseUser
+				}
+			}
+		}
+		`
+		if resp, err := gql.ListPostgresAttachments(ctx, client.GenqClient(), appName); err != nil {
+			errs = append(errs, fmt.Errorf("failed retrieving postgres attachments: %w", err))
+		} else {
+			for _, a := range resp.PostgresAttachments.Nodes {
+				result.PostgresAttachments = append(result.PostgresAttachments, fmt.Sprintf("%s (user %s)", a.DatabaseName, a.DatabaseUser))
+			}
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+func printAttachedResources(io *iostreams.IOStreams, app *destroyedApp) {
+	if len(app.Volumes)+len(app.IPAddresses)+len(app.Certificates)+len(app.Extensions)+len(app.PostgresAttachments) == 0 {
+		return
+	}
+
+	fmt.Fprintf(io.ErrOut, "The following resources attached to %s will also be destroyed:\n", app.Name)
+	for _, v := range app.Volumes {
+		fmt.Fprintf(io.ErrOut, "  volume       %s\n", v)
+	}
+	for _, ip := range app.IPAddresses {
+		fmt.Fprintf(io.ErrOut, "  ip address   %s\n", ip)
+	}
+	for _, cert := range app.Certificates {
+		fmt.Fprintf(io.ErrOut, "  certificate  %s\n", cert)
+	}
+	for _, ext := range app.Extensions {
+		fmt.Fprintf(io.ErrOut, "  extension    %s\n", ext)
+	}
+	for _, db := range app.PostgresAttachments {
+		fmt.Fprintf(io.ErrOut, "  database     %s\n", db)
+	}
+}
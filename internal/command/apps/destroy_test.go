@@ -0,0 +1,134 @@
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	genq "github.com/Khan/genqlient/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/tokens"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/internal/mock"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// genqResponses answers GraphQL requests with canned data, by operation.
+type genqResponses map[string]string
+
+func (r genqResponses) MakeRequest(ctx context.Context, req *genq.Request, resp *genq.Response) error {
+	data, ok := r[req.OpName]
+	if !ok {
+		data = "{}"
+	}
+	return json.Unmarshal([]byte(data), resp.Data)
+}
+
+type destroyTest struct {
+	app     *fly.App
+	deleted []string
+	stdout  string
+	stderr  string
+}
+
+func (d *destroyTest) run(t *testing.T, jsonOutput bool, args ...string) error {
+	t.Helper()
+
+	flaps := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/apps/"+d.app.Name+"/volumes", r.URL.Path)
+		w.Write([]byte(`[{"id": "vol_123", "name": "data", "region": "ord"}]`))
+	}))
+	defer flaps.Close()
+	t.Setenv("FLY_FLAPS_BASE_URL", flaps.URL)
+
+	client := &mock.Client{
+		GetAppFunc: func(ctx context.Context, appName string) (*fly.App, error) {
+			return d.app, nil
+		},
+		GetIPAddressesFunc: func(ctx context.Context, appName string) ([]fly.IPAddress, error) {
+			return []fly.IPAddress{{Address: "2a09:8280:1::1"}}, nil
+		},
+		GetAppCertificatesFunc: func(ctx context.Context, appName string) ([]fly.AppCertificateCompact, error) {
+			return []fly.AppCertificateCompact{{Hostname: "example.com"}}, nil
+		},
+		GetOrganizationBySlugFunc: func(ctx context.Context, slug string) (*fly.Organization, error) {
+			return &fly.Organization{Slug: slug}, nil
+		},
+		GenqClientFunc: func() genq.Client {
+			return genqResponses{
+				"ListAppExtensions":       `{"app": {"addOns": {"nodes": [{"name": "my-bucket", "addOnProvider": {"displayName": "Tigris"}}]}}}`,
+				"ListPostgresAttachments": `{"postgresAttachments": {"nodes": [{"databaseName": "my_app", "databaseUser": "my_app"}]}}`,
+			}
+		},
+		DeleteAppFunc: func(ctx context.Context, appName string) error {
+			d.deleted = append(d.deleted, appName)
+			return nil
+		},
+	}
+
+	cmd := newDestroy()
+	require.NoError(t, cmd.ParseFlags(args))
+
+	ios, _, stdout, stderr := iostreams.Test()
+	ctx := iostreams.NewContext(context.Background(), ios)
+	ctx = config.NewContext(ctx, &config.Config{JSONOutput: jsonOutput, Tokens: tokens.Parse("test-token")})
+	ctx = flyutil.NewContextWithClient(ctx, client)
+	ctx = flag.NewContext(ctx, cmd.Flags())
+
+	err := RunDestroy(ctx)
+	d.stdout, d.stderr = stdout.String(), stderr.String()
+	return err
+}
+
+func TestDestroyWithYesListsAttachedResources(t *testing.T) {
+	d := &destroyTest{app: &fly.App{Name: "my-app", Organization: fly.Organization{Slug: "acme"}}}
+	require.NoError(t, d.run(t, true, "--yes", "my-app"))
+
+	assert.Equal(t, []string{"my-app"}, d.deleted)
+	assert.Contains(t, d.stderr, "Destroying an app is not reversible.")
+	assert.Contains(t, d.stderr, "volume       vol_123 (data, ord)")
+	assert.Contains(t, d.stderr, "ip address   2a09:8280:1::1")
+	assert.Contains(t, d.stderr, "certificate  example.com")
+	assert.Contains(t, d.stderr, "extension    my-bucket (Tigris)")
+	assert.NotContains(t, d.stderr, "database")
+
+	// Only the JSON goes to stdout.
+	var destroyed []destroyedApp
+	require.NoError(t, json.Unmarshal([]byte(d.stdout), &destroyed))
+	assert.Equal(t, []destroyedApp{{
+		Name:                "my-app",
+		Volumes:             []string{"vol_123 (data, ord)"},
+		IPAddresses:         []string{"2a09:8280:1::1"},
+		Certificates:        []string{"example.com"},
+		Extensions:          []string{"my-bucket (Tigris)"},
+		PostgresAttachments: []string{},
+	}}, destroyed)
+}
+
+func TestDestroyPostgresListsAttachments(t *testing.T) {
+	d := &destroyTest{app: &fly.App{
+		Name:            "my-db",
+		Organization:    fly.Organization{Slug: "acme"},
+		PostgresAppRole: &struct{ Name string }{Name: "postgres_cluster"},
+	}}
+	require.NoError(t, d.run(t, false, "--yes", "my-db"))
+
+	assert.Contains(t, d.stderr, "database     my_app (user my_app)")
+	assert.Contains(t, d.stdout, "Destroyed app my-db")
+}
+
+func TestDestroyNonInteractiveNeedsYes(t *testing.T) {
+	d := &destroyTest{app: &fly.App{Name: "my-app", Organization: fly.Organization{Slug: "acme"}}}
+	err := d.run(t, false, "my-app")
+
+	assert.True(t, prompt.IsNonInteractive(err))
+	assert.Empty(t, d.deleted)
+	assert.Contains(t, d.stderr, "volume       vol_123 (data, ord)")
+}
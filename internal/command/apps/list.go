@@ -33,6 +33,7 @@ the name, owner (org), status, and date/time of latest deploy for each app.
 	)
 
 	flag.Add(cmd, flag.JSONOutput())
+	flag.Add(cmd, flag.ListOutput())
 	flag.Add(cmd, flag.Org())
 	flag.Add(cmd, flag.Bool{
 		Name:        "quiet",
@@ -44,10 +45,17 @@ the name, owner (org), status, and date/time of latest deploy for each app.
 	return cmd
 }
 
+// listColumns are the columns of the list, for --columns and --sort.
+var listColumns = []string{"Name", "Owner", "Status", "Latest Deploy"}
+
 func runList(ctx context.Context) (err error) {
 	client := flyutil.ClientFromContext(ctx)
 	silence := flag.GetBool(ctx, "quiet")
 	cfg := config.FromContext(ctx)
+	listOpts, err := render.ListOptionsFromContext(ctx, listColumns...)
+	if err != nil {
+		return err
+	}
 	org, err := getOrg(ctx)
 	if err != nil {
 		return fmt.Errorf("error getting organization: %w", err)
@@ -99,9 +107,7 @@ func runList(ctx context.Context) (err error) {
 		})
 	}
 
-	_ = render.Table(out, "", rows, "Name", "Owner", "Status", "Latest Deploy")
-
-	return
+	return render.List(out, listOpts, "", rows, listColumns...)
 }
 
 func getOrg(ctx context.Context) (*fly.Organization, error) {
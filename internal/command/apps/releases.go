@@ -2,18 +2,21 @@ package apps
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
 
 	"github.com/spf13/cobra"
 
 	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
 	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/flyutil"
 	"github.com/superfly/flyctl/internal/format"
+	"github.com/superfly/flyctl/internal/gitinfo"
 	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/iostreams"
 )
@@ -23,6 +26,10 @@ func NewReleases() (cmd *cobra.Command) {
 	const (
 		long = `List all the releases of the application onto the Fly platform,
 including type, when, success/fail and which user triggered the release.
+
+With --rollback-to and --dry-run, preview what rolling back to a release
+would deploy: its image and strategy, and how its config differs from the
+current release's.
 `
 		short = "List app releases"
 	)
@@ -42,12 +49,28 @@ including type, when, success/fail and which user triggered the release.
 			Name:        "image",
 			Description: "Display the Docker image reference of the release",
 		},
+		flag.String{
+			Name:        "rollback-to",
+			Description: "The release version to preview a rollback to, with --dry-run",
+		},
+		flag.Bool{
+			Name:        "dry-run",
+			Description: "Show what rolling back to the --rollback-to release would deploy, without deploying it",
+		},
 	)
 
+	cmd.AddCommand(newReleasesDiff())
+
 	return
 }
 
 func runReleases(ctx context.Context) error {
+	if flag.IsSpecified(ctx, "rollback-to") {
+		return runRollbackDryRun(ctx)
+	} else if flag.GetBool(ctx, "dry-run") {
+		return fmt.Errorf("--dry-run can only be used with --rollback-to")
+	}
+
 	var (
 		appName = appconfig.NameFromContext(ctx)
 		client  = flyutil.ClientFromContext(ctx)
@@ -63,23 +86,84 @@ func runReleases(ctx context.Context) error {
 		return releases[i].Version > releases[j].Version
 	})
 
+	// The commits are extra details, the releases are still listed without them.
+	commits, err := releaseCommits(ctx, appName, 25)
+	if err != nil {
+		fmt.Fprintf(iostreams.FromContext(ctx).ErrOut, "Warning: failed retrieving the commits of the releases of %s: %v\n", appName, err)
+	}
+
 	if config.FromContext(ctx).JSONOutput {
-		return render.JSON(out, releases)
+		withCommits := make([]releaseWithGit, 0, len(releases))
+		for _, release := range releases {
+			withCommits = append(withCommits, releaseWithGit{Release: release, Git: commits[release.Version]})
+		}
+		return render.JSON(out, withCommits)
 	}
 
-	rows, headers := formatMachinesReleases(releases, flag.GetBool(ctx, "image"))
+	rows, headers := formatMachinesReleases(releases, commits, flag.GetBool(ctx, "image"))
 	return render.Table(out, "", rows, headers...)
 }
 
-func formatMachinesReleases(releases []fly.Release, image bool) ([][]string, []string) {
+// releaseWithGit is a release with the git details deploy recorded for it.
+type releaseWithGit struct {
+	fly.Release
+	Git *gitinfo.Info `json:"Git,omitempty"`
+}
+
+// releaseCommits returns the git details deploy recorded in the metadata of
+// the app's latest releases, by release version.
+func releaseCommits(ctx context.Context, appName string, limit int) (map[int]*gitinfo.Info, error) {
+	client := flyutil.ClientFromContext(ctx).GenqClient()
+
+	_ = `# @genqlient
+	query GetAppReleasesMetadata($appName: String!, $limit: Int!) {
+		app(name: $appName) {
+			releases(first: $limit) {
+				nodes {
+					version
+					metadata
+				}
+			}
+		}
+	}
+	`
+
+	resp, err := gql.GetAppReleasesMetadata(ctx, client, appName, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := map[int]*gitinfo.Info{}
+	for _, node := range resp.App.Releases.Nodes {
+		// Metadata is arbitrary JSON; round-trip it to pick out the git details.
+		b, err := json.Marshal(node.Metadata)
+		if err != nil {
+			continue
+		}
+		var metadata struct {
+			Git *gitinfo.Info `json:"git"`
+		}
+		if json.Unmarshal(b, &metadata) == nil && metadata.Git != nil && metadata.Git.Commit != "" {
+			commits[node.Version] = metadata.Git
+		}
+	}
+	return commits, nil
+}
+
+func formatMachinesReleases(releases []fly.Release, commits map[int]*gitinfo.Info, image bool) ([][]string, []string) {
 	var rows [][]string
 	for _, release := range releases {
+		var commit string
+		if info := commits[release.Version]; info != nil {
+			commit = info.ShortCommit()
+		}
 		row := []string{
 			fmt.Sprintf("v%d", release.Version),
 			release.Status,
 			release.Description,
 			release.User.Email,
 			format.RelativeTime(release.CreatedAt),
+			commit,
 		}
 		if image {
 			row = append(row, release.ImageRef)
@@ -93,6 +177,7 @@ func formatMachinesReleases(releases []fly.Release, image bool) ([][]string, []s
 		"Description",
 		"User",
 		"Date",
+		"Commit",
 	}
 	if image {
 		headers = append(headers, "Docker Image")
@@ -0,0 +1,233 @@
+package apps
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newReleasesDiff() *cobra.Command {
+	const (
+		long = `Compare two releases of the application: the change of their Docker
+image, and a diff of their configs. With --json, the config settings that
+were added, removed and changed are listed instead.
+`
+		short = "Compare two releases"
+		usage = "diff <from-version> <to-version>"
+	)
+
+	cmd := command.New(usage, short, long, runReleasesDiff,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+
+	cmd.Args = cobra.ExactArgs(2)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.JSONOutput(),
+	)
+
+	return cmd
+}
+
+func runReleasesDiff(ctx context.Context) error {
+	var (
+		appName = appconfig.NameFromContext(ctx)
+		io      = iostreams.FromContext(ctx)
+		args    = flag.Args(ctx)
+	)
+
+	fromVersion, err := parseReleaseVersion(args[0])
+	if err != nil {
+		return err
+	}
+	toVersion, err := parseReleaseVersion(args[1])
+	if err != nil {
+		return err
+	}
+
+	from, err := getReleaseConfig(ctx, appName, fromVersion)
+	if err != nil {
+		return err
+	}
+	to, err := getReleaseConfig(ctx, appName, toVersion)
+	if err != nil {
+		return err
+	}
+
+	diff, err := diffReleases(from, to)
+	if err != nil {
+		return err
+	}
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(io.Out, diff)
+	}
+	return diff.render(io.Out, io.ColorScheme())
+}
+
+// parseReleaseVersion parses a release version, like 12 or v12.
+func parseReleaseVersion(v string) (int, error) {
+	version, err := strconv.Atoi(strings.TrimPrefix(v, "v"))
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid release version %q, it must be a version like 12 or v12", v)
+	}
+	return version, nil
+}
+
+// releaseConfig is the image and config of a release.
+type releaseConfig struct {
+	Version  int
+	ImageRef string
+	// Image is the digest of the release's image, or its reference when the
+	// image details aren't known.
+	Image    string
+	Strategy string
+	Config   *appconfig.Config
+}
+
+// getReleaseConfig returns the image and config of the given release
+// version of the app.
+func getReleaseConfig(ctx context.Context, appName string, version int) (*releaseConfig, error) {
+	client := flyutil.ClientFromContext(ctx).GenqClient()
+
+	_ = `# @genqlient
+	query GetAppReleaseConfig($appName: String!, $version: Int!) {
+		app(name: $appName) {
+			# @genqlient(pointer: true)
+			release(version: $version) {
+				version
+				deploymentStrategy
+				imageRef
+				# @genqlient(pointer: true)
+				image {
+					digest
+				}
+				# @genqlient(pointer: true)
+				config {
+					definition
+				}
+			}
+		}
+	}
+	`
+
+	resp, err := gql.GetAppReleaseConfig(ctx, client, appName, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release v%d of %s: %w", version, appName, err)
+	}
+	release := resp.App.Release
+	if release == nil {
+		return nil, fmt.Errorf("app %s has no release v%d, run 'fly releases' to list its releases", appName, version)
+	}
+
+	rc := &releaseConfig{
+		Version:  release.Version,
+		ImageRef: release.ImageRef,
+		Image:    release.ImageRef,
+		Strategy: string(release.DeploymentStrategy),
+	}
+	if release.Image != nil && release.Image.Digest != "" {
+		rc.Image = release.Image.Digest
+	}
+
+	if release.Config == nil {
+		return nil, fmt.Errorf("release v%d of %s has no config", version, appName)
+	}
+	definition, ok := release.Config.Definition.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("likely a bug, could not convert config definition of type %T to api map[string]any", release.Config.Definition)
+	}
+	rc.Config, err = appconfig.FromDefinition(fly.DefinitionPtr(definition))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the config of release v%d: %w", version, err)
+	}
+	return rc, nil
+}
+
+// imageChange is the change of image between two releases.
+type imageChange struct {
+	From string
+	To   string
+}
+
+// releasesDiff is what changed between two releases.
+type releasesDiff struct {
+	From int
+	To   int
+	// Image is nil when both releases have the same image.
+	Image   *imageChange `json:",omitempty"`
+	Added   []appconfig.SettingChange
+	Removed []appconfig.SettingChange
+	Changed []appconfig.SettingChange
+
+	from, to *releaseConfig
+}
+
+// diffReleases compares the image and config of two releases.
+func diffReleases(from, to *releaseConfig) (*releasesDiff, error) {
+	changes, err := appconfig.Diff(from.Config, to.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &releasesDiff{
+		From:    from.Version,
+		To:      to.Version,
+		Added:   []appconfig.SettingChange{},
+		Removed: []appconfig.SettingChange{},
+		Changed: []appconfig.SettingChange{},
+		from:    from,
+		to:      to,
+	}
+	if from.Image != to.Image {
+		diff.Image = &imageChange{From: from.Image, To: to.Image}
+	}
+	for _, c := range changes {
+		switch {
+		case c.Added():
+			diff.Added = append(diff.Added, c)
+		case c.Removed():
+			diff.Removed = append(diff.Removed, c)
+		default:
+			diff.Changed = append(diff.Changed, c)
+		}
+	}
+	return diff, nil
+}
+
+// render writes the image change and the config diff.
+func (d *releasesDiff) render(w io.Writer, colorize *iostreams.ColorScheme) error {
+	if d.Image != nil {
+		fmt.Fprintf(w, "Image: %s → %s\n", d.Image.From, d.Image.To)
+	} else {
+		fmt.Fprintf(w, "Image: unchanged (%s)\n", d.from.Image)
+	}
+
+	if len(d.Added)+len(d.Removed)+len(d.Changed) == 0 {
+		fmt.Fprintln(w, "Config: unchanged")
+		return nil
+	}
+	diff, err := appconfig.TOMLDiff(d.from.Config, d.to.Config, colorize)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Config (v%d → v%d):\n%s\n", d.From, d.To, diff)
+	return nil
+}
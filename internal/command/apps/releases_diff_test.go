@@ -0,0 +1,78 @@
+package apps
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestParseReleaseVersion(t *testing.T) {
+	for _, v := range []string{"12", "v12"} {
+		version, err := parseReleaseVersion(v)
+		require.NoError(t, err, v)
+		assert.Equal(t, 12, version, v)
+	}
+	for _, v := range []string{"", "v", "0", "-3", "latest"} {
+		_, err := parseReleaseVersion(v)
+		assert.ErrorContains(t, err, "invalid release version", v)
+	}
+}
+
+func TestDiffReleases(t *testing.T) {
+	newConfig := func(env map[string]string, port int) *appconfig.Config {
+		cfg := appconfig.NewConfig()
+		cfg.AppName = "my-app"
+		cfg.PrimaryRegion = "ord"
+		cfg.Env = env
+		cfg.HTTPService = &appconfig.HTTPService{InternalPort: port}
+		return cfg
+	}
+	from := &releaseConfig{
+		Version: 3,
+		Image:   "sha256:aaa",
+		Config:  newConfig(map[string]string{"LOG_LEVEL": "info", "PORT": "8080"}, 8080),
+	}
+	to := &releaseConfig{
+		Version: 5,
+		Image:   "sha256:bbb",
+		Config:  newConfig(map[string]string{"LOG_LEVEL": "debug", "FEATURE": "on"}, 8080),
+	}
+
+	diff, err := diffReleases(from, to)
+	require.NoError(t, err)
+	assert.Equal(t, &imageChange{From: "sha256:aaa", To: "sha256:bbb"}, diff.Image)
+	assert.Equal(t, []appconfig.SettingChange{{Setting: "env.FEATURE", New: `"on"`}}, diff.Added)
+	assert.Equal(t, []appconfig.SettingChange{{Setting: "env.PORT", Old: `"8080"`}}, diff.Removed)
+	assert.Equal(t, []appconfig.SettingChange{{Setting: "env.LOG_LEVEL", Old: `"info"`, New: `"debug"`}}, diff.Changed)
+
+	b, err := json.Marshal(diff)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"From": 3,
+		"To": 5,
+		"Image": {"From": "sha256:aaa", "To": "sha256:bbb"},
+		"Added": [{"Setting": "env.FEATURE", "New": "\"on\""}],
+		"Removed": [{"Setting": "env.PORT", "Old": "\"8080\""}],
+		"Changed": [{"Setting": "env.LOG_LEVEL", "Old": "\"info\"", "New": "\"debug\""}]
+	}`, string(b))
+
+	ios, _, _, _ := iostreams.Test()
+	var out bytes.Buffer
+	require.NoError(t, diff.render(&out, ios.ColorScheme()))
+	assert.Contains(t, out.String(), "Image: sha256:aaa → sha256:bbb\n")
+	assert.Contains(t, out.String(), "Config (v3 → v5):\n")
+	assert.Contains(t, out.String(), `FEATURE = 'on'`)
+	assert.Contains(t, out.String(), `PORT = '8080'`)
+
+	diff, err = diffReleases(from, from)
+	require.NoError(t, err)
+	assert.Nil(t, diff.Image)
+	out.Reset()
+	require.NoError(t, diff.render(&out, ios.ColorScheme()))
+	assert.Equal(t, "Image: unchanged (sha256:aaa)\nConfig: unchanged\n", out.String())
+}
@@ -0,0 +1,112 @@
+package apps
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyerr"
+	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// rollbackPlan is what rolling back to a release would deploy.
+type rollbackPlan struct {
+	Version  int
+	ImageRef string
+	Digest   string
+	Strategy string
+	// Diff is the change from the current release to the rolled back one.
+	Diff *releasesDiff
+}
+
+// runRollbackDryRun previews rolling back to the release of --rollback-to:
+// the image and strategy it would deploy, and how it differs from the
+// current release. It fails if the image of that release is missing.
+func runRollbackDryRun(ctx context.Context) error {
+	var (
+		appName = appconfig.NameFromContext(ctx)
+		client  = flyutil.ClientFromContext(ctx)
+		io      = iostreams.FromContext(ctx)
+	)
+
+	version, err := parseReleaseVersion(flag.GetString(ctx, "rollback-to"))
+	if err != nil {
+		return err
+	}
+	if !flag.GetBool(ctx, "dry-run") {
+		return flyerr.GenericErr{
+			Err:     "--rollback-to can only be used with --dry-run for now",
+			Suggest: fmt.Sprintf("Preview the rollback with 'fly releases --rollback-to %d --dry-run', then deploy the image it shows with 'fly deploy --image'", version),
+		}
+	}
+
+	current, err := client.GetAppCurrentReleaseMachines(ctx, appName)
+	if err != nil {
+		return fmt.Errorf("failed to get the current release of %s: %w", appName, err)
+	}
+	if current == nil {
+		return fmt.Errorf("app %s has no release to roll back from", appName)
+	}
+
+	from, err := getReleaseConfig(ctx, appName, current.Version)
+	if err != nil {
+		return err
+	}
+	to, err := getReleaseConfig(ctx, appName, version)
+	if err != nil {
+		return err
+	}
+
+	if to.ImageRef == "" {
+		return fmt.Errorf("release v%d of %s has no image to roll back to", version, appName)
+	}
+	image, err := client.ResolveImageForApp(ctx, appName, to.ImageRef)
+	if err != nil {
+		return fmt.Errorf("failed to resolve the image of release v%d: %w", version, err)
+	}
+	if image == nil {
+		return flyerr.GenericErr{
+			Err:     fmt.Sprintf("the image of release v%d, %s, is missing, so the app can't be rolled back to it", version, to.ImageRef),
+			Suggest: "Run 'fly releases --image' to find a release whose image is still available",
+		}
+	}
+
+	diff, err := diffReleases(from, to)
+	if err != nil {
+		return err
+	}
+	plan := &rollbackPlan{
+		Version:  version,
+		ImageRef: to.ImageRef,
+		Digest:   image.Digest,
+		Strategy: to.Strategy,
+		Diff:     diff,
+	}
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(io.Out, plan)
+	}
+	return plan.render(io.Out, io.ColorScheme())
+}
+
+// render writes the rolled back release, and its diff from the current one.
+func (p *rollbackPlan) render(w io.Writer, colorize *iostreams.ColorScheme) error {
+	fmt.Fprintf(w, "Rolling back to v%d would deploy (dry run, nothing was changed):\n", p.Version)
+	fmt.Fprintf(w, "  Image: %s\n", p.ImageRef)
+	if p.Digest != "" {
+		fmt.Fprintf(w, "  Digest: %s\n", p.Digest)
+	}
+	fmt.Fprintf(w, "  Strategy: %s\n", p.Strategy)
+
+	if p.Diff.From == p.Version {
+		fmt.Fprintf(w, "\nv%d is the current release already.\n", p.Version)
+		return nil
+	}
+	fmt.Fprintf(w, "\nChanges from the current release, v%d:\n", p.Diff.From)
+	return p.Diff.render(w, colorize)
+}
@@ -0,0 +1,43 @@
+package apps
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestRollbackPlanRender(t *testing.T) {
+	cfg := appconfig.NewConfig()
+	cfg.PrimaryRegion = "ord"
+	current := &releaseConfig{Version: 7, ImageRef: "registry.fly.io/my-app:deployment-7", Image: "sha256:bbb", Config: cfg}
+	target := &releaseConfig{Version: 5, ImageRef: "registry.fly.io/my-app:deployment-5", Image: "sha256:aaa", Strategy: "ROLLING", Config: cfg}
+
+	diff, err := diffReleases(current, target)
+	require.NoError(t, err)
+	plan := &rollbackPlan{Version: 5, ImageRef: target.ImageRef, Digest: "sha256:aaa", Strategy: "ROLLING", Diff: diff}
+
+	ios, _, _, _ := iostreams.Test()
+	var out bytes.Buffer
+	require.NoError(t, plan.render(&out, ios.ColorScheme()))
+	assert.Equal(t, `Rolling back to v5 would deploy (dry run, nothing was changed):
+  Image: registry.fly.io/my-app:deployment-5
+  Digest: sha256:aaa
+  Strategy: ROLLING
+
+Changes from the current release, v7:
+Image: sha256:bbb → sha256:aaa
+Config: unchanged
+`, out.String())
+
+	diff, err = diffReleases(target, target)
+	require.NoError(t, err)
+	plan.Diff = diff
+	out.Reset()
+	require.NoError(t, plan.render(&out, ios.ColorScheme()))
+	assert.Contains(t, out.String(), "v5 is the current release already.\n")
+	assert.NotContains(t, out.String(), "Changes from")
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/flag/completion"
+	"github.com/superfly/flyctl/internal/flag/flagnames"
 	"github.com/superfly/flyctl/internal/flapsutil"
 	"github.com/superfly/flyctl/internal/flyutil"
 	"github.com/superfly/flyctl/internal/machine"
@@ -42,6 +43,7 @@ func newRestart() *cobra.Command {
 			Description: "Restarts app without waiting for health checks",
 			Default:     false,
 		},
+		flag.ProcessGroup("Only restart machines in the given process group"),
 	)
 
 	cmd.ValidArgsFunction = completion.Adapt(completion.CompleteApps)
@@ -98,6 +100,11 @@ func runMachinesRestart(ctx context.Context, app *fly.AppCompact) error {
 		return err
 	}
 
+	machines, err = machine.FilterByProcessGroup(machines, flag.GetString(ctx, flagnames.ProcessGroup))
+	if err != nil {
+		return err
+	}
+
 	machines, releaseFunc, err := machine.AcquireLeases(ctx, machines)
 	defer releaseFunc()
 	if err != nil {
@@ -20,7 +20,7 @@ If you do have an account, begin with the AUTH LOGIN subcommand.
 	auth := command.New("auth", short, long, nil)
 
 	auth.AddCommand(
-		newWhoAmI(),
+		NewWhoAmI(),
 		newToken(),
 		newLogin(),
 		newDocker(),
@@ -4,18 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
 	"os"
 	"time"
 
 	"github.com/azazeal/pause"
-	"github.com/briandowns/spinner"
 	"github.com/skratchdot/open-golang/open"
 	"github.com/superfly/fly-go"
 	"github.com/superfly/flyctl/agent"
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flyutil"
 	"github.com/superfly/flyctl/internal/logger"
+	"github.com/superfly/flyctl/internal/spinner"
 	"github.com/superfly/flyctl/internal/state"
 	"github.com/superfly/flyctl/iostreams"
 )
@@ -90,7 +89,7 @@ func RunWebLogin(ctx context.Context, signup bool) (string, error) {
 	colorize := io.ColorScheme()
 	fmt.Fprintf(io.Out, "Opening %s ...\n\n", colorize.Bold(auth.URL))
 
-	token, err := waitForCLISession(ctx, logger, io.ErrOut, auth.ID)
+	token, err := waitForCLISession(ctx, logger, io, auth.ID)
 	switch {
 	case errors.Is(err, context.DeadlineExceeded):
 		return "", errors.New("Login expired, please try again")
@@ -104,14 +103,12 @@ func RunWebLogin(ctx context.Context, signup bool) (string, error) {
 }
 
 // TODO: this does NOT break on interrupts
-func waitForCLISession(parent context.Context, logger *logger.Logger, w io.Writer, id string) (token string, err error) {
+func waitForCLISession(parent context.Context, logger *logger.Logger, streams *iostreams.IOStreams, id string) (token string, err error) {
 	ctx, cancel := context.WithTimeout(parent, 15*time.Minute)
 	defer cancel()
 
-	s := spinner.New(spinner.CharSets[11], 100*time.Millisecond)
-	s.Writer = w
-	s.Prefix = "Waiting for session..."
-	s.Start()
+	s := spinner.Run(streams, "Waiting for session...")
+	defer s.Stop()
 
 	for ctx.Err() == nil {
 		if token, err = fly.GetAccessTokenForCLISession(ctx, id); err != nil {
@@ -124,8 +121,7 @@ func waitForCLISession(parent context.Context, logger *logger.Logger, w io.Write
 
 		logger.Debug("retrieved access token.")
 
-		s.FinalMSG = "Waiting for session... Done\n"
-		s.Stop()
+		s.StopWithMessage("Waiting for session... Done")
 
 		break
 	}
@@ -15,16 +15,20 @@ import (
 	"github.com/superfly/flyctl/internal/render"
 )
 
-func newWhoAmI() *cobra.Command {
+// NewWhoAmI returns the whoami command. It's available both as AUTH WHOAMI
+// and as a top-level command.
+func NewWhoAmI() *cobra.Command {
 	const (
 		long = `Displays the users email address/service identity currently
-authenticated and in use.
+authenticated and in use, along with the organization used by default.
 `
 		short = "Show the currently authenticated user"
 	)
 
-	cmd := command.New("whoami", long, short, runWhoAmI,
-		command.RequireSession)
+	// whoami reports a missing session instead of offering to log in.
+	cmd := command.New("whoami", short, long, runWhoAmI,
+		command.RequireSessionNoLogin)
+	cmd.Args = cobra.NoArgs
 	flag.Add(cmd, flag.JSONOutput())
 	return cmd
 }
@@ -37,14 +41,42 @@ func runWhoAmI(ctx context.Context) error {
 		return fmt.Errorf("failed retrieving current user: %w", err)
 	}
 
+	defaultOrg, err := defaultOrgSlug(ctx, client)
+	if err != nil {
+		return fmt.Errorf("failed retrieving organizations: %w", err)
+	}
+
 	io := iostreams.FromContext(ctx)
 	cfg := config.FromContext(ctx)
 
 	if cfg.JSONOutput {
-		_ = render.JSON(io.Out, map[string]string{"email": user.Email})
-	} else {
-		fmt.Fprintln(io.Out, user.Email)
+		_ = render.JSON(io.Out, map[string]string{"email": user.Email, "default_org": defaultOrg})
+		return nil
+	}
+
+	fmt.Fprintln(io.Out, user.Email)
+	if defaultOrg != "" {
+		fmt.Fprintf(io.ErrOut, "Default organization: %s\n", defaultOrg)
 	}
 
 	return nil
 }
+
+// defaultOrgSlug returns the organization commands use unless told otherwise:
+// the one set with --org or FLY_ORG, falling back to the personal one.
+func defaultOrgSlug(ctx context.Context, client flyutil.Client) (string, error) {
+	if slug := config.FromContext(ctx).Organization; slug != "" {
+		return slug, nil
+	}
+
+	orgs, err := client.GetOrganizations(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, org := range orgs {
+		if org.Type == "PERSONAL" {
+			return org.Slug, nil
+		}
+	}
+	return "", nil
+}
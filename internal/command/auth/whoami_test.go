@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/internal/mock"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestWhoAmI(t *testing.T) {
+	client := &mock.Client{
+		AuthenticatedFunc: func() bool { return true },
+		GetCurrentUserFunc: func(ctx context.Context) (*fly.User, error) {
+			return &fly.User{Email: "dev@example.com"}, nil
+		},
+		GetOrganizationsFunc: func(ctx context.Context, filters ...fly.OrganizationFilter) ([]fly.Organization, error) {
+			return []fly.Organization{
+				{Slug: "acme", Type: "SHARED"},
+				{Slug: "personal", Type: "PERSONAL"},
+			}, nil
+		},
+	}
+
+	ios, _, out, _ := iostreams.Test()
+	ctx := iostreams.NewContext(context.Background(), ios)
+	ctx = config.NewContext(ctx, &config.Config{JSONOutput: true})
+	ctx = flyutil.NewContextWithClient(ctx, client)
+
+	require.NoError(t, runWhoAmI(ctx))
+
+	var got map[string]string
+	require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+	assert.Equal(t, map[string]string{"email": "dev@example.com", "default_org": "personal"}, got)
+}
@@ -0,0 +1,25 @@
+// Package builds implements the builds command chain.
+package builds
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/superfly/flyctl/internal/command"
+)
+
+func New() *cobra.Command {
+	const (
+		short = "Inspect an app's builds"
+		long  = `Commands for listing an app's past builds and reading their logs.
+Useful for debugging remote builds after the terminal that started them has closed.`
+	)
+
+	cmd := command.New("builds", short, long, nil)
+
+	cmd.AddCommand(
+		newList(),
+		newLogs(),
+	)
+
+	return cmd
+}
@@ -0,0 +1,109 @@
+package builds
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/internal/format"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newList() *cobra.Command {
+	const (
+		short = "List recent builds of an app"
+		long  = `List the most recent builds of an app, including their status,
+image and the user who started them.`
+	)
+
+	cmd := command.New("list", short, long, runList,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+	cmd.Aliases = []string{"ls"}
+	cmd.Args = cobra.NoArgs
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.JSONOutput(),
+		flag.ListOutput(),
+		flag.Int{
+			Name:        "limit",
+			Description: "Number of builds to list",
+			Default:     25,
+		},
+	)
+
+	return cmd
+}
+
+// buildColumns are the columns of the list, for --columns and --sort.
+var buildColumns = []string{"ID", "Number", "Status", "Image", "User", "Created"}
+
+func runList(ctx context.Context) error {
+	var (
+		appName = appconfig.NameFromContext(ctx)
+		client  = flyutil.ClientFromContext(ctx).GenqClient()
+		out     = iostreams.FromContext(ctx).Out
+	)
+
+	listOpts, err := render.ListOptionsFromContext(ctx, buildColumns...)
+	if err != nil {
+		return err
+	}
+
+	_ = `# @genqlient
+	query ListAppBuilds($appName: String!, $limit: Int!) {
+		app(name: $appName) {
+			builds(first: $limit) {
+				nodes {
+					id
+					number
+					status
+					image
+					createdAt
+					createdBy {
+						email
+					}
+				}
+			}
+		}
+	}
+	`
+
+	resp, err := gql.ListAppBuilds(ctx, client, appName, flag.GetInt(ctx, "limit"))
+	if err != nil {
+		return fmt.Errorf("failed retrieving builds of %s: %w", appName, err)
+	}
+	builds := resp.App.Builds.Nodes
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(out, builds)
+	}
+
+	return render.List(out, listOpts, "", formatBuilds(builds), buildColumns...)
+}
+
+func formatBuilds(builds []gql.ListAppBuildsAppBuildsBuildConnectionNodesBuild) [][]string {
+	rows := make([][]string, 0, len(builds))
+	for _, b := range builds {
+		rows = append(rows, []string{
+			b.Id,
+			fmt.Sprint(b.Number),
+			b.Status,
+			b.Image,
+			b.CreatedBy.Email,
+			format.RelativeTime(b.CreatedAt),
+		})
+	}
+	return rows
+}
@@ -0,0 +1,27 @@
+package builds
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/superfly/flyctl/gql"
+)
+
+func TestFormatBuilds(t *testing.T) {
+	rows := formatBuilds([]gql.ListAppBuildsAppBuildsBuildConnectionNodesBuild{
+		{
+			Id:        "build_123",
+			Number:    7,
+			Status:    "failed",
+			Image:     "registry.fly.io/my-app:deployment-01",
+			CreatedAt: time.Now().Add(-time.Hour),
+			CreatedBy: gql.ListAppBuildsAppBuildsBuildConnectionNodesBuildCreatedByUser{Email: "dev@example.com"},
+		},
+	})
+
+	assert.Equal(t, [][]string{
+		{"build_123", "7", "failed", "registry.fly.io/my-app:deployment-01", "dev@example.com", "1h0m ago"},
+	}, rows)
+}
@@ -0,0 +1,154 @@
+package builds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/azazeal/pause"
+	"github.com/spf13/cobra"
+
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/internal/cmdutil"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/iostreams"
+	"github.com/superfly/flyctl/terminal"
+)
+
+// maxConsecutiveFetchErrors bounds how many failed polls in a row --follow
+// tolerates before giving up on a build's logs.
+const maxConsecutiveFetchErrors = 5
+
+func newLogs() *cobra.Command {
+	const (
+		short = "Show the logs of a build"
+		long  = `Show the logs of a build. Use 'builds list' to find the ID of a build.`
+	)
+
+	cmd := command.New("logs <id>", short, long, runLogs,
+		command.RequireSession,
+	)
+	cmd.Args = cobra.ExactArgs(1)
+
+	flag.Add(cmd,
+		flag.Bool{
+			Name:        "follow",
+			Shorthand:   "f",
+			Description: "Keep streaming logs until the build finishes",
+		},
+		flag.Bool{
+			Name:        "plain-logs",
+			Description: "Strip color codes from the logs. This is the default when output is not a terminal",
+		},
+	)
+
+	return cmd
+}
+
+func runLogs(ctx context.Context) error {
+	var (
+		client = flyutil.ClientFromContext(ctx).GenqClient()
+		io     = iostreams.FromContext(ctx)
+		id     = flag.FirstArg(ctx)
+		plain  = flag.GetBool(ctx, "plain-logs") || !io.IsStdoutTTY()
+	)
+
+	_ = `# @genqlient
+	query GetBuildLogs($id: ID!) {
+		node(id: $id) {
+			... on Build {
+				inProgress
+				logs
+			}
+		}
+	}
+	`
+
+	fetch := func(ctx context.Context) (string, bool, error) {
+		resp, err := gql.GetBuildLogs(ctx, client, id)
+		if err != nil {
+			return "", false, fmt.Errorf("failed retrieving build %s: %w", id, err)
+		}
+		build, ok := resp.Node.(*gql.GetBuildLogsNodeBuild)
+		if !ok {
+			return "", false, fmt.Errorf("%w: %s", errBuildNotFound, id)
+		}
+		return plainLogs(build.Logs, plain), build.InProgress, nil
+	}
+
+	if flag.GetBool(ctx, "follow") {
+		return followLogs(ctx, io.Out, fetch, 2*time.Second)
+	}
+
+	logs, inProgress, err := fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(io.Out, logs)
+	if !strings.HasSuffix(logs, "\n") {
+		fmt.Fprintln(io.Out)
+	}
+
+	if inProgress {
+		fmt.Fprintf(io.ErrOut, "Build %s is still in progress; these logs may be incomplete. Use --follow to keep streaming.\n", id)
+	}
+
+	return nil
+}
+
+// plainLogs strips ANSI escape sequences from logs when plain is set. The
+// whole log is stripped at once so --follow offsets stay consistent.
+func plainLogs(logs string, plain bool) string {
+	if !plain {
+		return logs
+	}
+	return cmdutil.StripANSI(logs)
+}
+
+var errBuildNotFound = errors.New("build not found")
+
+type logFetcher func(ctx context.Context) (logs string, inProgress bool, err error)
+
+// followLogs polls a build's logs until it is no longer in progress, writing
+// only the output that wasn't written yet. Transient fetch errors don't end
+// the stream; the next successful poll resumes from the last written offset.
+func followLogs(ctx context.Context, w io.Writer, fetch logFetcher, interval time.Duration) error {
+	var (
+		written  int
+		failures int
+	)
+
+	for {
+		logs, inProgress, err := fetch(ctx)
+		switch {
+		case errors.Is(err, errBuildNotFound):
+			return err
+		case err != nil:
+			failures++
+			if failures >= maxConsecutiveFetchErrors {
+				return err
+			}
+			terminal.Debugf("failed fetching build logs, retrying: %v\n", err)
+		default:
+			failures = 0
+			if len(logs) > written {
+				fmt.Fprint(w, logs[written:])
+				written = len(logs)
+			}
+			if !inProgress {
+				return nil
+			}
+		}
+
+		pause.For(ctx, interval)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
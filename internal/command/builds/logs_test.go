@@ -0,0 +1,69 @@
+package builds
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fetchResult struct {
+	logs       string
+	inProgress bool
+	err        error
+}
+
+func scriptedFetcher(results ...fetchResult) (logFetcher, *int) {
+	var calls int
+	return func(ctx context.Context) (string, bool, error) {
+		r := results[calls]
+		calls++
+		return r.logs, r.inProgress, r.err
+	}, &calls
+}
+
+func TestFollowLogsResumesAfterDisconnect(t *testing.T) {
+	fetch, calls := scriptedFetcher(
+		fetchResult{logs: "step 1\n", inProgress: true},
+		fetchResult{err: errors.New("connection reset by peer")},
+		fetchResult{err: errors.New("connection reset by peer")},
+		fetchResult{logs: "step 1\nstep 2\n", inProgress: true},
+		fetchResult{logs: "step 1\nstep 2\nstep 3\n", inProgress: false},
+	)
+
+	var out bytes.Buffer
+	require.NoError(t, followLogs(context.Background(), &out, fetch, 0))
+	assert.Equal(t, "step 1\nstep 2\nstep 3\n", out.String())
+	assert.Equal(t, 5, *calls)
+}
+
+func TestFollowLogsGivesUpAfterRepeatedErrors(t *testing.T) {
+	results := []fetchResult{{logs: "step 1\n", inProgress: true}}
+	for range maxConsecutiveFetchErrors {
+		results = append(results, fetchResult{err: errors.New("connection refused")})
+	}
+	fetch, _ := scriptedFetcher(results...)
+
+	var out bytes.Buffer
+	err := followLogs(context.Background(), &out, fetch, 0)
+	assert.ErrorContains(t, err, "connection refused")
+	assert.Equal(t, "step 1\n", out.String())
+}
+
+func TestFollowLogsBuildNotFound(t *testing.T) {
+	fetch, calls := scriptedFetcher(fetchResult{err: errBuildNotFound})
+
+	err := followLogs(context.Background(), &bytes.Buffer{}, fetch, 0)
+	assert.ErrorIs(t, err, errBuildNotFound)
+	assert.Equal(t, 1, *calls)
+}
+
+func TestPlainLogs(t *testing.T) {
+	line := "\x1b[1;32m#5 [2/4] RUN npm ci\x1b[0m\n\x1b[31merror\x1b[0m\n"
+
+	assert.Equal(t, "#5 [2/4] RUN npm ci\nerror\n", plainLogs(line, true))
+	assert.Equal(t, line, plainLogs(line, false))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	fly "github.com/superfly/fly-go"
@@ -456,9 +457,28 @@ func printCertificate(ctx context.Context, cert *fly.AppCertificate) {
 	myprnt("Certificate Authority", readableCertAuthority(cert.CertificateAuthority))
 	myprnt("Issued", strings.Join(certtypes, ","))
 	myprnt("Added to App", humanize.Time(cert.CreatedAt))
+	myprnt("Expires", certificateExpiry(cert))
 	myprnt("Source", cert.Source)
 }
 
+// certificateExpiry returns when the first of the issued certificates
+// expires, or "-" if nothing has been issued yet.
+func certificateExpiry(cert *fly.AppCertificate) string {
+	var earliest time.Time
+	for _, v := range cert.Issued.Nodes {
+		if v.ExpiresAt.IsZero() {
+			continue
+		}
+		if earliest.IsZero() || v.ExpiresAt.Before(earliest) {
+			earliest = v.ExpiresAt
+		}
+	}
+	if earliest.IsZero() {
+		return "-"
+	}
+	return fmt.Sprintf("%s (%s)", humanize.Time(earliest), earliest.Format(time.DateOnly))
+}
+
 func readableCertAuthority(ca string) string {
 	if ca == "lets_encrypt" {
 		return "Let's Encrypt"
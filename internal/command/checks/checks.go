@@ -17,6 +17,7 @@ func New() *cobra.Command {
 	listCmd.Aliases = []string{"ls"}
 	flag.Add(listCmd, commonFlags,
 		flag.String{Name: "check-name", Description: "Filter checks by name"},
+		flag.Bool{Name: "failing-only", Description: "Only show checks that aren't passing"},
 	)
 	flag.Add(listCmd, flag.JSONOutput())
 	cmd.AddCommand(listCmd)
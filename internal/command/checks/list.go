@@ -21,6 +21,7 @@ func runAppCheckList(ctx context.Context) error {
 	appName := appconfig.NameFromContext(ctx)
 	out := iostreams.FromContext(ctx).Out
 	nameFilter := flag.GetString(ctx, "check-name")
+	failingOnly := flag.GetBool(ctx, "failing-only")
 
 	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{
 		AppName: appName,
@@ -36,10 +37,16 @@ func runAppCheckList(ctx context.Context) error {
 	sort.Slice(machines, func(i, j int) bool {
 		return machines[i].ID < machines[j].ID
 	})
+	for _, machine := range machines {
+		machine.Checks = filterChecks(machine.Checks, nameFilter, failingOnly)
+	}
 
 	if config.FromContext(ctx).JSONOutput {
 		checks := map[string][]fly.MachineCheckStatus{}
 		for _, machine := range machines {
+			if failingOnly && len(machine.Checks) == 0 {
+				continue
+			}
 			checks[machine.ID] = make([]fly.MachineCheckStatus, len(machine.Checks))
 			for i, check := range machine.Checks {
 				checks[machine.ID][i] = *check
@@ -49,21 +56,37 @@ func runAppCheckList(ctx context.Context) error {
 	}
 
 	fmt.Fprintf(out, "Health Checks for %s\n", appName)
-	table := helpers.MakeSimpleTable(out, []string{"Name", "Status", "Machine", "Last Updated", "Output"})
+	table := helpers.MakeSimpleTable(out, []string{"Name", "Status", "Machine", "Region", "Last Updated", "Output"})
 	table.SetRowLine(true)
 	for _, machine := range machines {
-		sort.Slice(machine.Checks, func(i, j int) bool {
-			return machine.Checks[i].Name < machine.Checks[j].Name
-		})
-
 		for _, check := range machine.Checks {
-			if nameFilter != "" && nameFilter != check.Name {
-				continue
+			lastUpdated := ""
+			if check.UpdatedAt != nil {
+				lastUpdated = format.RelativeTime(*check.UpdatedAt)
 			}
-			table.Append([]string{check.Name, string(check.Status), machine.ID, format.RelativeTime(*check.UpdatedAt), check.Output})
+			table.Append([]string{check.Name, string(check.Status), machine.ID, machine.Region, lastUpdated, check.Output})
 		}
 	}
 	table.Render()
 
 	return nil
 }
+
+// filterChecks returns the checks named name (any name when empty), leaving
+// out passing checks when failingOnly is set. The result is sorted by name.
+func filterChecks(checks []*fly.MachineCheckStatus, name string, failingOnly bool) []*fly.MachineCheckStatus {
+	filtered := make([]*fly.MachineCheckStatus, 0, len(checks))
+	for _, check := range checks {
+		if name != "" && name != check.Name {
+			continue
+		}
+		if failingOnly && check.Status == fly.Passing {
+			continue
+		}
+		filtered = append(filtered, check)
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].Name < filtered[j].Name
+	})
+	return filtered
+}
@@ -0,0 +1,28 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	fly "github.com/superfly/fly-go"
+)
+
+func TestFilterChecks(t *testing.T) {
+	checks := []*fly.MachineCheckStatus{
+		{Name: "tcp", Status: fly.Passing},
+		{Name: "http", Status: fly.Critical},
+		{Name: "disk", Status: fly.Warning},
+	}
+
+	names := func(checks []*fly.MachineCheckStatus) (out []string) {
+		for _, check := range checks {
+			out = append(out, check.Name)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"disk", "http", "tcp"}, names(filterChecks(checks, "", false)))
+	assert.Equal(t, []string{"disk", "http"}, names(filterChecks(checks, "", true)))
+	assert.Equal(t, []string{"http"}, names(filterChecks(checks, "http", true)))
+	assert.Empty(t, filterChecks(checks, "tcp", true))
+}
@@ -30,6 +30,8 @@ import (
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/env"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/flagnames"
+	"github.com/superfly/flyctl/internal/flyerr"
 	"github.com/superfly/flyctl/internal/incidents"
 	"github.com/superfly/flyctl/internal/logger"
 	"github.com/superfly/flyctl/internal/metrics"
@@ -64,6 +66,9 @@ var commonPreparers = []preparers.Preparer{
 	ensureConfigDirPerms,
 	loadCache,
 	preparers.LoadConfig,
+	initOrgCache,
+	applyQuietOutput,
+	applyNoPrompt,
 	startQueryingForNewRelease,
 	promptAndAutoUpdate,
 	startMetrics,
@@ -248,6 +253,12 @@ func ensureConfigDirPerms(parent context.Context) (ctx context.Context, err erro
 	return
 }
 
+// initOrgCache makes the organization resolved by one part of the command
+// available to the rest of it.
+func initOrgCache(ctx context.Context) (context.Context, error) {
+	return prompt.NewContextWithOrgCache(ctx), nil
+}
+
 func loadCache(ctx context.Context) (context.Context, error) {
 	logger := logger.FromContext(ctx)
 
@@ -267,6 +278,22 @@ func loadCache(ctx context.Context) (context.Context, error) {
 	return cache.NewContext(ctx, c), nil
 }
 
+func applyQuietOutput(ctx context.Context) (context.Context, error) {
+	if config.FromContext(ctx).QuietOutput {
+		iostreams.FromContext(ctx).SetQuiet(true)
+	}
+
+	return ctx, nil
+}
+
+func applyNoPrompt(ctx context.Context) (context.Context, error) {
+	if config.FromContext(ctx).NoPrompt {
+		iostreams.FromContext(ctx).SetNeverPrompt(true)
+	}
+
+	return ctx, nil
+}
+
 func startQueryingForNewRelease(ctx context.Context) (context.Context, error) {
 	logger := logger.FromContext(ctx)
 
@@ -365,6 +392,7 @@ func promptAndAutoUpdate(ctx context.Context) (context.Context, error) {
 	if shouldIgnore(ctx, [][]string{
 		{"version"},
 		{"version", "upgrade"},
+		{"version", "check"},
 		{"settings", "autoupdate"},
 	}) {
 		return ctx, nil
@@ -556,7 +584,7 @@ func RequireSession(ctx context.Context) (context.Context, error) {
 	if !flyutil.ClientFromContext(ctx).Authenticated() {
 		io := iostreams.FromContext(ctx)
 		// Ensure we have a session, and that the user hasn't set any flags that would lead them to expect consistent output or a lack of prompts
-		if io.IsInteractive() &&
+		if io.CanPrompt() &&
 			!env.IsCI() &&
 			!flag.GetBool(ctx, "now") &&
 			!flag.GetBool(ctx, "json") &&
@@ -605,6 +633,19 @@ func RequireSession(ctx context.Context) (context.Context, error) {
 	return ctx, nil
 }
 
+// RequireSessionNoLogin is a Preparer like RequireSession, except that it
+// returns fly.ErrNoAuthToken instead of offering to log in when there's no
+// session.
+func RequireSessionNoLogin(ctx context.Context) (context.Context, error) {
+	if !flyutil.ClientFromContext(ctx).Authenticated() {
+		return nil, fly.ErrNoAuthToken
+	}
+
+	config.MonitorTokens(ctx, config.Tokens(ctx), tryOpenUserURL)
+
+	return ctx, nil
+}
+
 // Apply uiex client to uiex
 func RequireUiex(ctx context.Context) (context.Context, error) {
 	cfg := config.FromContext(ctx)
@@ -649,8 +690,9 @@ func LoadAppConfigIfPresent(ctx context.Context) (context.Context, error) {
 	}
 
 	logger := logger.FromContext(ctx)
+	overlayEnv := config.FromContext(ctx).AppConfigEnv
 	for _, path := range appConfigFilePaths(ctx) {
-		switch cfg, err := appconfig.LoadConfig(path); {
+		switch cfg, err := loadAppConfig(path, overlayEnv); {
 		case err == nil:
 			logger.Debugf("app config loaded from %s", path)
 			if err := cfg.SetMachinesPlatform(); err != nil {
@@ -669,6 +711,15 @@ func LoadAppConfigIfPresent(ctx context.Context) (context.Context, error) {
 	return ctx, nil
 }
 
+// loadAppConfig loads the app config at path, merging the overlay for
+// overlayEnv over it when one is selected.
+func loadAppConfig(path, overlayEnv string) (*appconfig.Config, error) {
+	if overlayEnv == "" {
+		return appconfig.LoadConfig(path)
+	}
+	return appconfig.LoadConfigWithOverlay(path, overlayEnv)
+}
+
 // appConfigFilePaths returns the possible paths at which we may find a fly.toml
 // in order of preference. it takes into consideration whether the user has
 // specified a command-line path to a config file.
@@ -699,6 +750,9 @@ func RequireAppName(ctx context.Context) (context.Context, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := checkStrictAppName(ctx); err != nil {
+		return nil, err
+	}
 
 	name := flag.GetApp(ctx)
 	if name == "" {
@@ -728,6 +782,9 @@ func RequireAppNameNoFlag(ctx context.Context) (context.Context, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := checkStrictAppName(ctx); err != nil {
+		return nil, err
+	}
 
 	// First consult with the environment
 	name := env.First("FLY_APP")
@@ -745,6 +802,29 @@ func RequireAppNameNoFlag(ctx context.Context) (context.Context, error) {
 	return appconfig.WithName(ctx, name), nil
 }
 
+// checkStrictAppName makes sure, in strict app mode, that the app named by the
+// app config file, if any, isn't overridden by --app or FLY_APP.
+func checkStrictAppName(ctx context.Context) error {
+	cfg := appconfig.ConfigFromContext(ctx)
+	if !config.FromContext(ctx).StrictApp || cfg == nil || cfg.AppName == "" {
+		return nil
+	}
+
+	if flag.IsSpecified(ctx, flagnames.App) {
+		return flyerr.GenericErr{
+			Err:     fmt.Sprintf("--app can't be used in strict app mode, the app config file %s names the app %s", cfg.ConfigFilePath(), cfg.AppName),
+			Suggest: "Remove --app, or pass --config with the config file of the app to use",
+		}
+	}
+	if name := env.First("FLY_APP"); name != "" && name != cfg.AppName {
+		return flyerr.GenericErr{
+			Err:     fmt.Sprintf("FLY_APP is set to %s, but the app config file %s names the app %s", name, cfg.ConfigFilePath(), cfg.AppName),
+			Suggest: "Unset FLY_APP, or pass --config with the config file of the app to use",
+		}
+	}
+	return nil
+}
+
 // LoadAppNameIfPresent is a Preparer which adds app name if the user has used --app or there appConfig
 // but unlike RequireAppName it does not error if the user has not specified an app name.
 func LoadAppNameIfPresent(ctx context.Context) (context.Context, error) {
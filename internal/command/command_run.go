@@ -331,6 +331,20 @@ func parseFiles(ctx context.Context, flagName string, cb func(value string, file
 	return machineFiles, nil
 }
 
+// ParseVolumeSpec splits a --volume value of the form
+// <volume_id_or_name>:/path/inside/machine[:<options>] into the volume and
+// the mount path, which must be absolute.
+func ParseVolumeSpec(spec string) (volume, mountPath string, err error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Can't infer volume and mount path from '%s'", spec)
+	}
+	if !path.IsAbs(parts[1]) {
+		return "", "", fmt.Errorf("mount path '%s' must be absolute", parts[1])
+	}
+	return parts[0], path.Clean(parts[1]), nil
+}
+
 func DetermineMounts(ctx context.Context, mounts []fly.MachineMount, region string) ([]fly.MachineMount, error) {
 	unattachedVolumes := make(map[string][]fly.Volume)
 
@@ -340,12 +354,10 @@ func DetermineMounts(ctx context.Context, mounts []fly.MachineMount, region stri
 	}
 
 	for _, v := range flag.GetStringSlice(ctx, "volume") {
-		splittedIDDestOpts := strings.Split(v, ":")
-		if len(splittedIDDestOpts) < 2 {
-			return nil, fmt.Errorf("Can't infer volume and mount path from '%s'", v)
+		volID, mountPath, err := ParseVolumeSpec(v)
+		if err != nil {
+			return nil, err
 		}
-		volID := splittedIDDestOpts[0]
-		mountPath := splittedIDDestOpts[1]
 
 		if !strings.HasPrefix(volID, "vol_") {
 			volName := volID
@@ -0,0 +1,29 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVolumeSpec(t *testing.T) {
+	valid := []struct {
+		spec, volume, path string
+	}{
+		{"vol_123:/data", "vol_123", "/data"},
+		{"data:/var/lib/data/", "data", "/var/lib/data"},
+		{"vol_123:/data:ro", "vol_123", "/data"},
+	}
+	for _, tc := range valid {
+		volume, path, err := ParseVolumeSpec(tc.spec)
+		require.NoError(t, err, tc.spec)
+		assert.Equal(t, tc.volume, volume, tc.spec)
+		assert.Equal(t, tc.path, path, tc.spec)
+	}
+
+	for _, spec := range []string{"vol_123", "vol_123:", ":/data", "vol_123:data", "vol_123:./data"} {
+		_, _, err := ParseVolumeSpec(spec)
+		assert.Error(t, err, spec)
+	}
+}
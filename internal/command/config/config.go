@@ -18,6 +18,8 @@ func New() (cmd *cobra.Command) {
 		newShow(),
 		newSave(),
 		newValidate(),
+		newImport(),
+		newFormat(),
 		newEnv(),
 	)
 	return
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/superfly/flyctl/helpers"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyerr"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newFormat() (cmd *cobra.Command) {
+	const (
+		short = "Rewrite an app's config file in a canonical format"
+		long  = `Rewrites the local config file in a canonical format, with a stable key
+order and indentation, so that it doesn't change depending on the editor it was
+last saved with. Deprecated settings are migrated on the way, without changing
+the meaning of the config. The comments at the top of the file are kept.
+Files with other comments aren't formatted, as they would be dropped.
+
+With --check, the file is left untouched and the command fails if the file
+isn't in the canonical format, for use in pre-commit hooks and CI.`
+	)
+	cmd = command.New("format", short, long, runFormat)
+	cmd.Args = cobra.NoArgs
+	cmd.Aliases = []string{"fmt"}
+	flag.Add(cmd,
+		flag.AppConfig(),
+		flag.Bool{
+			Name:        "check",
+			Description: "Don't write the file; fail if it isn't in the canonical format",
+		},
+	)
+	return
+}
+
+func runFormat(ctx context.Context) error {
+	io := iostreams.FromContext(ctx)
+
+	path := state.WorkingDirectory(ctx)
+	if flag.IsSpecified(ctx, "config") {
+		path = flag.GetString(ctx, "config")
+	}
+	configfilename, err := appconfig.ResolveConfigFileFromPath(path)
+	if err != nil {
+		return err
+	}
+
+	current, err := os.ReadFile(configfilename)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	formatted, err := appconfig.Format(configfilename, current)
+	if err != nil {
+		return fmt.Errorf("failed to format %s: %w", helpers.PathRelativeToCWD(configfilename), err)
+	}
+
+	relative := helpers.PathRelativeToCWD(configfilename)
+	switch {
+	case bytes.Equal(current, formatted):
+		fmt.Fprintf(io.Out, "%s is already formatted\n", relative)
+		return nil
+	case flag.GetBool(ctx, "check"):
+		return flyerr.GenericErr{
+			Err:     fmt.Sprintf("%s is not formatted", relative),
+			Suggest: "Run 'fly config format' to format it",
+		}
+	}
+
+	if err := appconfig.WriteFileAtomic(configfilename, formatted); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	fmt.Fprintf(io.Out, "Formatted %s\n", relative)
+	return nil
+}
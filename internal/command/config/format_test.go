@@ -0,0 +1,59 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func formatContext(t *testing.T, dir string, args ...string) context.Context {
+	t.Helper()
+
+	cmd := newFormat()
+	require.NoError(t, cmd.Flags().Parse(args))
+
+	ios, _, _, _ := iostreams.Test()
+	ctx := iostreams.NewContext(context.Background(), ios)
+	ctx = command.NewContext(ctx, cmd)
+	ctx = flag.NewContext(ctx, cmd.Flags())
+	return state.WithWorkingDirectory(ctx, dir)
+}
+
+func TestFormat(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fly.toml")
+	original := []byte("primary_region = \"ord\"\napp = \"my-app\"\n")
+	require.NoError(t, os.WriteFile(path, original, 0o644))
+
+	err := runFormat(formatContext(t, dir, "--check"))
+	assert.ErrorContains(t, err, "is not formatted")
+	buf, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, original, buf)
+
+	require.NoError(t, runFormat(formatContext(t, dir)))
+	buf, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "app = 'my-app'\nprimary_region = 'ord'\n", string(buf))
+
+	assert.NoError(t, runFormat(formatContext(t, dir, "--check")))
+}
+
+func TestFormatKeepsMode(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fly.toml")
+	require.NoError(t, os.WriteFile(path, []byte("primary_region = \"ord\"\napp = \"my-app\"\n"), 0o600))
+
+	require.NoError(t, runFormat(formatContext(t, dir)))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/superfly/flyctl/helpers"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newImport() (cmd *cobra.Command) {
+	const (
+		short = "Import a config file for an existing app"
+		long  = `Validates the given config file like 'fly config validate' and, if it
+is valid, writes it as the app's local config file. The existing config file
+is backed up with a .bak suffix first. Nothing is written when validation
+fails.`
+	)
+	cmd = command.New("import <file>", short, long, runImport,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+	cmd.Args = cobra.ExactArgs(1)
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.Yes(),
+	)
+	return
+}
+
+func runImport(ctx context.Context) error {
+	var (
+		io      = iostreams.FromContext(ctx)
+		appName = appconfig.NameFromContext(ctx)
+		source  = flag.FirstArg(ctx)
+	)
+
+	cfg, err := appconfig.LoadConfig(source)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", source, err)
+	}
+
+	if cfg.AppName != "" && cfg.AppName != appName {
+		fmt.Fprintf(io.ErrOut, "Replacing app name %q from %s with %q\n", cfg.AppName, source, appName)
+	}
+	cfg.AppName = appName
+
+	if err := cfg.SetMachinesPlatform(); err != nil {
+		return err
+	}
+	if err, extraInfo := validateConfig(ctx, cfg); err != nil {
+		fmt.Fprintln(io.ErrOut, extraInfo)
+		return fmt.Errorf("%s was not imported: %w", source, err)
+	}
+
+	path := state.WorkingDirectory(ctx)
+	if flag.IsSpecified(ctx, "config") {
+		path = flag.GetString(ctx, "config")
+	}
+	configfilename, err := appconfig.ResolveConfigFileFromPath(path)
+	if err != nil {
+		return err
+	}
+
+	exists, _ := appconfig.ConfigFileExistsAtPath(configfilename)
+	if exists {
+		if !flag.GetYes(ctx) {
+			confirmation, err := prompt.Confirmf(ctx, "Overwrite config file '%s'", helpers.PathRelativeToCWD(configfilename))
+			switch {
+			case prompt.IsNonInteractive(err):
+				return errors.New("--yes must be specified to overwrite the existing config file when not running interactively")
+			case err != nil:
+				return err
+			case !confirmation:
+				return nil
+			}
+		}
+		if err := backupConfig(ctx, configfilename); err != nil {
+			return err
+		}
+	}
+
+	return cfg.WriteToDisk(ctx, configfilename)
+}
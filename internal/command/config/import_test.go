@@ -0,0 +1,82 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/internal/mock"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func importContext(t *testing.T, dir string, args ...string) context.Context {
+	t.Helper()
+
+	cmd := newImport()
+	require.NoError(t, cmd.Flags().Parse(args))
+
+	ios, _, _, _ := iostreams.Test()
+	ctx := iostreams.NewContext(context.Background(), ios)
+	ctx = command.NewContext(ctx, cmd)
+	ctx = flag.NewContext(ctx, cmd.Flags())
+	ctx = state.WithWorkingDirectory(ctx, dir)
+	ctx = flyutil.NewContextWithClient(ctx, &mock.Client{
+		PlatformRegionsFunc: func(ctx context.Context) ([]fly.Region, *fly.Region, error) {
+			return []fly.Region{{Code: "ord"}, {Code: "ams"}}, nil, nil
+		},
+	})
+	return appconfig.WithName(ctx, "my-app")
+}
+
+func TestImport(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "fly.toml")
+	require.NoError(t, os.WriteFile(existing, []byte("app = \"my-app\"\nprimary_region = \"ord\"\n"), 0o644))
+
+	valid := filepath.Join(t.TempDir(), "external.toml")
+	require.NoError(t, os.WriteFile(valid, []byte("app = \"other-app\"\nprimary_region = \"ams\"\n\n[http_service]\n  internal_port = 8080\n"), 0o644))
+
+	require.NoError(t, runImport(importContext(t, dir, "--yes", valid)))
+
+	cfg, err := appconfig.LoadConfig(existing)
+	require.NoError(t, err)
+	assert.Equal(t, "my-app", cfg.AppName)
+	assert.Equal(t, "ams", cfg.PrimaryRegion)
+	assert.Equal(t, 8080, cfg.HTTPService.InternalPort)
+
+	backup, err := os.ReadFile(existing + ".bak")
+	require.NoError(t, err)
+	assert.Contains(t, string(backup), `primary_region = "ord"`)
+}
+
+func TestImportInvalid(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "fly.toml")
+	original := []byte("app = \"my-app\"\nprimary_region = \"ord\"\n")
+	require.NoError(t, os.WriteFile(existing, original, 0o644))
+
+	invalid := filepath.Join(t.TempDir(), "external.toml")
+	require.NoError(t, os.WriteFile(invalid, []byte("app = \"my-app\"\n\n[deploy]\n  strategy = \"sideways\"\n"), 0o644))
+
+	err := runImport(importContext(t, dir, "--yes", invalid))
+	assert.ErrorContains(t, err, "was not imported")
+
+	// The platform checks of config validate apply too.
+	require.NoError(t, os.WriteFile(invalid, []byte("app = \"my-app\"\nprimary_region = \"ordd\"\n"), 0o644))
+	err = runImport(importContext(t, dir, "--yes", invalid))
+	assert.ErrorContains(t, err, "was not imported: invalid primary_region: region ordd not found, did you mean ord?")
+
+	buf, err := os.ReadFile(existing)
+	require.NoError(t, err)
+	assert.Equal(t, original, buf)
+	assert.NoFileExists(t, existing+".bak")
+}
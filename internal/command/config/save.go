@@ -5,11 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/helpers"
 	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/flag"
@@ -42,6 +44,10 @@ retrieved from the Fly service and saved in TOML format.`
 			Name:        "yaml",
 			Description: "Output the configuration in YAML format",
 		},
+		flag.Bool{
+			Name:        "backup",
+			Description: "Save a copy of the existing config file with a .bak suffix before overwriting it",
+		},
 	)
 	return
 }
@@ -81,7 +87,8 @@ func runSave(ctx context.Context) error {
 		configfilename = strings.TrimSuffix(configfilename, filepath.Ext(configfilename)) + ".yaml"
 	}
 
-	if exists, _ := appconfig.ConfigFileExistsAtPath(configfilename); exists && !autoConfirm {
+	exists, _ := appconfig.ConfigFileExistsAtPath(configfilename)
+	if exists && !autoConfirm {
 		confirmation, err := prompt.Confirmf(ctx,
 			"An existing configuration file has been found\nOverwrite file '%s'", configfilename)
 		if err != nil {
@@ -92,6 +99,12 @@ func runSave(ctx context.Context) error {
 		}
 	}
 
+	if exists && flag.GetBool(ctx, "backup") {
+		if err := backupConfig(ctx, configfilename); err != nil {
+			return err
+		}
+	}
+
 	err = keepPrevSections(ctx, cfg, configfilename)
 	if err != nil {
 		return err
@@ -100,6 +113,31 @@ func runSave(ctx context.Context) error {
 	return cfg.WriteToDisk(ctx, configfilename)
 }
 
+func backupConfig(ctx context.Context, configPath string) error {
+	io := iostreams.FromContext(ctx)
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read config file for backup: %w", err)
+	}
+	info, err := os.Stat(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read config file for backup: %w", err)
+	}
+
+	// The backup may hold the same secrets as the config, so it gets its mode.
+	backupPath := configPath + ".bak"
+	if err := os.WriteFile(backupPath, data, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write config backup: %w", err)
+	}
+	if err := os.Chmod(backupPath, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write config backup: %w", err)
+	}
+
+	fmt.Fprintf(io.Out, "Saved previous config file to %s\n", helpers.PathRelativeToCWD(backupPath))
+	return nil
+}
+
 func keepPrevSections(ctx context.Context, currentCfg *appconfig.Config, configPath string) error {
 	io := iostreams.FromContext(ctx)
 
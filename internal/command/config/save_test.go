@@ -0,0 +1,27 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestBackupConfigKeepsMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fly.toml")
+	require.NoError(t, os.WriteFile(path, []byte("app = \"my-app\"\n"), 0o600))
+
+	ios, _, _, _ := iostreams.Test()
+	require.NoError(t, backupConfig(iostreams.NewContext(context.Background(), ios), path))
+
+	buf, err := os.ReadFile(path + ".bak")
+	require.NoError(t, err)
+	assert.Equal(t, "app = \"my-app\"\n", string(buf))
+	info, err := os.Stat(path + ".bak")
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
@@ -3,11 +3,13 @@ package config
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/superfly/fly-go/flaps"
 	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/command"
+	cliconfig "github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/flapsutil"
 	"github.com/superfly/flyctl/iostreams"
@@ -17,7 +19,10 @@ func newShow() (cmd *cobra.Command) {
 	const (
 		short = "Show an app's configuration"
 		long  = `Show an application's configuration. The configuration is presented by default
-in JSON format. The configuration data is retrieved from the Fly service.`
+in JSON format; use --format toml to get output usable as a fly.toml. The
+configuration data is retrieved from the Fly service, unless --local is set
+or a --config-env overlay is selected, in which case the effective local
+configuration is shown.`
 	)
 	cmd = command.New("show", short, long, runShow,
 		command.RequireSession,
@@ -39,6 +44,10 @@ in JSON format. The configuration data is retrieved from the Fly service.`
 			Name:        "toml",
 			Description: "Show configuration in TOML format",
 		},
+		flag.String{
+			Name:        "format",
+			Description: "Format to show the configuration in: json, yaml or toml",
+		},
 	)
 	return
 }
@@ -47,9 +56,17 @@ func runShow(ctx context.Context) error {
 	io := iostreams.FromContext(ctx)
 	appName := appconfig.NameFromContext(ctx)
 
+	format, err := showFormat(ctx)
+	if err != nil {
+		return err
+	}
+
 	var cfg *appconfig.Config
 
-	if !flag.GetBool(ctx, "local") {
+	// Overlays only exist locally, so selecting one implies --local.
+	local := flag.GetBool(ctx, "local") || cliconfig.FromContext(ctx).AppConfigEnv != ""
+
+	if !local {
 		flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{
 			AppName: appName,
 		})
@@ -69,19 +86,35 @@ func runShow(ctx context.Context) error {
 		}
 	}
 
-	format := "json"
-
-	if flag.GetBool(ctx, "yaml") {
-		format = "yaml"
-	} else if flag.GetBool(ctx, "toml") {
-		format = "toml"
+	if _, err := cfg.WriteTo(io.Out, format); err != nil {
+		return err
 	}
 
-	_, err := cfg.WriteTo(io.Out, format)
+	return nil
+}
+
+// showFormat returns the output format selected by --format, or by the older
+// --yaml and --toml flags, defaulting to JSON.
+func showFormat(ctx context.Context) (string, error) {
+	format := strings.ToLower(flag.GetString(ctx, "format"))
+	switch format {
+	case "", "json", "yaml", "toml":
+	default:
+		return "", fmt.Errorf("unsupported --format %q, expected json, yaml or toml", format)
+	}
 
-	if err != nil {
-		return err
+	for _, legacy := range []string{"yaml", "toml"} {
+		if !flag.GetBool(ctx, legacy) {
+			continue
+		}
+		if format != "" && format != legacy {
+			return "", fmt.Errorf("--%s can't be combined with --format %s", legacy, format)
+		}
+		format = legacy
 	}
 
-	return nil
+	if format == "" {
+		format = "json"
+	}
+	return format, nil
 }
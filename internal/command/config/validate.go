@@ -8,6 +8,7 @@ import (
 	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/prompt"
 	"github.com/superfly/flyctl/iostreams"
 )
 
@@ -33,7 +34,20 @@ func runValidate(ctx context.Context) error {
 	if err := cfg.SetMachinesPlatform(); err != nil {
 		return err
 	}
-	err, extra_info := cfg.Validate(ctx)
+	err, extra_info := validateConfig(ctx, cfg)
 	fmt.Fprintln(io.Out, extra_info)
 	return err
 }
+
+// validateConfig runs the checks of config validate: those of the config
+// itself, then those against the platform, like whether its primary region
+// exists.
+func validateConfig(ctx context.Context, cfg *appconfig.Config) (err error, extraInfo string) {
+	if err, extraInfo = cfg.Validate(ctx); err != nil {
+		return
+	}
+	if err = prompt.ValidateRegion(ctx, cfg.PrimaryRegion); err != nil {
+		err = fmt.Errorf("invalid primary_region: %w", err)
+	}
+	return
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/urls"
 	"github.com/superfly/flyctl/iostreams"
 )
 
@@ -23,6 +24,7 @@ func New() *cobra.Command {
 	)
 	cmd.AddCommand(
 		newDashboardMetrics(),
+		newDashboardMonitoring(),
 	)
 	flag.Add(cmd,
 		flag.App(),
@@ -48,18 +50,42 @@ func newDashboardMetrics() *cobra.Command {
 	return cmd
 }
 
+func newDashboardMonitoring() *cobra.Command {
+	const (
+		short = "Open web browser on Fly Web UI for this app's monitoring"
+		long  = `Open web browser on Fly Web UI for this application's monitoring page`
+	)
+	cmd := command.New("monitoring", short, long, runDashboardMonitoring,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+	)
+	return cmd
+}
+
 func runDashboard(ctx context.Context) error {
 	appName := appconfig.NameFromContext(ctx)
-	return runDashboardOpen(ctx, "https://fly.io/apps/"+appName)
+	return runDashboardOpen(ctx, urls.App(appName))
 }
 
 func runDashboardMetrics(ctx context.Context) error {
 	appName := appconfig.NameFromContext(ctx)
-	return runDashboardOpen(ctx, "https://fly.io/apps/"+appName+"/metrics")
+	return runDashboardOpen(ctx, urls.App(appName, "metrics"))
+}
+
+func runDashboardMonitoring(ctx context.Context) error {
+	appName := appconfig.NameFromContext(ctx)
+	return runDashboardOpen(ctx, urls.Monitoring(appName, ""))
 }
 
 func runDashboardOpen(ctx context.Context, url string) error {
 	io := iostreams.FromContext(ctx)
 	fmt.Fprintln(io.Out, "Opening", url)
-	return open.Run(url)
+	if err := open.Run(url); err != nil {
+		fmt.Fprintf(io.ErrOut, "Could not open a browser, visit %s instead\n", url)
+	}
+	return nil
 }
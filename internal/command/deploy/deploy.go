@@ -3,6 +3,7 @@ package deploy
 import (
 	"context"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -26,6 +27,7 @@ import (
 	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/internal/sentry"
 	"github.com/superfly/flyctl/internal/tracing"
+	"github.com/superfly/flyctl/internal/urls"
 	"github.com/superfly/flyctl/iostreams"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -33,6 +35,10 @@ import (
 
 var defaultMaxConcurrent = 8
 
+// defaultFailureLogConcurrency bounds the requests made to report failed
+// machines, so that a large failed rollout doesn't get rate limited.
+const defaultFailureLogConcurrency = 5
+
 var CommonFlags = flag.Set{
 	flag.Image(),
 	flag.Now(),
@@ -50,12 +56,15 @@ var CommonFlags = flag.Set{
 	flag.BuildSecret(),
 	flag.BuildTarget(),
 	flag.NoCache(),
+	flag.CacheFrom(),
+	flag.CacheTo(),
 	flag.Depot(),
 	flag.DepotScope(),
 	flag.Nixpacks(),
 	flag.BuildOnly(),
 	flag.BpDockerHost(),
 	flag.BpVolume(),
+	flag.BpBuilder(),
 	flag.RecreateBuilder(),
 	flag.Yes(),
 	flag.VMSizeFlags,
@@ -77,6 +86,7 @@ var CommonFlags = flag.Set{
 			"The lease is refreshed periodically for this same time, which is why it is short." +
 			"flyctl releases leases in most cases.",
 		Default: DefaultLeaseTtl.String(),
+		Aliases: []string{"lease-ttl"},
 	},
 	flag.Bool{
 		Name:        "force-machines",
@@ -99,10 +109,14 @@ var CommonFlags = flag.Set{
 		Description: "Perform DNS checks during deployment",
 		Default:     true,
 	},
-	flag.Float64{
+	flag.String{
 		Name:        "max-unavailable",
-		Description: "Max number of unavailable machines during rolling updates. A number between 0 and 1 means percent of total machines",
-		Default:     DefaultMaxUnavailable,
+		Description: "Max number of unavailable machines during rolling updates. A number between 0 and 1, or a percentage like 25%, is a fraction of the machines in each process group; a whole number of 1 or more is a count",
+		Default:     "33%",
+	},
+	flag.String{
+		Name:        "abort-on-failure-threshold",
+		Description: "Percentage of a process group's machines, like 20%, that may fail to update during a rolling deploy before the deploy is aborted and the machines updated in place are rolled back. By default the deploy is aborted on the first failure",
 	},
 	flag.Bool{
 		Name:        "no-public-ips",
@@ -147,17 +161,47 @@ var CommonFlags = flag.Set{
 	},
 	flag.StringSlice{
 		Name:        "process-groups",
-		Description: "Deploy to machines only in these process groups",
+		Description: "Deploy to machines only in these process groups. With a single group, an image built for the deploy is tagged with its name",
 	},
 	flag.StringArray{
 		Name:        "label",
-		Description: "Add custom metadata to an image via docker labels",
+		Description: "Add custom metadata to an image via docker labels, as KEY=VALUE. Can be specified multiple times. fly.deploy.time and, in a git checkout, org.opencontainers.image.revision are always added",
 	},
 	flag.Int{
 		Name:        "max-concurrent",
 		Description: "Maximum number of machines to operate on concurrently.",
 		Default:     defaultMaxConcurrent,
 	},
+	flag.Bool{
+		Name:        "no-git",
+		Description: "Don't detect the git commit, branch and dirty state of the working directory to add them to the image and the release",
+	},
+	flag.Bool{
+		Name:        "allow-dirty",
+		Description: "Don't warn when deploying from a git working tree with uncommitted changes",
+	},
+	flag.String{
+		Name:        "watch-immediate",
+		Description: "With the immediate strategy, watch the updated machines for this long, like 30s, and fail if any of them crash or don't come up. By default immediate deploys don't wait for machines",
+	},
+	flag.String{
+		Name:        "wait-for-machines",
+		Description: "Once the machines are updated, wait until at least this many machines of the deployed process groups are started and healthy, or 'all' of them, the default without a value. Standbys and machines kept stopped by auto-stop aren't counted. Use when scaling up concurrently",
+		NoOptDefVal: "all",
+	},
+	flag.String{
+		Name:        "smoke-test",
+		Description: "Once the deploy succeeded, GET this path of the app, like /healthz, and fail the deploy if it doesn't return a 2xx status",
+	},
+	flag.String{
+		Name:        "smoke-test-timeout",
+		Description: "Time duration to retry the smoke test for before failing the deploy.",
+		Default:     defaultSmokeTestTimeout.String(),
+	},
+	flag.Bool{
+		Name:        "smoke-test-private",
+		Description: "Run the smoke test against the app's Flycast address in the private network, through the agent, instead of its public URL",
+	},
 	flag.Int{
 		Name:        "immediate-max-concurrent",
 		Description: "Maximum number of machines to update concurrently when using the immediate deployment strategy.",
@@ -178,10 +222,25 @@ var CommonFlags = flag.Set{
 		Description: "Number of times to retry a deployment if it fails",
 		Default:     "auto",
 	},
+	flag.Bool{
+		Name:        "timing",
+		Description: "Print a breakdown of how long each deploy phase took. With --json it is written to stderr as JSON",
+	},
+	flag.Bool{
+		Name:        "from-current",
+		Description: "Redeploy the image of the app's current release without building",
+	},
+	flag.Bool{
+		Name:        "no-pin",
+		Description: "Deploy a pre-built --image by its tag instead of pinning it to the resolved digest",
+	},
 }
 
 type Command struct {
 	*cobra.Command
+
+	// cleanup, when set, removes the clone made for --from.
+	cleanup func()
 }
 
 func New() *Command {
@@ -196,8 +255,9 @@ func New() *Command {
 	cmd := &Command{}
 	cmd.Command = command.New("deploy [WORKING_DIRECTORY]", short, long, cmd.run,
 		command.RequireSession,
+		ValidateCacheFlags,
 		command.ChangeWorkingDirectoryToFirstArgIfPresent,
-		command.RequireAppName,
+		cmd.prepareSource,
 	)
 	cmd.Args = cobra.MaximumNArgs(1)
 
@@ -211,11 +271,33 @@ func New() *Command {
 			Description: "Do not create Machines for new process groups",
 			Default:     false,
 		},
+		flag.Int{
+			Name:        "failure-log-concurrency",
+			Description: "Maximum number of failed machines to fetch logs for concurrently when reporting a failed deploy",
+			Default:     defaultFailureLogConcurrency,
+		},
 		flag.Bool{
 			Name:        "skip-release-command",
 			Description: "Do not run the release command during deployment.",
 			Default:     false,
 		},
+		flag.String{
+			Name:        "from",
+			Description: "Deploy from a git repository, as <git-url>[#<branch or tag>], instead of the working directory. The repository is shallow cloned into a temporary directory, which is removed afterwards",
+		},
+		flag.Bool{
+			Name:        "warn-on-drift",
+			Description: "Before deploying, warn if the deployed config was changed since the last deploy from the local config file, and ask for confirmation, or require --yes when not interactive. The first deploy from a config file has nothing to compare with and isn't checked",
+			Default:     true,
+		},
+		flag.String{
+			Name:        "image-tag",
+			Description: "Push the built image to this tag, like ghcr.io/my-org/my-app:v1, instead of the Fly registry. Credentials for other registries are read from the local Docker config, as stored by `docker login`",
+		},
+		flag.Bool{
+			Name:        "verify-only",
+			Description: "Run the preflight checks of a deploy (config, image source, builder, registry auth) and report the results without building or releasing",
+		},
 		flag.String{
 			Name:        "export-manifest",
 			Description: "Specify a file to export the deployment configuration to a deploy manifest file, or '-' to print to stdout.",
@@ -232,6 +314,10 @@ func New() *Command {
 }
 
 func (cmd *Command) run(ctx context.Context) (err error) {
+	if cmd.cleanup != nil {
+		defer cmd.cleanup()
+	}
+
 	io := iostreams.FromContext(ctx)
 	appName := appconfig.NameFromContext(ctx)
 
@@ -295,6 +381,16 @@ func (cmd *Command) run(ctx context.Context) (err error) {
 		return deployFromManifest(ctx, manifest)
 	}
 
+	if flag.GetBool(ctx, "verify-only") {
+		return verifyDeploy(ctx)
+	}
+
+	if local := appconfig.ConfigFromContext(ctx); local != nil && flag.GetBool(ctx, "warn-on-drift") && !flag.GetBuildOnly(ctx) {
+		if err := checkConfigDrift(ctx, appName, local); err != nil {
+			return err
+		}
+	}
+
 	appConfig, err := determineAppConfig(ctx)
 	if err != nil {
 		if strings.Contains(err.Error(), "Could not find App") {
@@ -314,8 +410,13 @@ func (cmd *Command) run(ctx context.Context) (err error) {
 	span.SetAttributes(attribute.StringSlice("gpu.kinds", gpuKinds))
 	span.SetAttributes(attribute.StringSlice("cpu.kinds", cpuKinds))
 
-	err = DeployWithConfig(ctx, appConfig, 0, flag.GetYes(ctx))
-	return err
+	if err := DeployWithConfig(ctx, appConfig, 0, flag.GetYes(ctx)); err != nil {
+		return err
+	}
+	if !flag.GetBuildOnly(ctx) {
+		recordDeployedConfig(ctx, appName, appConfig)
+	}
+	return nil
 }
 
 func DeployWithConfig(ctx context.Context, appConfig *appconfig.Config, userID int, forceYes bool) (err error) {
@@ -348,11 +449,21 @@ func DeployWithConfig(ctx context.Context, appConfig *appconfig.Config, userID i
 		}
 	}
 
+	if err := checkWatchImmediateStrategy(ctx, appConfig); err != nil {
+		return err
+	}
+
 	httpFailover := flag.GetHTTPSFailover(ctx)
 	usingWireguard := flag.GetWireguard(ctx)
 	recreateBuilder := flag.GetRecreateBuilder(ctx)
 
+	timer := newDeployTimer(flag.GetBool(ctx, "timing"))
+	ctx = withDeployTimer(ctx, timer)
+
+	ctx = withGitInfo(ctx, detectGit(ctx))
+
 	// Fetch an image ref or build from source to get the final image reference to deploy
+	stopBuild := timer.track("build")
 	img, err := determineImage(ctx, appConfig, usingWireguard, recreateBuilder)
 	if err != nil {
 		noBuilder := strings.Contains(err.Error(), "Could not find App")
@@ -364,18 +475,31 @@ func DeployWithConfig(ctx context.Context, appConfig *appconfig.Config, userID i
 		}
 	}
 
+	stopBuild()
+
 	if err != nil {
 		return fmt.Errorf("failed to fetch an image or build from source: %w", err)
 	}
+	timer.addBuild(img)
 
 	if flag.GetBuildOnly(ctx) {
-		return nil
+		return timer.report(ctx)
+	}
+
+	smoke, err := newSmokeTest(ctx, appConfig, appCompact)
+	if err != nil {
+		return err
 	}
 
-	fmt.Fprintf(io.Out, "\nWatch your deployment at https://fly.io/apps/%s/monitoring\n\n", appName)
+	fmt.Fprintf(io.Out, "\nWatch your deployment at %s\n\n", urls.Monitoring(appName, ""))
 	if err := deployToMachines(ctx, appConfig, appCompact, img); err != nil {
 		return err
 	}
+	if smoke != nil {
+		if err := smoke.run(ctx, io.Out); err != nil {
+			return err
+		}
+	}
 	var ip = "public"
 	if flag.GetBool(ctx, "flycast") || flag.GetBool(ctx, "attach") {
 		ip = "private"
@@ -390,7 +514,7 @@ func DeployWithConfig(ctx context.Context, appConfig *appconfig.Config, userID i
 		fmt.Fprintf(io.Out, "\nYour app is deployed but does not have a public or private IP address\n")
 	}
 
-	return err
+	return timer.report(ctx)
 }
 
 func parseDurationFlag(ctx context.Context, flagName string) (*time.Duration, error) {
@@ -466,6 +590,21 @@ func deployToMachines(
 		return err
 	}
 
+	var watchImmediate time.Duration
+	if d, err := parseDurationFlag(ctx, "watch-immediate"); err != nil {
+		return err
+	} else if d != nil {
+		watchImmediate = *d
+	}
+
+	waitForMachines, err := parseWaitForMachines(flag.GetString(ctx, "wait-for-machines"))
+	if err != nil {
+		return err
+	}
+	if waitForMachines != 0 && flag.GetDetach(ctx) {
+		return fmt.Errorf("--wait-for-machines can't be used with --detach")
+	}
+
 	files, err := command.FilesFromCommand(ctx)
 	if err != nil {
 		return err
@@ -501,15 +640,22 @@ func deployToMachines(
 		processGroups[r] = true
 	}
 
-	// We default the flag to 0.33 so that --help can show the actual default value,
+	// We default the flag to 33% so that --help can show the actual default value,
 	// but internally we want to differentiate between the flag being specified and not.
-	// We use 0.0 to denote unspecified, as that value is invalid for maxUnavailable.
 	var maxUnavailable *float64 = nil
 	if flag.IsSpecified(ctx, "max-unavailable") {
-		maxUnavailable = fly.Pointer(flag.GetFloat64(ctx, "max-unavailable"))
-		// Validation to ensure that 0.0 is *purely* the "unspecified" value
-		if *maxUnavailable <= 0 {
-			return fmt.Errorf("the value for --max-unavailable must be > 0")
+		mu, err := parseMaxUnavailable(flag.GetString(ctx, "max-unavailable"))
+		if err != nil {
+			return err
+		}
+		maxUnavailable = &mu
+	}
+
+	var abortThreshold float64
+	if flag.IsSpecified(ctx, "abort-on-failure-threshold") {
+		var err error
+		if abortThreshold, err = parseAbortThreshold(flag.GetString(ctx, "abort-on-failure-threshold")); err != nil {
+			return err
 		}
 	}
 
@@ -518,6 +664,17 @@ func deployToMachines(
 	if maxConcurrent == defaultMaxConcurrent && immediateMaxConcurrent != defaultMaxConcurrent {
 		maxConcurrent = immediateMaxConcurrent
 	}
+	if maxConcurrent < 1 {
+		return fmt.Errorf("the value for --max-concurrent must be at least 1, got %d", maxConcurrent)
+	}
+	// --failure-log-concurrency is only a flag of deploy, not of launch.
+	failureLogConcurrency := defaultFailureLogConcurrency
+	if flag.IsSpecified(ctx, "failure-log-concurrency") {
+		failureLogConcurrency = flag.GetInt(ctx, "failure-log-concurrency")
+		if failureLogConcurrency < 1 {
+			return fmt.Errorf("the value for --failure-log-concurrency must be at least 1, got %d", failureLogConcurrency)
+		}
+	}
 
 	status.AppName = app.Name
 	status.OrgSlug = app.Organization.Slug
@@ -580,6 +737,10 @@ func deployToMachines(
 		ReleaseCmdTimeout:     releaseCmdTimeout,
 		LeaseTimeout:          leaseTimeout,
 		MaxUnavailable:        maxUnavailable,
+		AbortThreshold:        abortThreshold,
+		WatchImmediate:        watchImmediate,
+		WaitForMachines:       waitForMachines,
+		Git:                   gitInfoFromContext(ctx),
 		Guest:                 guest,
 		IncreasedAvailability: flag.GetBool(ctx, "ha"),
 		AllocIP:               ip,
@@ -591,6 +752,7 @@ func deployToMachines(
 		ExcludeMachines:       excludeMachines,
 		OnlyMachines:          onlyMachines,
 		MaxConcurrent:         maxConcurrent,
+		FailureLogConcurrency: failureLogConcurrency,
 		VolumeInitialSize:     flag.GetInt(ctx, "volume-initial-size"),
 		ProcessGroups:         processGroups,
 		DeployRetries:         deployRetries,
@@ -678,3 +840,47 @@ func determineAppConfig(ctx context.Context) (cfg *appconfig.Config, err error)
 	tb.Done("Verified app config")
 	return cfg, nil
 }
+
+// parseMaxUnavailable parses a --max-unavailable value: a fraction between 0
+// and 1, a percentage like 25%, or a whole number of machines.
+func parseMaxUnavailable(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+
+	if pct, ok := strings.CutSuffix(s, "%"); ok {
+		v, err := strconv.ParseFloat(pct, 64)
+		switch {
+		case err != nil:
+			return 0, fmt.Errorf("invalid --max-unavailable %q: %w", s, err)
+		case v <= 0 || v >= 100:
+			// 100% would read as a count of 1 machine.
+			return 0, fmt.Errorf("--max-unavailable percentage must be between 0%% and 100%%, exclusive; got %s (use --strategy immediate to update all machines at once)", s)
+		}
+		return v / 100, nil
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	switch {
+	case err != nil:
+		return 0, fmt.Errorf("invalid --max-unavailable %q: must be a fraction, a percentage or a number of machines", s)
+	case v <= 0:
+		// 0 denotes an unspecified value.
+		return 0, fmt.Errorf("the value for --max-unavailable must be > 0")
+	case v >= 1 && v != math.Trunc(v):
+		return 0, fmt.Errorf("--max-unavailable %s must be a whole number of machines, or a fraction between 0 and 1", s)
+	}
+	return v, nil
+}
+
+// parseAbortThreshold parses an --abort-on-failure-threshold percentage, with
+// or without the % sign, into a fraction.
+func parseAbortThreshold(s string) (float64, error) {
+	pct := strings.TrimSuffix(strings.TrimSpace(s), "%")
+	v, err := strconv.ParseFloat(pct, 64)
+	switch {
+	case err != nil:
+		return 0, fmt.Errorf("invalid --abort-on-failure-threshold %q: must be a percentage like 20%%", s)
+	case v < 0 || v > 100:
+		return 0, fmt.Errorf("--abort-on-failure-threshold must be between 0%% and 100%%, got %s", s)
+	}
+	return v / 100, nil
+}
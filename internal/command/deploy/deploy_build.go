@@ -4,7 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/superfly/flyctl/internal/appconfig"
@@ -23,6 +27,89 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 )
 
+// imageLabels returns the labels to add to a built image: the fly.deploy.time
+// of the build, the git details of the deploy when they were detected, details
+// of the GitHub Actions run when in one, and finally the KEY=VALUE labels given
+// with --label.
+func imageLabels(ctx context.Context, args []string) (map[string]string, error) {
+	userLabels, err := cmdutil.ParseKVStringsToMap(args)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := map[string]string{
+		"fly.deploy.time": time.Now().UTC().Format(time.RFC3339),
+	}
+	for k, v := range gitLabels(gitInfoFromContext(ctx)) {
+		labels[k] = v
+	}
+	if env.IS_GH_ACTION() {
+		labels["GH_SHA"] = env.GitCommitSHA()
+		labels["GH_ACTION_NAME"] = env.GitActionName()
+		labels["GH_REPO"] = env.GitRepoAndOwner()
+		labels["GH_EVENT_NAME"] = env.GitActionEventName()
+	}
+
+	for k, v := range userLabels {
+		if err := validateLabelKey(k); err != nil {
+			return nil, err
+		}
+		labels[k] = v
+	}
+	return labels, nil
+}
+
+var labelKeyPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?$`)
+
+// validateLabelKey checks a --label key: letters, digits, dots, dashes and
+// underscores, starting and ending with a letter or digit, outside of the
+// namespaces Docker reserves.
+func validateLabelKey(key string) error {
+	if !labelKeyPattern.MatchString(key) {
+		return fmt.Errorf("invalid label key %q: must contain only letters, digits, '.', '-' and '_', and start and end with a letter or digit", key)
+	}
+	for _, reserved := range []string{"com.docker.", "io.docker.", "org.dockerproject."} {
+		if strings.HasPrefix(strings.ToLower(key), reserved) {
+			return fmt.Errorf("invalid label key %q: the %s namespace is reserved by Docker", key, strings.TrimSuffix(reserved, "."))
+		}
+	}
+	return nil
+}
+
+// parseBuildSecrets parses the NAME=VALUE pairs of --build-secret. A value
+// of @path reads the secret from the file at path, relative to the working
+// directory, while a value starting with @@ is taken literally, minus the
+// first @. Empty secrets are an error, as they're most likely a mistake.
+func parseBuildSecrets(ctx context.Context, args []string) (map[string]string, error) {
+	secrets, err := cmdutil.ParseKVStringsToMap(args)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range secrets {
+		if name == "" {
+			return nil, fmt.Errorf("invalid build secret %q: the name is empty", "="+value)
+		}
+		if literal, ok := strings.CutPrefix(value, "@@"); ok {
+			value = "@" + literal
+			secrets[name] = value
+		} else if path, ok := strings.CutPrefix(value, "@"); ok {
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(state.WorkingDirectory(ctx), path)
+			}
+			b, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read build secret %s: %w", name, err)
+			}
+			value = string(b)
+			secrets[name] = value
+		}
+		if value == "" {
+			return nil, fmt.Errorf("build secret %s is empty", name)
+		}
+	}
+	return secrets, nil
+}
+
 func multipleDockerfile(ctx context.Context, appConfig *appconfig.Config) error {
 	if len(appConfig.BuildStrategies()) == 0 {
 		// fly.toml doesn't know anything about building this image.
@@ -49,6 +136,27 @@ func multipleDockerfile(ctx context.Context, appConfig *appconfig.Config) error
 
 // determineImage picks the deployment strategy, builds the image and returns a
 // DeploymentImage struct
+// imageProcess returns the process group an image built for this deploy is
+// tagged with: the one given with --process-groups, if it's the only one.
+// An image that may be used by several groups gets no process tag.
+func imageProcess(ctx context.Context) string {
+	groups := flag.GetNonEmptyStringSlice(ctx, "process-groups")
+	if len(groups) != 1 {
+		return ""
+	}
+	return groups[0]
+}
+
+// ValidateCacheFlags is a Preparer which checks --cache-from and --cache-to
+// before any builder is provisioned for the build.
+func ValidateCacheFlags(ctx context.Context) (context.Context, error) {
+	err := imgsrc.ValidateCacheOptions(flag.GetStringArray(ctx, "cache-from"), flag.GetStringArray(ctx, "cache-to"))
+	if err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
 func determineImage(ctx context.Context, appConfig *appconfig.Config, useWG, recreateBuilder bool) (img *imgsrc.DeploymentImage, err error) {
 	ctx, span := tracing.GetTracer().Start(ctx, "determine_image")
 	defer span.End()
@@ -107,6 +215,10 @@ func determineImage(ctx context.Context, appConfig *appconfig.Config, useWG, rec
 		}
 
 		span.AddEvent("using pre-built docker image")
+
+		if !flag.GetBool(ctx, "no-pin") {
+			pinImageDigest(img, tb)
+		}
 		return
 	}
 
@@ -124,12 +236,27 @@ func determineImage(ctx context.Context, appConfig *appconfig.Config, useWG, rec
 		Publish:              flag.GetBool(ctx, "push") || !flag.GetBuildOnly(ctx),
 		ImageLabel:           flag.GetString(ctx, "image-label"),
 		NoCache:              flag.GetBool(ctx, "no-cache"),
+		CacheFrom:            flag.GetStringArray(ctx, "cache-from"),
+		CacheTo:              flag.GetStringArray(ctx, "cache-to"),
 		BuiltIn:              build.Builtin,
 		BuiltInSettings:      build.Settings,
 		Builder:              build.Builder,
 		Buildpacks:           build.Buildpacks,
 		BuildpacksDockerHost: flag.GetString(ctx, flag.BuildpacksDockerHost),
 		BuildpacksVolumes:    flag.GetStringSlice(ctx, flag.BuildpacksVolume),
+		Process:              imageProcess(ctx),
+	}
+
+	if builder := flag.GetString(ctx, flag.BuildpacksBuilder); builder != "" {
+		opts.Builder = builder
+	}
+
+	if tag := flag.GetString(ctx, "image-tag"); tag != "" {
+		opts.Tag = tag
+		if opts.RegistryAuth, err = imgsrc.RegistryAuthForTag(tag); err != nil {
+			tracing.RecordError(span, err, "failed to get registry credentials")
+			return
+		}
 	}
 
 	if appConfig.Experimental != nil {
@@ -138,9 +265,10 @@ func determineImage(ctx context.Context, appConfig *appconfig.Config, useWG, rec
 		opts.UseZstd = appConfig.Experimental.UseZstd
 	}
 
-	// flyctl supports key=value form while Docker supports id=key,src=/path/to/secret form.
+	// flyctl supports key=value and key=@/path/to/secret forms while Docker
+	// supports id=key,src=/path/to/secret form.
 	// https://docs.docker.com/engine/reference/commandline/buildx_build/#secret
-	cliBuildSecrets, err := cmdutil.ParseKVStringsToMap(flag.GetStringArray(ctx, "build-secret"))
+	cliBuildSecrets, err := parseBuildSecrets(ctx, flag.GetStringArray(ctx, "build-secret"))
 	if err != nil {
 		tracing.RecordError(span, err, "failed to generate cliBuildSecrets")
 		return
@@ -150,21 +278,10 @@ func determineImage(ctx context.Context, appConfig *appconfig.Config, useWG, rec
 		opts.BuildSecrets = cliBuildSecrets
 	}
 
-	arrLabels := flag.GetStringArray(ctx, "label")
-	labels, err := cmdutil.ParseKVStringsToMap(arrLabels)
-	if err != nil {
+	if opts.Label, err = imageLabels(ctx, flag.GetStringArray(ctx, "label")); err != nil {
 		tracing.RecordError(span, err, "failed to parse labels")
 		return
 	}
-	if env.IS_GH_ACTION() {
-		labels["GH_SHA"] = env.GitCommitSHA()
-		labels["GH_ACTION_NAME"] = env.GitActionName()
-		labels["GH_REPO"] = env.GitRepoAndOwner()
-		labels["GH_EVENT_NAME"] = env.GitActionEventName()
-	}
-	if labels != nil {
-		opts.Label = labels
-	}
 
 	var buildArgs map[string]string
 	if buildArgs, err = mergeBuildArgs(ctx, build.Args); err != nil {
@@ -262,6 +379,13 @@ func mergeBuildArgs(ctx context.Context, args map[string]string) (map[string]str
 		args = make(map[string]string)
 	}
 
+	// git details are defaults; the config and command line may override them
+	for k, v := range gitBuildArgs(gitInfoFromContext(ctx)) {
+		if _, ok := args[k]; !ok {
+			args[k] = v
+		}
+	}
+
 	// set additional Docker build args from the command line, overriding similar ones from the config
 	cliBuildArgs, err := cmdutil.ParseKVStringsToMap(flag.GetStringArray(ctx, "build-arg"))
 	if err != nil {
@@ -274,7 +398,31 @@ func mergeBuildArgs(ctx context.Context, args map[string]string) (map[string]str
 	return args, nil
 }
 
+// pinImageDigest rewrites img's tag to reference its digest, so every machine
+// in the release runs the same image even if the tag is later moved.
+func pinImageDigest(img *imgsrc.DeploymentImage, tb *render.TextBlock) {
+	if strings.Contains(img.Tag, "@") {
+		return
+	}
+	if img.Digest == "" {
+		tb.Printf("could not resolve the digest of %s, deploying the tag as-is\n", img.Tag)
+		return
+	}
+
+	tag := img.Tag
+	img.Tag = img.String()
+	tb.Printf("image: %s\n", tag)
+	tb.Printf("pinned to digest: %s\n", img.Tag)
+}
+
 func fetchImageRef(ctx context.Context, cfg *appconfig.Config) (ref string, err error) {
+	if flag.GetBool(ctx, "from-current") {
+		if flag.GetString(ctx, "image") != "" {
+			return "", errors.New("--from-current and --image cannot be used together")
+		}
+		return currentReleaseImageRef(ctx, cfg.AppName)
+	}
+
 	if ref = flag.GetString(ctx, "image"); ref != "" {
 		return
 	}
@@ -287,3 +435,16 @@ func fetchImageRef(ctx context.Context, cfg *appconfig.Config) (ref string, err
 
 	return ref, nil
 }
+
+// currentReleaseImageRef returns the image of the app's current release, so it
+// can be redeployed as-is.
+func currentReleaseImageRef(ctx context.Context, appName string) (string, error) {
+	release, err := flyutil.ClientFromContext(ctx).GetAppCurrentReleaseMachines(ctx, appName)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch the current release of %s: %w", appName, err)
+	}
+	if release == nil || release.ImageRef == "" {
+		return "", fmt.Errorf("%s has no current release to redeploy; deploy without --from-current first", appName)
+	}
+	return release.ImageRef, nil
+}
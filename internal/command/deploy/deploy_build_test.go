@@ -2,16 +2,50 @@ package deploy
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
 	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/build/imgsrc"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/internal/gitinfo"
+	"github.com/superfly/flyctl/internal/mock"
+	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
 )
 
+func TestPinImageDigest(t *testing.T) {
+	const digest = "sha256:4b5e0a2f3b0d6c1e9a7f8e2d1c0b9a8f7e6d5c4b3a291807f6e5d4c3b2a1908f"
+
+	ios, _, _, errOut := iostreams.Test()
+	tb := render.NewTextBlock(iostreams.NewContext(context.Background(), ios))
+
+	img := &imgsrc.DeploymentImage{Tag: "docker.io/library/nginx:latest", Digest: digest}
+	pinImageDigest(img, tb)
+	assert.Equal(t, "docker.io/library/nginx:latest@"+digest, img.Tag)
+	assert.Equal(t, "image: docker.io/library/nginx:latest\npinned to digest: docker.io/library/nginx:latest@"+digest+"\n", errOut.String())
+
+	errOut.Reset()
+	img = &imgsrc.DeploymentImage{Tag: "docker.io/library/nginx@" + digest, Digest: digest}
+	pinImageDigest(img, tb)
+	assert.Equal(t, "docker.io/library/nginx@"+digest, img.Tag, "already pinned")
+	assert.Empty(t, errOut.String())
+
+	img = &imgsrc.DeploymentImage{Tag: "docker.io/library/nginx:latest"}
+	pinImageDigest(img, tb)
+	assert.Equal(t, "docker.io/library/nginx:latest", img.Tag, "digest not resolved")
+	assert.Contains(t, errOut.String(), "could not resolve the digest of docker.io/library/nginx:latest")
+}
+
 func TestMultipleDockerfile(t *testing.T) {
 	dir := t.TempDir()
 
@@ -37,3 +71,158 @@ func TestMultipleDockerfile(t *testing.T) {
 	err = multipleDockerfile(ctx, cfg)
 	assert.ErrorContains(t, err, "fly.production.toml")
 }
+
+func TestFetchImageRefFromCurrent(t *testing.T) {
+	newCtx := func(release *fly.Release, args ...string) context.Context {
+		flags := pflag.NewFlagSet("deploy", pflag.ContinueOnError)
+		flags.String("image", "", "")
+		flags.Bool("from-current", false, "")
+		require.NoError(t, flags.Parse(args))
+
+		ctx := flag.NewContext(context.Background(), flags)
+		return flyutil.NewContextWithClient(ctx, &mock.Client{
+			GetAppCurrentReleaseMachinesFunc: func(ctx context.Context, appName string) (*fly.Release, error) {
+				assert.Equal(t, "my-app", appName)
+				return release, nil
+			},
+		})
+	}
+	cfg := &appconfig.Config{AppName: "my-app"}
+
+	ref, err := fetchImageRef(newCtx(&fly.Release{ImageRef: "registry.fly.io/my-app@sha256:abc"}, "--from-current"), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "registry.fly.io/my-app@sha256:abc", ref)
+
+	_, err = fetchImageRef(newCtx(nil, "--from-current"), cfg)
+	assert.ErrorContains(t, err, "has no current release")
+
+	_, err = fetchImageRef(newCtx(nil, "--from-current", "--image", "nginx"), cfg)
+	assert.ErrorContains(t, err, "cannot be used together")
+
+	ref, err = fetchImageRef(newCtx(nil, "--image", "nginx"), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "nginx", ref)
+}
+
+func TestImageLabels(t *testing.T) {
+	t.Setenv("GITHUB_ACTIONS", "")
+
+	dir := t.TempDir()
+	// Keep git from finding a repository the test directory happens to be in.
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+	ctx := state.WithWorkingDirectory(context.Background(), dir)
+
+	labels, err := imageLabels(ctx, []string{"team=core", "fly.deploy.time=overridden"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "core", "fly.deploy.time": "overridden"}, labels)
+
+	labels, err = imageLabels(ctx, nil)
+	require.NoError(t, err)
+	_, err = time.Parse(time.RFC3339, labels["fly.deploy.time"])
+	assert.NoError(t, err)
+	assert.NotContains(t, labels, "org.opencontainers.image.revision")
+
+	_, err = imageLabels(ctx, []string{"team"})
+	assert.EqualError(t, err, "'team': must be in the format NAME=VALUE")
+
+	for _, key := range []string{"-team", "team.", "my team", "com.docker.thing"} {
+		_, err := imageLabels(ctx, []string{key + "=x"})
+		assert.ErrorContains(t, err, "invalid label key", key)
+	}
+}
+
+func TestParseBuildSecrets(t *testing.T) {
+	dir := t.TempDir()
+	ctx := state.WithWorkingDirectory(context.Background(), dir)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "npmrc"), []byte("token=abc\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "empty"), nil, 0o600))
+
+	secrets, err := parseBuildSecrets(ctx, []string{"API_KEY=inline=value", "NPMRC=@npmrc", "ABS=@" + filepath.Join(dir, "npmrc")})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"API_KEY": "inline=value", "NPMRC": "token=abc\n", "ABS": "token=abc\n"}, secrets)
+
+	secrets, err = parseBuildSecrets(ctx, []string{"HANDLE=@@me", "AT=@@"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"HANDLE": "@me", "AT": "@"}, secrets)
+
+	_, err = parseBuildSecrets(ctx, []string{"API_KEY="})
+	assert.EqualError(t, err, "build secret API_KEY is empty")
+	_, err = parseBuildSecrets(ctx, []string{"NPMRC=@empty"})
+	assert.EqualError(t, err, "build secret NPMRC is empty")
+	_, err = parseBuildSecrets(ctx, []string{"NPMRC=@missing"})
+	assert.ErrorContains(t, err, "failed to read build secret NPMRC")
+	_, err = parseBuildSecrets(ctx, []string{"=value"})
+	assert.ErrorContains(t, err, "the name is empty")
+	_, err = parseBuildSecrets(ctx, []string{"API_KEY"})
+	assert.EqualError(t, err, "'API_KEY': must be in the format NAME=VALUE")
+}
+
+func TestImageLabelsGit(t *testing.T) {
+	t.Setenv("GITHUB_ACTIONS", "")
+
+	ctx := withGitInfo(context.Background(), &gitinfo.Info{Commit: "0123456789abcdef", Branch: "main", Dirty: true})
+	labels, err := imageLabels(ctx, []string{"fly.git.branch=release"})
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef", labels["org.opencontainers.image.revision"])
+	assert.Equal(t, "true", labels["fly.git.dirty"])
+	assert.Equal(t, "release", labels["fly.git.branch"])
+}
+
+func TestMergeBuildArgsGit(t *testing.T) {
+	flags := pflag.NewFlagSet("deploy", pflag.ContinueOnError)
+	flags.StringArray("build-arg", nil, "")
+	require.NoError(t, flags.Parse([]string{"--build-arg", "GIT_BRANCH=override"}))
+
+	ctx := flag.NewContext(context.Background(), flags)
+	ctx = withGitInfo(ctx, &gitinfo.Info{Commit: "0123456789abcdef", Branch: "main"})
+
+	args, err := mergeBuildArgs(ctx, map[string]string{"GIT_SHA": "from-config"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"GIT_SHA":    "from-config",
+		"GIT_BRANCH": "override",
+		"GIT_DIRTY":  "false",
+	}, args)
+
+	args, err = mergeBuildArgs(flag.NewContext(context.Background(), flags), nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"GIT_BRANCH": "override"}, args)
+}
+
+func TestReleaseMetadataGit(t *testing.T) {
+	info := &gitinfo.Info{Commit: "0123456789abcdef", Branch: "main"}
+
+	b, err := json.Marshal(releaseMetadata(nil, info))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"git":{"commit":"0123456789abcdef","branch":"main"}}`, string(b))
+
+	b, err = json.Marshal(releaseMetadata(&fly.ReleaseMetadata{
+		PostDeploymentInfo: fly.PostDeploymentInfo{FlyctlVersion: "1.0.0"},
+	}, info))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"post_deployment_info":{"flyctl_version":"1.0.0","error":""},"git":{"commit":"0123456789abcdef","branch":"main"}}`, string(b))
+}
+
+func TestValidateCacheFlags(t *testing.T) {
+	flags := pflag.NewFlagSet("deploy", pflag.ContinueOnError)
+	flags.StringArray("cache-from", nil, "")
+	flags.StringArray("cache-to", nil, "")
+	require.NoError(t, flags.Parse([]string{"--cache-from", "registry.fly.io/my-app:cache", "--cache-to", "type=registry,mode=max"}))
+
+	_, err := ValidateCacheFlags(flag.NewContext(context.Background(), flags))
+	assert.ErrorContains(t, err, "--cache-to: ")
+	assert.ErrorContains(t, err, "requires a ref")
+}
+
+func TestImageProcess(t *testing.T) {
+	process := func(args ...string) string {
+		flags := pflag.NewFlagSet("deploy", pflag.ContinueOnError)
+		flags.StringSlice("process-groups", nil, "")
+		require.NoError(t, flags.Parse(args))
+		return imageProcess(flag.NewContext(context.Background(), flags))
+	}
+
+	assert.Equal(t, "", process())
+	assert.Equal(t, "worker", process("--process-groups", "worker"))
+	assert.Equal(t, "", process("--process-groups", "app,worker"))
+}
@@ -0,0 +1,113 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// scpLikeGitURL matches git's scp-like syntax for SSH remotes, e.g.
+// git@github.com:superfly/flyctl.git.
+var scpLikeGitURL = regexp.MustCompile(`^[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:[^/].*$`)
+
+// parseGitSource splits a --from value of the form <git-url>[#ref] and checks
+// that the URL is one git can clone.
+func parseGitSource(from string) (repo, ref string, err error) {
+	repo, ref, _ = strings.Cut(from, "#")
+	if repo == "" {
+		return "", "", errors.New("--from requires a git URL")
+	}
+
+	if scpLikeGitURL.MatchString(repo) {
+		return repo, ref, nil
+	}
+
+	u, err := url.Parse(repo)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid --from git URL %q: %w", repo, err)
+	}
+	switch u.Scheme {
+	case "https", "http", "ssh", "git", "file":
+	default:
+		return "", "", fmt.Errorf("invalid --from git URL %q: use an https://, ssh://, git:// or file:// URL, or user@host:path", repo)
+	}
+	if u.Scheme != "file" && u.Host == "" {
+		return "", "", fmt.Errorf("invalid --from git URL %q: missing host", repo)
+	}
+	return repo, ref, nil
+}
+
+// prepareSource requires the app name like command.RequireAppName. With
+// --from, it first shallow clones the repository into a temporary directory and
+// makes that the working directory, so the app config is read from the clone.
+// run removes the clone once the deploy is done.
+func (cmd *Command) prepareSource(ctx context.Context) (context.Context, error) {
+	from := flag.GetString(ctx, "from")
+	if from == "" {
+		return command.RequireAppName(ctx)
+	}
+	if flag.FirstArg(ctx) != "" {
+		return nil, errors.New("--from can't be used together with a working directory argument")
+	}
+
+	repo, ref, err := parseGitSource(from)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "flyctl-deploy-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a directory to clone into: %w", err)
+	}
+	cmd.cleanup = func() { os.RemoveAll(dir) }
+
+	if err := cloneGitSource(ctx, repo, ref, dir); err != nil {
+		cmd.cleanup()
+		return nil, err
+	}
+
+	if ctx, err = command.ChangeWorkingDirectory(ctx, dir); err == nil {
+		ctx, err = command.RequireAppName(ctx)
+	}
+	if err != nil {
+		cmd.cleanup()
+		return nil, err
+	}
+	return ctx, nil
+}
+
+// cloneGitSource shallow clones the ref of repo, a branch or tag, or its
+// default branch when ref is empty, into dir.
+func cloneGitSource(ctx context.Context, repo, ref, dir string) error {
+	io := iostreams.FromContext(ctx)
+
+	args := []string{"clone", "--depth", "1", "--recurse-submodules", "--shallow-submodules"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+		fmt.Fprintf(io.Out, "Cloning %s at %s\n", repo, ref)
+	} else {
+		fmt.Fprintf(io.Out, "Cloning %s\n", repo)
+	}
+	args = append(args, "--", repo, dir)
+
+	var stderr bytes.Buffer
+	git := exec.CommandContext(ctx, "git", args...)
+	git.Stderr = &stderr
+	if err := git.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("failed to clone %s: %s", repo, msg)
+		}
+		return fmt.Errorf("failed to clone %s: %w", repo, err)
+	}
+	return nil
+}
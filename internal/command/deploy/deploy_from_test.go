@@ -0,0 +1,100 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/logger"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestParseGitSource(t *testing.T) {
+	cases := []struct {
+		from, repo, ref, err string
+	}{
+		{from: "https://github.com/superfly/flyctl", repo: "https://github.com/superfly/flyctl"},
+		{from: "https://github.com/superfly/flyctl.git#v1.2.3", repo: "https://github.com/superfly/flyctl.git", ref: "v1.2.3"},
+		{from: "git@github.com:superfly/flyctl.git#main", repo: "git@github.com:superfly/flyctl.git", ref: "main"},
+		{from: "ssh://git@github.com/superfly/flyctl", repo: "ssh://git@github.com/superfly/flyctl"},
+		{from: "file:///srv/repo", repo: "file:///srv/repo"},
+		{from: "#main", err: "--from requires a git URL"},
+		{from: "github.com/superfly/flyctl", err: "use an https://"},
+		{from: "ftp://example.com/repo", err: "use an https://"},
+		{from: "https:///repo", err: "missing host"},
+	}
+
+	for _, c := range cases {
+		repo, ref, err := parseGitSource(c.from)
+		if c.err != "" {
+			assert.ErrorContains(t, err, c.err, c.from)
+			continue
+		}
+		require.NoError(t, err, c.from)
+		assert.Equal(t, c.repo, repo, c.from)
+		assert.Equal(t, c.ref, ref, c.from)
+	}
+}
+
+func TestPrepareSourceFromGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	repo := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "fly.toml"), []byte("app = \"from-git\"\n"), 0o644))
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"add", "fly.toml"},
+		{"commit", "-q", "-m", "initial"},
+		{"tag", "v1"},
+	} {
+		git := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		git.Dir = repo
+		out, err := git.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	prepare := func(args ...string) (*Command, context.Context, error) {
+		cmd := New()
+		require.NoError(t, cmd.Flags().Parse(args))
+
+		ios, _, _, _ := iostreams.Test()
+		ctx := iostreams.NewContext(context.Background(), ios)
+		ctx = logger.NewContext(ctx, logger.New(&bytes.Buffer{}, logger.Info, false))
+		ctx = config.NewContext(ctx, &config.Config{})
+		ctx = command.NewContext(ctx, cmd.Command)
+		ctx = flag.NewContext(ctx, cmd.Flags())
+		ctx = state.WithWorkingDirectory(ctx, wd)
+
+		ctx, err := cmd.prepareSource(ctx)
+		return cmd, ctx, err
+	}
+
+	cmd, ctx, err := prepare("--from", "file://"+repo+"#v1")
+	require.NoError(t, err)
+	clone := state.WorkingDirectory(ctx)
+	assert.NotEqual(t, wd, clone)
+	assert.Equal(t, "from-git", appconfig.NameFromContext(ctx))
+	assert.FileExists(t, filepath.Join(clone, "fly.toml"))
+
+	cmd.cleanup()
+	assert.NoDirExists(t, clone)
+
+	_, _, err = prepare("--from", "file://"+repo+"#no-such-branch")
+	assert.ErrorContains(t, err, "failed to clone file://"+repo)
+}
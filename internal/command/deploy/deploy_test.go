@@ -9,6 +9,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/superfly/fly-go"
 	"github.com/superfly/flyctl/internal/flapsutil"
 	"github.com/superfly/flyctl/internal/flyutil"
@@ -30,12 +32,14 @@ func TestCommand_Execute(t *testing.T) {
 		t.Fatal(err)
 	}
 	chdir(t, dir)
+	t.Setenv("FLY_CONFIG_DIR", t.TempDir())
 
 	var buf bytes.Buffer
 	cmd := New()
 	cmd.SetOut(&buf)
 	cmd.SetErr(&buf)
-	cmd.SetArgs([]string{"--image", "test-registry.fly.io/my-image:deployment-00000000000000000000000000"})
+	// The in-memory server has no GraphQL client to fetch the deployed config with.
+	cmd.SetArgs([]string{"--image", "test-registry.fly.io/my-image:deployment-00000000000000000000000000", "--warn-on-drift=false"})
 
 	ctx := context.Background()
 	ctx = iostreams.NewContext(ctx, &iostreams.IOStreams{Out: &buf, ErrOut: &buf})
@@ -101,3 +105,36 @@ func chdir(tb testing.TB, dir string) {
 		}
 	})
 }
+
+func TestParseMaxUnavailable(t *testing.T) {
+	valid := map[string]float64{
+		"0.25": 0.25,
+		"25%":  0.25,
+		"50%":  0.5,
+		"1":    1,
+		"3":    3,
+		" 10 ": 10,
+	}
+	for in, want := range valid {
+		got, err := parseMaxUnavailable(in)
+		require.NoError(t, err, in)
+		assert.InDelta(t, want, got, 1e-9, in)
+	}
+
+	for _, in := range []string{"0", "-1", "0%", "100%", "150%", "2.5", "abc", "%", ""} {
+		_, err := parseMaxUnavailable(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestParseAbortThreshold(t *testing.T) {
+	for in, want := range map[string]float64{"20%": 0.2, "20": 0.2, "0": 0, "100%": 1, "12.5%": 0.125} {
+		got, err := parseAbortThreshold(in)
+		require.NoError(t, err, in)
+		assert.InDelta(t, want, got, 1e-9, in)
+	}
+	for _, in := range []string{"-1%", "101%", "abc", ""} {
+		_, err := parseAbortThreshold(in)
+		assert.Error(t, err, in)
+	}
+}
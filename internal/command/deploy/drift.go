@@ -0,0 +1,194 @@
+package deploy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyerr"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
+	"github.com/superfly/flyctl/terminal"
+)
+
+// maxDriftLines is the number of differences listed in a drift summary.
+const maxDriftLines = 10
+
+// driftIgnoredKeys are the top level config keys that aren't compared for
+// drift: the app name can be overridden with --app, and the build settings
+// don't end up in the deployed config as written.
+var driftIgnoredKeys = []string{"app", "build"}
+
+// checkConfigDrift warns when the config of the app's current release differs
+// from the one last deployed from the local config file, as this deploy
+// would overwrite the changes made elsewhere since. Deploying over drift takes
+// confirmation, or --yes when the session can't prompt. Without a record of a
+// deploy from the local config file, as for the first deploy from it with
+// this check, or when the deployed config can't be fetched, there's nothing
+// to compare and the deploy goes on. Comparing with the deployed config
+// instead would report every intended change to the local file as drift.
+func checkConfigDrift(ctx context.Context, appName string, local *appconfig.Config) error {
+	io := iostreams.FromContext(ctx)
+	colorize := io.ColorScheme()
+
+	deployedHere, err := loadDeployedConfig(ctx, appName, local.ConfigFilePath())
+	if err != nil {
+		terminal.Debugf("could not read the config last deployed from %s: %v\n", local.ConfigFilePath(), err)
+		return nil
+	}
+	if deployedHere == nil {
+		terminal.Debugf("no record of a deploy from %s, not checking for drift\n", local.ConfigFilePath())
+		return nil
+	}
+
+	remote, err := appconfig.FromCurrentRelease(ctx, appName)
+	if err != nil {
+		fmt.Fprintf(io.ErrOut, "%s could not check the deployed config for drift: %v\n", colorize.WarningIcon(), err)
+		return nil
+	}
+	if remote == nil {
+		return nil
+	}
+
+	lines, err := configDrift(deployedHere, remote)
+	if err != nil {
+		fmt.Fprintf(io.ErrOut, "%s could not check the deployed config for drift: %v\n", colorize.WarningIcon(), err)
+		return nil
+	}
+	return reportDrift(ctx, lines)
+}
+
+// reportDrift shows the drift lines, if any, and asks for confirmation to
+// deploy anyway. Sessions that can't prompt need --yes to go on.
+func reportDrift(ctx context.Context, lines []string) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	io := iostreams.FromContext(ctx)
+	fmt.Fprintf(io.ErrOut, "%s The deployed config was changed since the last deploy from this config file, and this deploy will overwrite the changes:\n", io.ColorScheme().WarningIcon())
+	for i, line := range lines {
+		if i == maxDriftLines {
+			fmt.Fprintf(io.ErrOut, "  ... and %d more\n", len(lines)-maxDriftLines)
+			break
+		}
+		fmt.Fprintf(io.ErrOut, "  %s\n", line)
+	}
+	fmt.Fprintln(io.ErrOut, "Run 'fly config show' to see the deployed config.")
+
+	if flag.GetYes(ctx) {
+		return nil
+	}
+	switch confirmed, err := prompt.Confirm(ctx, "Deploy anyway?"); {
+	case err == nil:
+		if !confirmed {
+			return flyerr.ErrAbort
+		}
+		return nil
+	case prompt.IsNonInteractive(err):
+		return prompt.NonInteractiveError("yes flag must be specified to deploy over the changes to the deployed config when not running interactively, or set --warn-on-drift=false")
+	default:
+		return err
+	}
+}
+
+// configDrift lists the changes from the config last deployed from here to
+// the deployed one, one per setting, sorted by setting: "+" for settings
+// added since, "-" for removed ones, and "~" for changed ones. Lists are
+// compared as a whole.
+func configDrift(deployedHere, remote *appconfig.Config) ([]string, error) {
+	changes, err := appconfig.Diff(deployedHere, remote)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for _, c := range changes {
+		if key, _, _ := strings.Cut(c.Setting, "."); slices.Contains(driftIgnoredKeys, key) {
+			continue
+		}
+		switch {
+		case c.Added():
+			lines = append(lines, fmt.Sprintf("+ %s = %s", c.Setting, shortSetting(c.New)))
+		case c.Removed():
+			lines = append(lines, fmt.Sprintf("- %s = %s", c.Setting, shortSetting(c.Old)))
+		default:
+			lines = append(lines, fmt.Sprintf("~ %s: %s -> %s", c.Setting, shortSetting(c.Old), shortSetting(c.New)))
+		}
+	}
+	return lines, nil
+}
+
+// deployedConfigPath returns where the config last deployed to the app from
+// the given config file is recorded, under the flyctl config directory.
+func deployedConfigPath(ctx context.Context, appName, configPath string) string {
+	if abs, err := filepath.Abs(configPath); err == nil {
+		configPath = abs
+	}
+	sum := sha256.Sum256([]byte(appName + "\x00" + configPath))
+	return filepath.Join(state.ConfigDirectory(ctx), "deployed", appName+"-"+hex.EncodeToString(sum[:8])+".json")
+}
+
+// loadDeployedConfig returns the config last deployed to the app from the
+// given config file, or nil if there's no record of one.
+func loadDeployedConfig(ctx context.Context, appName, configPath string) (*appconfig.Config, error) {
+	if configPath == "" {
+		return nil, nil
+	}
+	b, err := os.ReadFile(deployedConfigPath(ctx, appName, configPath))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var definition fly.Definition
+	if err := json.Unmarshal(b, &definition); err != nil {
+		return nil, err
+	}
+	return appconfig.FromDefinition(&definition)
+}
+
+// recordDeployedConfig records cfg as the config last deployed to the app
+// from its config file, for the next deploy's drift check. Failing to record
+// it only loses the check, so errors are only logged.
+func recordDeployedConfig(ctx context.Context, appName string, cfg *appconfig.Config) {
+	if cfg.ConfigFilePath() == "" {
+		return
+	}
+	err := func() error {
+		// Like the release's definition, which is the JSON of the config.
+		b, err := json.Marshal(cfg)
+		if err != nil {
+			return err
+		}
+		path := deployedConfigPath(ctx, appName, cfg.ConfigFilePath())
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return err
+		}
+		return os.WriteFile(path, b, 0o600)
+	}()
+	if err != nil {
+		terminal.Debugf("could not record the deployed config: %v\n", err)
+	}
+}
+
+// shortSetting shortens a setting value to fit on a line of a drift summary.
+func shortSetting(v string) string {
+	if len(v) > 60 {
+		return v[:57] + "..."
+	}
+	return v
+}
@@ -0,0 +1,126 @@
+package deploy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestConfigDrift(t *testing.T) {
+	const toml = `
+app = "my-app"
+primary_region = "ord"
+
+[build]
+  image = "nginx"
+
+[env]
+  LOG_LEVEL = "info"
+  PORT = "8080"
+
+[http_service]
+  internal_port = 8080
+  force_https = true
+
+[[vm]]
+  size = "shared-cpu-1x"
+`
+	path := filepath.Join(t.TempDir(), "fly.toml")
+	require.NoError(t, os.WriteFile(path, []byte(toml), 0o644))
+	local, err := appconfig.LoadConfig(path)
+	require.NoError(t, err)
+
+	deployed := func(t *testing.T) *appconfig.Config {
+		definition, err := local.ToDefinition()
+		require.NoError(t, err)
+		cfg, err := appconfig.FromDefinition(definition)
+		require.NoError(t, err)
+		return cfg
+	}
+
+	lines, err := configDrift(local, deployed(t))
+	require.NoError(t, err)
+	assert.Empty(t, lines, "a config doesn't drift from itself once deployed")
+
+	remote := deployed(t)
+	remote.Env["LOG_LEVEL"] = "debug"
+	delete(remote.Env, "PORT")
+	remote.Env["FEATURE"] = "on"
+	remote.HTTPService.InternalPort = 3000
+	remote.Compute[0].Memory = "1gb"
+	remote.Build = nil
+
+	lines, err = configDrift(local, remote)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`+ env.FEATURE = "on"`,
+		`~ env.LOG_LEVEL: "info" -> "debug"`,
+		`- env.PORT = "8080"`,
+		`~ http_service.internal_port: 8080 -> 3000`,
+		`~ vm: [{"size":"shared-cpu-1x"}] -> [{"memory":"1gb","size":"shared-cpu-1x"}]`,
+	}, lines)
+}
+
+func TestDeployedConfigRecord(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fly.toml")
+	require.NoError(t, os.WriteFile(path, []byte("app = \"my-app\"\n\n[env]\n  PORT = \"8080\"\n"), 0o644))
+	cfg, err := appconfig.LoadConfig(path)
+	require.NoError(t, err)
+
+	ctx := state.WithConfigDirectory(context.Background(), filepath.Join(dir, "config"))
+
+	deployedHere, err := loadDeployedConfig(ctx, "my-app", path)
+	require.NoError(t, err)
+	assert.Nil(t, deployedHere, "nothing was deployed from here yet")
+
+	recordDeployedConfig(ctx, "my-app", cfg)
+	deployedHere, err = loadDeployedConfig(ctx, "my-app", path)
+	require.NoError(t, err)
+	require.NotNil(t, deployedHere)
+	lines, err := configDrift(deployedHere, cfg)
+	require.NoError(t, err)
+	assert.Empty(t, lines)
+
+	other, err := loadDeployedConfig(ctx, "other-app", path)
+	require.NoError(t, err)
+	assert.Nil(t, other, "records are kept per app")
+}
+
+func TestReportDrift(t *testing.T) {
+	cmd := New()
+	require.NoError(t, cmd.Flags().Parse(nil))
+	ios, _, _, errOut := iostreams.Test()
+	ctx := iostreams.NewContext(context.Background(), ios)
+	ctx = flag.NewContext(ctx, cmd.Flags())
+
+	require.NoError(t, reportDrift(ctx, nil))
+	assert.Empty(t, errOut.String())
+
+	drift := []string{`~ env.PORT: "8080" -> "3000"`}
+	err := reportDrift(ctx, drift)
+	assert.True(t, prompt.IsNonInteractive(err), "a session that can't prompt needs --yes")
+	assert.ErrorContains(t, err, "yes flag must be specified")
+	assert.Contains(t, errOut.String(), "this deploy will overwrite the changes")
+	assert.Contains(t, errOut.String(), `~ env.PORT: "8080" -> "3000"`)
+
+	require.NoError(t, cmd.Flags().Set("yes", "true"))
+	require.NoError(t, reportDrift(ctx, drift))
+}
+
+func TestShortSetting(t *testing.T) {
+	assert.Equal(t, `"short"`, shortSetting(`"short"`))
+	long := shortSetting(`"` + strings.Repeat("a", 100) + `"`)
+	assert.Len(t, long, 60)
+	assert.True(t, strings.HasSuffix(long, "..."))
+}
@@ -0,0 +1,86 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/logrusorgru/aurora"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/gitinfo"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+type gitInfoContextKey struct{}
+
+func withGitInfo(ctx context.Context, info *gitinfo.Info) context.Context {
+	return context.WithValue(ctx, gitInfoContextKey{}, info)
+}
+
+// gitInfoFromContext returns the git details of the deploy, or nil when they
+// weren't detected.
+func gitInfoFromContext(ctx context.Context) *gitinfo.Info {
+	info, _ := ctx.Value(gitInfoContextKey{}).(*gitinfo.Info)
+	return info
+}
+
+// detectGit returns the git details of the working directory unless --no-git
+// is set. It warns when the working tree has uncommitted changes, since the
+// deploy then doesn't match the commit it's recorded with, unless
+// --allow-dirty is set.
+func detectGit(ctx context.Context) *gitinfo.Info {
+	if flag.GetBool(ctx, "no-git") {
+		return nil
+	}
+
+	info := gitinfo.Detect(ctx, state.WorkingDirectory(ctx))
+	if info != nil && info.Dirty && !flag.GetBool(ctx, "allow-dirty") {
+		fmt.Fprintf(iostreams.FromContext(ctx).ErrOut,
+			"%s The git working tree has uncommitted changes, so this deploy doesn't match commit %s. Pass --allow-dirty to silence this warning.\n",
+			aurora.Yellow("WARN"), info.Commit[:7])
+	}
+	return info
+}
+
+// gitLabels returns the image labels describing the git checkout.
+func gitLabels(info *gitinfo.Info) map[string]string {
+	if info == nil {
+		return nil
+	}
+
+	labels := map[string]string{
+		"org.opencontainers.image.revision": info.Commit,
+		"fly.git.dirty":                     strconv.FormatBool(info.Dirty),
+	}
+	if info.Branch != "" {
+		labels["fly.git.branch"] = info.Branch
+	}
+	return labels
+}
+
+// gitBuildArgs returns the GIT_SHA, GIT_BRANCH and GIT_DIRTY build args
+// describing the git checkout.
+func gitBuildArgs(info *gitinfo.Info) map[string]string {
+	if info == nil {
+		return nil
+	}
+
+	return map[string]string{
+		"GIT_SHA":    info.Commit,
+		"GIT_BRANCH": info.Branch,
+		"GIT_DIRTY":  strconv.FormatBool(info.Dirty),
+	}
+}
+
+// gitReleaseMetadata is release metadata carrying the git details of the
+// deploy, which fly releases shows.
+type gitReleaseMetadata struct {
+	*fly.ReleaseMetadata
+	Git *gitinfo.Info `json:"git"`
+}
+
+func releaseMetadata(metadata *fly.ReleaseMetadata, info *gitinfo.Info) any {
+	return gitReleaseMetadata{ReleaseMetadata: metadata, Git: info}
+}
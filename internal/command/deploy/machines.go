@@ -21,6 +21,7 @@ import (
 	"github.com/superfly/flyctl/internal/command/deploy/statics"
 	"github.com/superfly/flyctl/internal/flapsutil"
 	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/internal/gitinfo"
 	"github.com/superfly/flyctl/internal/machine"
 	"github.com/superfly/flyctl/internal/tracing"
 	"github.com/superfly/flyctl/iostreams"
@@ -54,6 +55,10 @@ type MachineDeploymentArgs struct {
 	SkipDNSChecks         bool
 	SkipReleaseCommand    bool
 	MaxUnavailable        *float64
+	AbortThreshold        float64
+	WatchImmediate        time.Duration
+	WaitForMachines       int
+	Git                   *gitinfo.Info
 	RestartOnly           bool
 	WaitTimeout           *time.Duration
 	StopSignal            string
@@ -71,6 +76,7 @@ type MachineDeploymentArgs struct {
 	OnlyMachines          map[string]bool
 	ProcessGroups         map[string]bool
 	MaxConcurrent         int
+	FailureLogConcurrency int
 	VolumeInitialSize     int
 	RestartPolicy         *fly.MachineRestartPolicy
 	RestartMaxRetries     int
@@ -90,6 +96,10 @@ func argsFromManifest(manifest *DeployManifest, app *fly.AppCompact) MachineDepl
 		SkipDNSChecks:         manifest.SkipDNSChecks,
 		SkipReleaseCommand:    manifest.SkipReleaseCommand,
 		MaxUnavailable:        manifest.MaxUnavailable,
+		AbortThreshold:        manifest.AbortThreshold,
+		WatchImmediate:        manifest.WatchImmediate,
+		WaitForMachines:       manifest.WaitForMachines,
+		Git:                   manifest.Git,
 		RestartOnly:           manifest.RestartOnly,
 		WaitTimeout:           manifest.WaitTimeout,
 		StopSignal:            manifest.StopSignal,
@@ -105,6 +115,7 @@ func argsFromManifest(manifest *DeployManifest, app *fly.AppCompact) MachineDepl
 		OnlyMachines:          manifest.OnlyMachines,
 		ProcessGroups:         manifest.ProcessGroups,
 		MaxConcurrent:         manifest.MaxConcurrent,
+		FailureLogConcurrency: manifest.FailureLogConcurrency,
 		VolumeInitialSize:     manifest.VolumeInitialSize,
 		RestartPolicy:         manifest.RestartPolicy,
 		RestartMaxRetries:     manifest.RestartMaxRetries,
@@ -134,6 +145,10 @@ type machineDeployment struct {
 	skipDNSChecks         bool
 	skipReleaseCommand    bool
 	maxUnavailable        float64
+	abortThreshold        float64
+	watchImmediate        time.Duration
+	waitForMachines       int
+	git                   *gitinfo.Info
 	restartOnly           bool
 	waitTimeout           time.Duration
 	stopSignal            string
@@ -151,6 +166,7 @@ type machineDeployment struct {
 	onlyMachines          map[string]bool
 	processGroups         map[string]bool
 	maxConcurrent         int
+	failureLogConcurrency int
 	volumeInitialSize     int
 	tigrisStatics         *statics.DeployerState
 	deployRetries         int
@@ -250,6 +266,10 @@ func NewMachineDeployment(ctx context.Context, args MachineDeploymentArgs) (_ Ma
 	if maxConcurrent < 1 {
 		maxConcurrent = 1
 	}
+	failureLogConcurrency := args.FailureLogConcurrency
+	if failureLogConcurrency < 1 {
+		failureLogConcurrency = defaultFailureLogConcurrency
+	}
 
 	md := &machineDeployment{
 		apiClient:             apiClient,
@@ -265,6 +285,10 @@ func NewMachineDeployment(ctx context.Context, args MachineDeploymentArgs) (_ Ma
 		skipReleaseCommand:    args.SkipReleaseCommand,
 		restartOnly:           args.RestartOnly,
 		maxUnavailable:        maxUnavailable,
+		abortThreshold:        args.AbortThreshold,
+		watchImmediate:        args.WatchImmediate,
+		waitForMachines:       args.WaitForMachines,
+		git:                   args.Git,
 		waitTimeout:           waitTimeout,
 		stopSignal:            args.StopSignal,
 		leaseTimeout:          leaseTimeout,
@@ -278,6 +302,7 @@ func NewMachineDeployment(ctx context.Context, args MachineDeploymentArgs) (_ Ma
 		excludeMachines:       args.ExcludeMachines,
 		onlyMachines:          args.OnlyMachines,
 		maxConcurrent:         maxConcurrent,
+		failureLogConcurrency: failureLogConcurrency,
 		volumeInitialSize:     args.VolumeInitialSize,
 		processGroups:         args.ProcessGroups,
 		deployRetries:         args.DeployRetries,
@@ -611,6 +636,9 @@ func (md *machineDeployment) updateReleaseInBackend(ctx context.Context, status
 		Status:    status,
 		Metadata:  metadata,
 	}
+	if md.git != nil {
+		input.Metadata = releaseMetadata(metadata, md.git)
+	}
 
 	_, err := md.apiClient.UpdateRelease(ctx, input)
 
@@ -622,7 +650,7 @@ func (md *machineDeployment) updateReleaseInBackend(ctx context.Context, status
 }
 
 func (md *machineDeployment) logClearLinesAbove(count int) {
-	if md.io.IsInteractive() {
+	if md.io.ProgressIndicatorEnabled() {
 		builder := aec.EmptyBuilder
 		str := builder.Up(uint(count)).EraseLine(aec.EraseModes.All).ANSI
 		fmt.Fprint(md.io.ErrOut, str.String())
@@ -10,6 +10,9 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/cenkalti/backoff/v5"
@@ -28,6 +31,7 @@ import (
 	"github.com/superfly/flyctl/internal/machine"
 	"github.com/superfly/flyctl/internal/statuslogger"
 	"github.com/superfly/flyctl/internal/tracing"
+	"github.com/superfly/flyctl/internal/urls"
 	"github.com/superfly/flyctl/iostreams"
 	"github.com/superfly/flyctl/terminal"
 	"go.opentelemetry.io/otel/attribute"
@@ -424,12 +428,18 @@ func (md *machineDeployment) deployMachinesApp(ctx context.Context) error {
 	ctx, span := tracing.GetTracer().Start(ctx, "deploy_new_machines")
 	defer span.End()
 
+	timer := deployTimerFromContext(ctx)
 	if !md.skipReleaseCommand {
-		if err := md.runReleaseCommands(ctx); err != nil {
+		stopRelease := timer.track("release command")
+		err := md.runReleaseCommands(ctx)
+		stopRelease()
+		if err != nil {
 			return fmt.Errorf("release command failed - aborting deployment. %w", err)
 		}
 	}
 
+	defer timer.track("rollout")()
+
 	processGroupMachineDiff := md.resolveProcessGroupChanges()
 	md.warnAboutProcessGroupChanges(processGroupMachineDiff)
 
@@ -464,7 +474,21 @@ func (md *machineDeployment) deployMachinesApp(ctx context.Context) error {
 		machineUpdateEntries = append(machineUpdateEntries, &machineUpdateEntry{leasableMachine: lm, launchInput: li})
 	}
 
-	return md.updateExistingMachines(ctx, machineUpdateEntries)
+	if err := md.updateExistingMachines(ctx, machineUpdateEntries); err != nil {
+		return err
+	}
+
+	if md.strategy == "immediate" && md.watchImmediate > 0 {
+		// Machines kept stopped or suspended aren't expected to come up.
+		if err := md.watchImmediateMachines(ctx, launchedMachines(machineUpdateEntries)); err != nil {
+			return err
+		}
+	}
+
+	if md.waitForMachines != 0 {
+		return md.waitForMachineCount(ctx)
+	}
+	return nil
 }
 
 type machineUpdateEntry struct {
@@ -478,6 +502,14 @@ func (m machineUpdateEntries) machines() []machine.LeasableMachine {
 	return lo.Map(m, func(i *machineUpdateEntry, _ int) machine.LeasableMachine { return i.leasableMachine })
 }
 
+// launchedMachines returns the machines of entries that are started by their
+// update, leaving out the ones updated without being launched (SkipLaunch).
+func launchedMachines(entries []*machineUpdateEntry) []machine.LeasableMachine {
+	return machineUpdateEntries(lo.Filter(entries, func(e *machineUpdateEntry, _ int) bool {
+		return !e.launchInput.SkipLaunch
+	})).machines()
+}
+
 func errorIsTimeout(err error) bool {
 	// Match an error against various known timeout conditions.
 	// This is probably a sign that we need to standardize this better, but it works for now.
@@ -776,9 +808,6 @@ func (md *machineDeployment) updateUsingRollingStrategy(parentCtx context.Contex
 	parentCtx, span := tracing.GetTracer().Start(parentCtx, "rolling", trace.WithAttributes(attribute.String("strategy", md.strategy)))
 	defer span.End()
 
-	sl := statuslogger.Create(parentCtx, len(updateEntries), true)
-	defer sl.Destroy(false)
-
 	// Rolling strategy
 	slices.SortFunc(updateEntries, func(a, b *machineUpdateEntry) int {
 		return cmp.Compare(a.leasableMachine.Machine().ID, b.leasableMachine.Machine().ID)
@@ -789,6 +818,21 @@ func (md *machineDeployment) updateUsingRollingStrategy(parentCtx context.Contex
 		return e.launchInput.Config.ProcessGroup()
 	})
 
+	groups := lo.Keys(entriesByGroup)
+	slices.Sort(groups)
+	for _, group := range groups {
+		started := lo.CountBy(entriesByGroup[group], func(e *machineUpdateEntry) bool {
+			return e.leasableMachine.Machine().State == "started"
+		})
+		if started > 0 {
+			fmt.Fprintf(md.io.Out, "Updating %d started machines in group %s, %d at a time\n",
+				started, md.colorize.Bold(group), md.getPoolSize(started))
+		}
+	}
+
+	sl := statuslogger.Create(parentCtx, len(updateEntries), true)
+	defer sl.Destroy(false)
+
 	startIdx := 0
 	groupsPool := pool.New().
 		WithErrors().
@@ -806,8 +850,9 @@ func (md *machineDeployment) updateUsingRollingStrategy(parentCtx context.Contex
 			return e.leasableMachine.Machine().State != "started"
 		})
 
-		groupsPool.Go(func(ctx context.Context) error {
-			eg, ctx := errgroup.WithContext(ctx)
+		progress := &rolloutProgress{group: group, total: len(entries), abortThreshold: md.abortThreshold}
+		groupsPool.Go(func(groupCtx context.Context) error {
+			eg, ctx := errgroup.WithContext(groupCtx)
 
 			coldIdx := startIdx
 			if len(coldMachines) > 0 {
@@ -817,7 +862,7 @@ func (md *machineDeployment) updateUsingRollingStrategy(parentCtx context.Contex
 					if chunk >= STOPPED_MACHINES_POOL_SIZE {
 						chunk = STOPPED_MACHINES_POOL_SIZE
 					}
-					return md.updateEntriesGroup(ctx, group, coldMachines, sl, coldIdx, chunk)
+					return md.updateEntriesGroup(ctx, group, coldMachines, sl, coldIdx, chunk, progress)
 				})
 			}
 			startIdx += len(coldMachines)
@@ -828,12 +873,12 @@ func (md *machineDeployment) updateUsingRollingStrategy(parentCtx context.Contex
 					// Since these machines are still receiving traffic, the chunk size here is more conservative (lower)
 					// then the one above.
 					chunk := md.getPoolSize(len(warmMachines))
-					return md.updateEntriesGroup(ctx, group, warmMachines, sl, warmIdx, chunk)
+					return md.updateEntriesGroup(ctx, group, warmMachines, sl, warmIdx, chunk, progress)
 				})
 			}
 			startIdx += len(warmMachines)
 
-			return eg.Wait()
+			return md.finishRollout(groupCtx, progress, eg.Wait())
 		})
 	}
 
@@ -854,7 +899,117 @@ func (md *machineDeployment) getPoolSize(totalMachines int) int {
 	}
 }
 
-func (md *machineDeployment) updateEntriesGroup(parentCtx context.Context, group string, entries []*machineUpdateEntry, sl statuslogger.StatusLogger, startIdx int, poolSize int) error {
+// rolloutProgress tracks the machine updates of a process group during a
+// rolling deploy.
+type rolloutProgress struct {
+	group string
+	total int
+	// abortThreshold is the fraction of the group's machines that may fail
+	// before the rollout is aborted; 0 aborts on the first failure.
+	abortThreshold float64
+
+	updated atomic.Int32
+	failed  atomic.Int32
+
+	// mu protects previous.
+	mu sync.Mutex
+	// previous holds the machines updated in place, as they were before the
+	// update, so that an aborted rollout can restore them.
+	previous map[machine.LeasableMachine]*fly.Machine
+}
+
+// rolloutAbortedError is returned when a group's failed machine updates go
+// over the abort threshold.
+type rolloutAbortedError struct {
+	failed, total int
+	group         string
+	threshold     float64
+	err           error
+}
+
+func (e *rolloutAbortedError) Error() string {
+	return fmt.Sprintf("aborting deploy: %d of %d machines in group %s failed to update, over the %g%% abort threshold; last error: %v",
+		e.failed, e.total, e.group, e.threshold*100, e.err)
+}
+
+func (e *rolloutAbortedError) Unwrap() error {
+	return e.err
+}
+
+// changed records that lm was updated in place from previous.
+func (p *rolloutProgress) changed(lm machine.LeasableMachine, previous *fly.Machine) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.previous == nil {
+		p.previous = map[machine.LeasableMachine]*fly.Machine{}
+	}
+	p.previous[lm] = previous
+}
+
+// fail records a failed machine update. It returns the error that aborts the
+// group's rollout, or nil when the failure is within the abort threshold.
+func (p *rolloutProgress) fail(err error) error {
+	failed := p.failed.Add(1)
+	if p.abortThreshold == 0 || errors.Is(err, context.Canceled) {
+		return err
+	}
+	if float64(failed)/float64(p.total) > p.abortThreshold {
+		return &rolloutAbortedError{failed: int(failed), total: p.total, group: p.group, threshold: p.abortThreshold, err: err}
+	}
+	return nil
+}
+
+// err reports the failures that were tolerated during the rollout.
+func (p *rolloutProgress) err() error {
+	if failed := p.failed.Load(); failed > 0 {
+		return fmt.Errorf("%d of %d machines in group %s failed to update", failed, p.total, p.group)
+	}
+	return nil
+}
+
+// finishRollout returns the result of a group's rollout given the error of
+// its machine updates, rolling back the group when the rollout was aborted.
+func (md *machineDeployment) finishRollout(ctx context.Context, progress *rolloutProgress, err error) error {
+	var abortErr *rolloutAbortedError
+	switch {
+	case errors.As(err, &abortErr):
+		return md.rollBackGroup(ctx, progress, err)
+	case err != nil:
+		return err
+	default:
+		return progress.err()
+	}
+}
+
+// rollBackGroup restores the machines of an aborted rollout that were
+// updated in place to the config they had before the deploy. Machines that
+// were replaced can't be restored and are left as they are. It returns
+// abortErr along with any rollback failure.
+func (md *machineDeployment) rollBackGroup(ctx context.Context, progress *rolloutProgress, abortErr error) error {
+	progress.mu.Lock()
+	defer progress.mu.Unlock()
+	if len(progress.previous) == 0 {
+		return abortErr
+	}
+
+	fmt.Fprintf(md.io.ErrOut, "Rolling back %d machine(s) in group %s to their previous config\n",
+		len(progress.previous), md.colorize.Bold(progress.group))
+
+	var errs []error
+	for lm, previous := range progress.previous {
+		err := lm.Update(ctx, fly.LaunchMachineInput{
+			Config:     previous.Config,
+			Region:     previous.Region,
+			SkipLaunch: previous.State != fly.MachineStateStarted,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to roll back machine %s: %w", previous.ID, err))
+		}
+	}
+	return errors.Join(append([]error{abortErr}, errs...)...)
+}
+
+func (md *machineDeployment) updateEntriesGroup(parentCtx context.Context, group string, entries []*machineUpdateEntry, sl statuslogger.StatusLogger, startIdx int, poolSize int, progress *rolloutProgress) error {
 	parentCtx, span := tracing.GetTracer().Start(parentCtx, "update_entries_in_group", trace.WithAttributes(
 		attribute.Int("start_id", startIdx),
 		attribute.String("group", group),
@@ -911,9 +1066,10 @@ func (md *machineDeployment) updateEntriesGroup(parentCtx context.Context, group
 		statusSuccess := func() {
 			statuslogger.LogfStatus(eCtx,
 				statuslogger.StatusSuccess,
-				"Machine %s update %s",
+				"Machine %s update %s [%d/%d]",
 				md.colorize.Bold(fmtID),
 				md.colorize.Green("succeeded"),
+				progress.updated.Add(1), progress.total,
 			)
 		}
 		updateFunc := func(poolCtx context.Context) error {
@@ -931,15 +1087,19 @@ func (md *machineDeployment) updateEntriesGroup(parentCtx context.Context, group
 				statusRunning()
 			}
 
+			previous := e.leasableMachine.Machine()
 			if err := md.updateMachine(ctx, e, sl.Line(startIdx+idx)); err != nil {
 				statusFailure(err)
 				tracing.RecordError(span, err, "failed to update machine")
-				return err
+				return progress.fail(err)
+			}
+			if e.leasableMachine.Machine().ID == previous.ID {
+				progress.changed(e.leasableMachine, previous)
 			}
 			if err := md.waitForMachine(ctx, e, sl.Line(startIdx+idx)); err != nil {
 				tracing.RecordError(span, err, "failed to wait for machine")
 				statusFailure(err)
-				return err
+				return progress.fail(err)
 			}
 
 			statusSuccess()
@@ -1314,23 +1474,18 @@ func (md *machineDeployment) doSmokeChecks(ctx context.Context, lm machine.Leasa
 		resumeLogFn := statuslogger.Pause(ctx)
 		defer resumeLogFn()
 
-		logs, _, logErr := md.apiClient.GetAppLogs(ctx, md.app.Name, "", md.appConfig.PrimaryRegion, lm.Machine().ID)
+		logs, logErr := md.fetchMachineLogs(ctx, lm.Machine())
 		switch {
 		case logErr == nil:
-			for _, l := range logs {
-				// Ideally we should use InstanceID here, but it's not available in the logs.
-				if l.Timestamp >= lm.Machine().UpdatedAt {
-					smokeErr.logs += fmt.Sprintf("%s\n", l.Message)
-				}
-			}
+			smokeErr.logs = logs
 		case fly.IsNotAuthenticatedError(logErr):
 			span.AddEvent("not authorized to retrieve logs")
-			fmt.Fprintf(md.io.ErrOut, "Warn: not authorized to retrieve app logs (this can happen when using deploy tokens), so we can't show you what failed. Use `fly logs -i %s` or open the monitoring dashboard to see them: https://fly.io/apps/%s/monitoring?region=&instance=%s\n", lm.Machine().ID, md.appConfig.AppName, lm.Machine().ID)
-			smokeErr.logs = "<not authorized to retrieve logs>"
+			fmt.Fprintf(md.io.ErrOut, "Warn: not authorized to retrieve app logs (this can happen when using deploy tokens), so we can't show you what failed. Use `fly logs -i %s` or open the monitoring dashboard to see them: %s\n", lm.Machine().ID, urls.Monitoring(md.appConfig.AppName, lm.Machine().ID))
+			smokeErr.logs = machineSummary(md.latestMachine(ctx, lm.Machine())) + "<not authorized to retrieve logs>"
 		default:
 			span.AddEvent("error retrieving machine logs")
-			fmt.Fprintf(md.io.ErrOut, "Warn: got an error retrieving the logs so we can't show you what failed. Use `fly logs -i %s` or open the monitoring dashboard to see them: https://fly.io/apps/%s/monitoring?region=&instance=%s\n", lm.Machine().ID, md.appConfig.AppName, lm.Machine().ID)
-			smokeErr.logs = fmt.Sprintf("<error fetching logs, try `fly logs -i %s>", smokeErr.machineID)
+			fmt.Fprintf(md.io.ErrOut, "Warn: got an error retrieving the logs so we can't show you what failed. Use `fly logs -i %s` or open the monitoring dashboard to see them: %s\n", lm.Machine().ID, urls.Monitoring(md.appConfig.AppName, lm.Machine().ID))
+			smokeErr.logs = machineSummary(md.latestMachine(ctx, lm.Machine())) + fmt.Sprintf("<error fetching logs, try `fly logs -i %s`>", smokeErr.machineID)
 		}
 	}
 
@@ -1338,6 +1493,83 @@ func (md *machineDeployment) doSmokeChecks(ctx context.Context, lm machine.Leasa
 	return smokeErr
 }
 
+// machineLogAttempts bounds how many times the logs of a failed machine are
+// fetched when the connection is reset or times out, which the API client's
+// transport doesn't retry.
+const machineLogAttempts = 3
+
+var machineLogBackOff = func() backoff.BackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = 500 * time.Millisecond
+	return b
+}
+
+// fetchMachineLogs returns the log lines of m since its last update.
+func (md *machineDeployment) fetchMachineLogs(ctx context.Context, m *fly.Machine) (string, error) {
+	return backoff.Retry(ctx, func() (string, error) {
+		entries, _, err := md.apiClient.GetAppLogs(ctx, md.app.Name, "", md.appConfig.PrimaryRegion, m.ID)
+		switch {
+		case err != nil && isConnectionResetOrTimeout(err):
+			return "", err
+		case err != nil:
+			return "", backoff.Permanent(err)
+		}
+
+		var logs string
+		for _, l := range entries {
+			// Ideally we should use InstanceID here, but it's not available in the logs.
+			if l.Timestamp >= m.UpdatedAt {
+				logs += fmt.Sprintf("%s\n", l.Message)
+			}
+		}
+		return logs, nil
+	}, backoff.WithBackOff(machineLogBackOff()), backoff.WithMaxTries(machineLogAttempts))
+}
+
+func isConnectionResetOrTimeout(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	// The GraphQL client doesn't always wrap the underlying error.
+	return strings.Contains(err.Error(), "connection reset by peer")
+}
+
+// latestMachine returns m as it is now, so that its state and events are
+// current, or m itself when it can't be fetched.
+func (md *machineDeployment) latestMachine(ctx context.Context, m *fly.Machine) *fly.Machine {
+	latest, err := md.flapsClient.Get(ctx, m.ID)
+	if err != nil || latest == nil {
+		return m
+	}
+	return latest
+}
+
+// machineSummary describes what is known of m without its logs: its state,
+// region, image and latest event.
+func machineSummary(m *fly.Machine) string {
+	summary := fmt.Sprintf("Machine %s in %s is %s", m.ID, m.Region, m.State)
+	if m.ImageRef.Repository != "" {
+		summary += fmt.Sprintf(", running %s", m.ImageRefWithVersion())
+	}
+	summary += "\n"
+
+	if len(m.Events) > 0 {
+		e := m.Events[0]
+		summary += fmt.Sprintf("Latest event: %s %s at %s", e.Type, e.Status, e.Time().UTC().Format(time.RFC3339))
+		if e.Type == "exit" && e.Request != nil {
+			if code, err := e.Request.GetExitCode(); err == nil {
+				summary += fmt.Sprintf(" (exit code %d)", code)
+			}
+		}
+		summary += "\n"
+	}
+	return summary
+}
+
 func (md *machineDeployment) checkDNS(ctx context.Context) error {
 	ctx, span := tracing.GetTracer().Start(ctx, "check_dns")
 	defer span.End()
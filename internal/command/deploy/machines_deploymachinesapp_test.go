@@ -2,15 +2,22 @@ package deploy
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"syscall"
 	"testing"
 	"time"
 
+	"github.com/cenkalti/backoff/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/superfly/fly-go"
 	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/flapsutil"
 	"github.com/superfly/flyctl/internal/machine"
 	"github.com/superfly/flyctl/internal/mock"
+	"github.com/superfly/flyctl/internal/statuslogger"
 	"github.com/superfly/flyctl/iostreams"
 )
 
@@ -72,3 +79,184 @@ func TestDeployMachinesApp(t *testing.T) {
 	err := md.deployMachinesApp(ctx)
 	assert.NoError(t, err)
 }
+
+// rollOut drives updateEntriesGroup over 10 machines, one at a time, where
+// the updates of the 2nd, 4th, 6th and 8th fail. It returns the IDs of the
+// machines whose update was attempted, the IDs of the machines rolled back to
+// their previous image and the rollout error.
+func rollOut(t *testing.T, abortThreshold float64) (updated, rolledBack []string, err error) {
+	t.Helper()
+
+	var mu sync.Mutex
+	client := &mock.FlapsClient{
+		UpdateFunc: func(ctx context.Context, input fly.LaunchMachineInput, nonce string) (*fly.Machine, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if input.Config.Image == "old" {
+				rolledBack = append(rolledBack, input.ID)
+				return &fly.Machine{ID: input.ID, LeaseNonce: nonce, Config: input.Config}, nil
+			}
+			updated = append(updated, input.ID)
+			switch input.ID {
+			case "m1", "m3", "m5", "m7":
+				return nil, errors.New("health checks failed")
+			}
+			return &fly.Machine{ID: input.ID, LeaseNonce: nonce, Config: input.Config}, nil
+		},
+	}
+
+	ios, _, _, _ := iostreams.Test()
+	ctx := iostreams.NewContext(context.Background(), ios)
+	md := &machineDeployment{
+		app:            &fly.AppCompact{Name: "my-app"},
+		io:             ios,
+		colorize:       ios.ColorScheme(),
+		flapsClient:    client,
+		abortThreshold: abortThreshold,
+	}
+
+	var entries []*machineUpdateEntry
+	for i := 0; i < 10; i++ {
+		m := &fly.Machine{ID: fmt.Sprintf("m%d", i), LeaseNonce: "nonce", State: "stopped", Config: &fly.MachineConfig{Image: "old"}}
+		entries = append(entries, &machineUpdateEntry{
+			leasableMachine: machine.NewLeasableMachine(client, ios, m, false),
+			launchInput:     &fly.LaunchMachineInput{Config: &fly.MachineConfig{Image: "new"}, SkipLaunch: true},
+		})
+	}
+
+	sl := statuslogger.Create(ctx, len(entries), true)
+	defer sl.Destroy(true)
+
+	progress := &rolloutProgress{group: "app", total: len(entries), abortThreshold: abortThreshold}
+	err = md.finishRollout(ctx, progress, md.updateEntriesGroup(ctx, "app", entries, sl, 0, 1, progress))
+	slices.Sort(rolledBack)
+	return updated, rolledBack, err
+}
+
+func TestRolloutAbortThreshold(t *testing.T) {
+	t.Run("default aborts on the first failure", func(t *testing.T) {
+		updated, rolledBack, err := rollOut(t, 0)
+		assert.ErrorContains(t, err, "health checks failed")
+		assert.NotContains(t, err.Error(), "aborting deploy")
+		assert.Equal(t, []string{"m0", "m1"}, updated)
+		assert.Empty(t, rolledBack)
+	})
+
+	t.Run("aborts and rolls back once failures exceed the threshold", func(t *testing.T) {
+		updated, rolledBack, err := rollOut(t, 0.25)
+		assert.ErrorContains(t, err, "aborting deploy: 3 of 10 machines in group app failed to update, over the 25% abort threshold")
+		assert.Equal(t, []string{"m0", "m1", "m2", "m3", "m4", "m5"}, updated)
+		assert.Equal(t, []string{"m0", "m2", "m4"}, rolledBack)
+	})
+
+	t.Run("completes with failures within the threshold", func(t *testing.T) {
+		updated, rolledBack, err := rollOut(t, 0.5)
+		assert.EqualError(t, err, "4 of 10 machines in group app failed to update")
+		assert.Len(t, updated, 10)
+		assert.Empty(t, rolledBack)
+	})
+
+	t.Run("cancellation always aborts", func(t *testing.T) {
+		progress := &rolloutProgress{group: "app", total: 10, abortThreshold: 0.5}
+		assert.ErrorIs(t, progress.fail(context.Canceled), context.Canceled)
+	})
+}
+
+func TestFetchMachineLogs(t *testing.T) {
+	newDeployment := func(getAppLogs func(ctx context.Context, appName, token, region, instanceID string) ([]fly.LogEntry, string, error)) *machineDeployment {
+		return &machineDeployment{
+			app:       &fly.AppCompact{Name: "my-app"},
+			appConfig: &appconfig.Config{},
+			apiClient: &mock.Client{GetAppLogsFunc: getAppLogs},
+		}
+	}
+	m := &fly.Machine{ID: "m1", UpdatedAt: "2024-01-01T12:00:00Z"}
+
+	md := newDeployment(func(ctx context.Context, appName, token, region, instanceID string) ([]fly.LogEntry, string, error) {
+		return []fly.LogEntry{
+			{Timestamp: "2024-01-01T00:00:00Z", Message: "before the update"},
+			{Timestamp: "2024-01-02T00:00:00Z", Message: "crashed"},
+		}, "", nil
+	})
+	logs, err := md.fetchMachineLogs(context.Background(), m)
+	assert.NoError(t, err)
+	assert.Equal(t, "crashed\n", logs)
+
+	defer func(orig func() backoff.BackOff) { machineLogBackOff = orig }(machineLogBackOff)
+	machineLogBackOff = func() backoff.BackOff { return &backoff.ZeroBackOff{} }
+
+	// A reset connection or a timeout is retried a bounded number of times.
+	for _, fetchErr := range []error{
+		errors.New("read tcp: connection reset by peer"),
+		fmt.Errorf("fetching logs: %w", syscall.ECONNRESET),
+		fmt.Errorf("fetching logs: %w", context.DeadlineExceeded),
+	} {
+		var calls int
+		md = newDeployment(func(ctx context.Context, appName, token, region, instanceID string) ([]fly.LogEntry, string, error) {
+			calls++
+			if calls < machineLogAttempts {
+				return nil, "", fetchErr
+			}
+			return []fly.LogEntry{{Timestamp: "2024-01-02T00:00:00Z", Message: "crashed"}}, "", nil
+		})
+		logs, err = md.fetchMachineLogs(context.Background(), m)
+		assert.NoError(t, err, fetchErr)
+		assert.Equal(t, "crashed\n", logs)
+		assert.Equal(t, machineLogAttempts, calls)
+
+		calls = 0
+		md = newDeployment(func(ctx context.Context, appName, token, region, instanceID string) ([]fly.LogEntry, string, error) {
+			calls++
+			return nil, "", fetchErr
+		})
+		_, err = md.fetchMachineLogs(context.Background(), m)
+		assert.ErrorIs(t, err, fetchErr)
+		assert.Equal(t, machineLogAttempts, calls)
+	}
+
+	// Other failures are returned as is.
+	var calls int
+	md = newDeployment(func(ctx context.Context, appName, token, region, instanceID string) ([]fly.LogEntry, string, error) {
+		calls++
+		return nil, "", errors.New("not found")
+	})
+	_, err = md.fetchMachineLogs(context.Background(), m)
+	assert.EqualError(t, err, "not found")
+	assert.Equal(t, 1, calls)
+}
+
+func TestLatestMachine(t *testing.T) {
+	stale := &fly.Machine{ID: "m1", State: "started"}
+
+	md := &machineDeployment{flapsClient: &mock.FlapsClient{
+		GetFunc: func(ctx context.Context, machineID string) (*fly.Machine, error) {
+			return &fly.Machine{ID: machineID, State: "stopped"}, nil
+		},
+	}}
+	assert.Equal(t, "stopped", md.latestMachine(context.Background(), stale).State)
+
+	md = &machineDeployment{flapsClient: &mock.FlapsClient{
+		GetFunc: func(ctx context.Context, machineID string) (*fly.Machine, error) {
+			return nil, errors.New("timeout")
+		},
+	}}
+	assert.Same(t, stale, md.latestMachine(context.Background(), stale))
+}
+
+func TestMachineSummary(t *testing.T) {
+	m := &fly.Machine{
+		ID:       "m1",
+		Region:   "ord",
+		State:    "stopped",
+		ImageRef: fly.MachineImageRef{Repository: "registry.fly.io/my-app", Tag: "deployment-1"},
+		Events: []*fly.MachineEvent{
+			{Type: "exit", Status: "stopped", Timestamp: 1700000000000, Request: &fly.MachineRequest{ExitEvent: &fly.MachineExitEvent{ExitCode: 137}}},
+			{Type: "start", Status: "started", Timestamp: 1699999990000},
+		},
+	}
+	assert.Equal(t,
+		"Machine m1 in ord is stopped, running registry.fly.io/my-app:deployment-1\nLatest event: exit stopped at 2023-11-14T22:13:20Z (exit code 137)\n",
+		machineSummary(m),
+	)
+	assert.Equal(t, "Machine m2 in ams is created\n", machineSummary(&fly.Machine{ID: "m2", Region: "ams", State: "created"}))
+}
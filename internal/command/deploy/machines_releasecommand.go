@@ -23,6 +23,7 @@ import (
 	"github.com/superfly/flyctl/internal/machine"
 	"github.com/superfly/flyctl/internal/statuslogger"
 	"github.com/superfly/flyctl/internal/tracing"
+	"github.com/superfly/flyctl/internal/urls"
 	"github.com/superfly/flyctl/logs"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
@@ -194,7 +195,7 @@ func waitForLogs(md *machineDeployment, ctx context.Context, stream logs.LogStre
 	select {
 	case <-ctx.Done():
 		if fly.IsNotAuthenticatedError(stream.Err()) {
-			fmt.Fprintf(md.io.ErrOut, "Warn: not authorized to retrieve app logs (this can happen when using deploy tokens). Use `fly logs -i %s` or open the monitoring dashboard to see them: https://fly.io/apps/%s/monitoring?region=&instance=%s\n", id, md.appConfig.AppName, id)
+			fmt.Fprintf(md.io.ErrOut, "Warn: not authorized to retrieve app logs (this can happen when using deploy tokens). Use `fly logs -i %s` or open the monitoring dashboard to see them: %s\n", id, urls.Monitoring(md.appConfig.AppName, id))
 		} else if stream.Err() != nil && !errors.Is(stream.Err(), context.Canceled) {
 			fmt.Fprintf(md.io.ErrOut, "error getting release command logs: %v\n", stream.Err())
 		}
@@ -0,0 +1,100 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	fly "github.com/superfly/fly-go"
+)
+
+// waitForAllMachines is the waitForMachines value that waits for all the
+// machines of the deployed process groups that are expected to run.
+const waitForAllMachines = -1
+
+// machineCountWaitInterval is how often waitForMachineCount polls.
+var machineCountWaitInterval = 2 * time.Second
+
+// parseWaitForMachines parses the value of --wait-for-machines: nothing, a
+// number of machines, or "all".
+func parseWaitForMachines(v string) (int, error) {
+	switch v {
+	case "":
+		return 0, nil
+	case "all":
+		return waitForAllMachines, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid value %q for --wait-for-machines: it must be a number of machines or 'all'", v)
+	}
+	return n, nil
+}
+
+// waitForMachineCount waits, for at most md.waitTimeout, until at least
+// md.waitForMachines machines of the deployed process groups are started with
+// all their health checks passing. This covers the machines the deploy
+// doesn't wait for itself, like the ones being created by a concurrent
+// scale up.
+func (md *machineDeployment) waitForMachineCount(ctx context.Context) error {
+	waitCtx, cancel := context.WithTimeout(ctx, md.waitTimeout)
+	defer cancel()
+
+	var printed bool
+	for {
+		machines, err := md.flapsClient.ListActive(waitCtx)
+		if err != nil {
+			if !errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("failed to list machines: %w", err)
+			}
+		}
+
+		healthy, total := md.countHealthyMachines(machines)
+		want := md.waitForMachines
+		if want == waitForAllMachines {
+			want = max(total, 1)
+		}
+		if err == nil && healthy >= want {
+			fmt.Fprintf(md.io.Out, "%d of %d machines are started and healthy\n", healthy, want)
+			return nil
+		}
+		if !printed && err == nil {
+			fmt.Fprintf(md.io.Out, "Waiting for %d started and healthy machines, currently %d\n", want, healthy)
+			printed = true
+		}
+
+		select {
+		case <-waitCtx.Done():
+		case <-time.After(machineCountWaitInterval):
+		}
+		if errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+			return suggestChangeWaitTimeout(
+				fmt.Errorf("timeout reached waiting for %d started and healthy machines, got %d: %w", want, healthy, waitCtx.Err()),
+				"wait-timeout",
+			)
+		} else if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// countHealthyMachines returns the number of machines of the deployed process
+// groups that are expected to run, and how many of them are started with all
+// their health checks passing. Standbys and the stopped or suspended machines
+// a deploy keeps that way, like auto-stopped ones, aren't expected to run.
+func (md *machineDeployment) countHealthyMachines(machines []*fly.Machine) (healthy, total int) {
+	groups := md.ProcessNames()
+	for _, m := range machines {
+		if !slices.Contains(groups, m.ProcessGroup()) || (m.Config != nil && skipLaunch(m, m.Config)) {
+			continue
+		}
+		total++
+		if m.State == fly.MachineStateStarted && m.AllHealthChecks().AllPassing() {
+			healthy++
+		}
+	}
+	return healthy, total
+}
@@ -0,0 +1,102 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/mock"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestParseWaitForMachines(t *testing.T) {
+	for v, want := range map[string]int{"": 0, "all": waitForAllMachines, "3": 3} {
+		n, err := parseWaitForMachines(v)
+		require.NoError(t, err, v)
+		assert.Equal(t, want, n, v)
+	}
+	for _, v := range []string{"0", "-2", "some"} {
+		_, err := parseWaitForMachines(v)
+		assert.ErrorContains(t, err, "invalid value", v)
+	}
+}
+
+func TestWaitForMachineCount(t *testing.T) {
+	defer func(d time.Duration) { machineCountWaitInterval = d }(machineCountWaitInterval)
+	machineCountWaitInterval = 10 * time.Millisecond
+
+	newMachine := func(id, group, state string, checks ...fly.ConsulCheckStatus) *fly.Machine {
+		m := &fly.Machine{ID: id, State: state, Config: &fly.MachineConfig{
+			Metadata: map[string]string{fly.MachineConfigMetadataKeyFlyProcessGroup: group},
+		}}
+		for _, status := range checks {
+			m.Checks = append(m.Checks, &fly.MachineCheckStatus{Status: status})
+		}
+		return m
+	}
+
+	// m2 starts after the first poll, and a scale up creates m3 after the
+	// rollout, healthy from the third poll.
+	var polls atomic.Int32
+	client := &mock.FlapsClient{
+		ListActiveFunc: func(ctx context.Context) ([]*fly.Machine, error) {
+			n := polls.Add(1)
+			m2State := fly.MachineStateStarted
+			if n == 1 {
+				m2State = "starting"
+			}
+			ms := []*fly.Machine{
+				newMachine("m1", "app", fly.MachineStateStarted, fly.Passing),
+				newMachine("m2", "app", m2State),
+				newMachine("w1", "worker", fly.MachineStateStarted),
+			}
+			standby := newMachine("s1", "app", fly.MachineStateStopped)
+			standby.Config.Standbys = []string{"m1"}
+			autostopped := newMachine("a1", "app", fly.MachineStateStopped)
+			autostopped.Config.Services = []fly.MachineService{{Autostop: fly.Pointer(fly.MachineAutostopStop)}}
+			ms = append(ms, standby, autostopped)
+			switch {
+			case n == 2:
+				ms = append(ms, newMachine("m3", "app", fly.MachineStateStarted, fly.Critical))
+			case n > 2:
+				ms = append(ms, newMachine("m3", "app", fly.MachineStateStarted, fly.Passing))
+			}
+			return ms, nil
+		},
+	}
+
+	newDeployment := func(want int) *machineDeployment {
+		polls.Store(0)
+		ios, _, _, _ := iostreams.Test()
+		return &machineDeployment{
+			app:             &fly.AppCompact{Name: "my-app"},
+			appConfig:       &appconfig.Config{Processes: map[string]string{"app": "", "worker": ""}},
+			processGroups:   map[string]bool{"app": true},
+			io:              ios,
+			flapsClient:     client,
+			waitTimeout:     500 * time.Millisecond,
+			waitForMachines: want,
+		}
+	}
+
+	md := newDeployment(3)
+	require.NoError(t, md.waitForMachineCount(context.Background()))
+	assert.Equal(t, int32(3), polls.Load())
+	assert.Contains(t, md.io.Out.(fmt.Stringer).String(), "Waiting for 3 started and healthy machines, currently 1\n")
+
+	md = newDeployment(waitForAllMachines)
+	require.NoError(t, md.waitForMachineCount(context.Background()))
+	assert.Equal(t, int32(3), polls.Load(), "all waits for m3 once it exists, but not for a1 which is auto-stopped")
+
+	md = newDeployment(4)
+	start := time.Now()
+	err := md.waitForMachineCount(context.Background())
+	assert.ErrorContains(t, err, "timeout reached waiting for 4 started and healthy machines, got 3")
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
@@ -0,0 +1,165 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/sourcegraph/conc/pool"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyerr"
+	"github.com/superfly/flyctl/internal/machine"
+)
+
+// checkWatchImmediateStrategy fails when --watch-immediate is given for a
+// deploy that doesn't use the immediate strategy, before anything is built.
+func checkWatchImmediateStrategy(ctx context.Context, appConfig *appconfig.Config) error {
+	if !flag.IsSpecified(ctx, "watch-immediate") {
+		return nil
+	}
+
+	strategy := flag.GetString(ctx, "strategy")
+	if strategy == "" && appConfig.Deploy != nil {
+		strategy = appConfig.Deploy.Strategy
+	}
+	if strategy == "" {
+		strategy = "rolling"
+	}
+	if strategy == "immediate" {
+		return nil
+	}
+	return flyerr.GenericErr{
+		Err:     fmt.Sprintf("--watch-immediate only applies to the immediate strategy, but this deploy uses the %s strategy", strategy),
+		Suggest: "Deploy with --strategy immediate, or drop --watch-immediate",
+	}
+}
+
+// immediateWatchInterval is how often watchImmediateMachines polls.
+var immediateWatchInterval = 2 * time.Second
+
+// watchImmediateMachines polls the machines an immediate deploy updated, for
+// at most md.watchImmediate. It fails naming the machines that exited, or that
+// weren't started with passing health checks by the end of the window.
+func (md *machineDeployment) watchImmediateMachines(ctx context.Context, machines []machine.LeasableMachine) error {
+	if len(machines) == 0 {
+		return nil
+	}
+
+	pending := lo.Map(machines, func(lm machine.LeasableMachine, _ int) string { return lm.Machine().ID })
+	fmt.Fprintf(md.io.Out, "Watching %d machines for up to %s\n", len(pending), md.watchImmediate)
+
+	since := time.Now()
+	watchCtx, cancel := context.WithTimeout(ctx, md.watchImmediate)
+	defer cancel()
+
+	problems := map[string]string{}
+	latest := map[string]*fly.Machine{}
+	for len(pending) > 0 {
+		ms, err := md.flapsClient.GetMany(watchCtx, pending)
+		if err != nil {
+			if errors.Is(watchCtx.Err(), context.DeadlineExceeded) {
+				break
+			}
+			return fmt.Errorf("failed to watch machines: %w", err)
+		}
+
+		pending = nil
+		for _, m := range ms {
+			latest[m.ID] = m
+			up, exited, problem := immediateMachineStatus(m, since)
+			switch {
+			case up:
+				delete(problems, m.ID)
+			case exited:
+				problems[m.ID] = problem
+			default:
+				problems[m.ID] = problem
+				pending = append(pending, m.ID)
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+
+		select {
+		case <-watchCtx.Done():
+		case <-time.After(immediateWatchInterval):
+		}
+		if errors.Is(watchCtx.Err(), context.DeadlineExceeded) {
+			break
+		} else if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	if len(problems) == 0 {
+		fmt.Fprintf(md.io.Out, "All %d machines are up\n", len(machines))
+		return nil
+	}
+
+	ids := lo.Keys(problems)
+	sort.Strings(ids)
+	failed := lo.Map(ids, func(id string, _ int) *fly.Machine {
+		if m, ok := latest[id]; ok {
+			return m
+		}
+		return &fly.Machine{ID: id}
+	})
+	for i, report := range md.failedMachineReports(ctx, failed) {
+		fmt.Fprintf(md.io.ErrOut, "Failure #%d: machine %s %s\n%s\n", i+1, md.colorize.Bold(ids[i]), problems[ids[i]], report)
+	}
+	return fmt.Errorf("%d of %d machines didn't come up within %s of the immediate deploy", len(problems), len(machines), md.watchImmediate)
+}
+
+// immediateMachineStatus reports whether m is up, started with all its health
+// checks passing, or has exited with an error since the given time. When it
+// isn't up, problem describes why.
+func immediateMachineStatus(m *fly.Machine, since time.Time) (up, exited bool, problem string) {
+	for _, e := range m.Events {
+		if e.Type != "exit" || e.Request == nil || e.Time().Before(since) {
+			continue
+		}
+		if code, err := e.Request.GetExitCode(); err == nil && code != 0 {
+			return false, true, fmt.Sprintf("exited with code %d", code)
+		}
+	}
+
+	if m.State != fly.MachineStateStarted {
+		return false, false, fmt.Sprintf("is %s", m.State)
+	}
+	if checks := m.AllHealthChecks(); !checks.AllPassing() {
+		return false, false, fmt.Sprintf("has %d of %d health checks passing", checks.Passing, checks.Total)
+	}
+	return true, false, ""
+}
+
+// failedMachineReports returns the recent logs of each failed machine, in
+// the order of machines, or what is known of the machine when its logs can't
+// be fetched. At most md.failureLogConcurrency fetches run at once, so that
+// reporting a large failed rollout doesn't get rate limited.
+func (md *machineDeployment) failedMachineReports(ctx context.Context, machines []*fly.Machine) []string {
+	reports := make([]string, len(machines))
+
+	p := pool.New().WithMaxGoroutines(max(md.failureLogConcurrency, 1))
+	for i, m := range machines {
+		p.Go(func() {
+			logs, err := md.fetchMachineLogs(ctx, m)
+			switch {
+			case err != nil:
+				reports[i] = machineSummary(m) + fmt.Sprintf("<error fetching logs, try `fly logs -i %s`>\n", m.ID)
+			case logs == "":
+				reports[i] = machineSummary(m)
+			default:
+				reports[i] = logs
+			}
+		})
+	}
+	p.Wait()
+
+	return reports
+}
@@ -0,0 +1,172 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyerr"
+	"github.com/superfly/flyctl/internal/machine"
+	"github.com/superfly/flyctl/internal/mock"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestWatchImmediateMachines(t *testing.T) {
+	defer func(d time.Duration) { immediateWatchInterval = d }(immediateWatchInterval)
+	immediateWatchInterval = 10 * time.Millisecond
+
+	crashed := &fly.Machine{ID: "m2", State: fly.MachineStateStarted, Events: []*fly.MachineEvent{{
+		Type:      "exit",
+		Timestamp: time.Now().Add(time.Minute).UnixMilli(),
+		Request:   &fly.MachineRequest{ExitEvent: &fly.MachineExitEvent{ExitCode: 1}},
+	}}}
+
+	var polls atomic.Int32
+	client := &mock.FlapsClient{
+		GetManyFunc: func(ctx context.Context, ids []string) ([]*fly.Machine, error) {
+			n := polls.Add(1)
+			var ms []*fly.Machine
+			for _, id := range ids {
+				switch {
+				case id == "m1":
+					ms = append(ms, &fly.Machine{ID: id, State: fly.MachineStateStarted, Checks: []*fly.MachineCheckStatus{{Status: fly.Passing}}})
+				case id == "m2":
+					ms = append(ms, crashed)
+				case id == "m3" && n > 1:
+					ms = append(ms, &fly.Machine{ID: id, State: fly.MachineStateStarted})
+				default:
+					ms = append(ms, &fly.Machine{ID: id, State: "starting"})
+				}
+			}
+			return ms, nil
+		},
+	}
+
+	newDeployment := func() *machineDeployment {
+		ios, _, _, _ := iostreams.Test()
+		md := &machineDeployment{
+			app:            &fly.AppCompact{Name: "my-app"},
+			appConfig:      &appconfig.Config{},
+			io:             ios,
+			colorize:       ios.ColorScheme(),
+			flapsClient:    client,
+			watchImmediate: 200 * time.Millisecond,
+			apiClient: &mock.Client{
+				GetAppLogsFunc: func(ctx context.Context, appName, token, region, instanceID string) ([]fly.LogEntry, string, error) {
+					return []fly.LogEntry{{Message: "log line of " + instanceID}}, "", nil
+				},
+			},
+		}
+		return md
+	}
+	leasable := func(md *machineDeployment, ids ...string) (lms []machine.LeasableMachine) {
+		for _, id := range ids {
+			lms = append(lms, machine.NewLeasableMachine(client, md.io, &fly.Machine{ID: id}, false))
+		}
+		return
+	}
+
+	md := newDeployment()
+	require.NoError(t, md.watchImmediateMachines(context.Background(), leasable(md, "m1", "m3")))
+	assert.Greater(t, polls.Load(), int32(1))
+
+	md = newDeployment()
+	start := time.Now()
+	err := md.watchImmediateMachines(context.Background(), leasable(md, "m1", "m2", "m4"))
+	assert.EqualError(t, err, "2 of 3 machines didn't come up within 200ms of the immediate deploy")
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	errOut := md.io.ErrOut.(interface{ String() string }).String()
+	assert.Contains(t, errOut, "Failure #1: machine m2 exited with code 1\nlog line of m2\n")
+	assert.Contains(t, errOut, "Failure #2: machine m4 is starting\nlog line of m4\n")
+}
+
+func TestLaunchedMachines(t *testing.T) {
+	entry := func(id string, skipLaunch bool) *machineUpdateEntry {
+		return &machineUpdateEntry{
+			leasableMachine: machine.NewLeasableMachine(&mock.FlapsClient{}, iostreams.System(), &fly.Machine{ID: id}, false),
+			launchInput:     &fly.LaunchMachineInput{ID: id, SkipLaunch: skipLaunch},
+		}
+	}
+
+	machines := launchedMachines([]*machineUpdateEntry{entry("m1", false), entry("m2", true), entry("m3", false)})
+	var ids []string
+	for _, lm := range machines {
+		ids = append(ids, lm.Machine().ID)
+	}
+	assert.Equal(t, []string{"m1", "m3"}, ids, "stopped and suspended machines aren't watched")
+}
+
+func TestFailedMachineReportsConcurrency(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	md := &machineDeployment{
+		app:                   &fly.AppCompact{Name: "my-app"},
+		appConfig:             &appconfig.Config{},
+		failureLogConcurrency: 3,
+		apiClient: &mock.Client{
+			GetAppLogsFunc: func(ctx context.Context, appName, token, region, instanceID string) ([]fly.LogEntry, string, error) {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					m := maxInFlight.Load()
+					if n <= m || maxInFlight.CompareAndSwap(m, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+
+				if instanceID == "m7" {
+					return nil, "", errors.New("rate limited")
+				}
+				return []fly.LogEntry{{Message: "log line of " + instanceID}}, "", nil
+			},
+		},
+	}
+
+	var machines []*fly.Machine
+	for i := range 20 {
+		machines = append(machines, &fly.Machine{ID: fmt.Sprintf("m%d", i), Region: "ord", State: "stopped"})
+	}
+
+	reports := md.failedMachineReports(context.Background(), machines)
+	require.Len(t, reports, 20)
+	for i, report := range reports {
+		if i == 7 {
+			assert.Equal(t, "Machine m7 in ord is stopped\n<error fetching logs, try `fly logs -i m7`>\n", report)
+			continue
+		}
+		assert.Equal(t, fmt.Sprintf("log line of m%d\n", i), report)
+	}
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(3))
+}
+
+func TestCheckWatchImmediateStrategy(t *testing.T) {
+	check := func(appConfig *appconfig.Config, args ...string) error {
+		flags := pflag.NewFlagSet("deploy", pflag.ContinueOnError)
+		flags.String("strategy", "", "")
+		flags.String("watch-immediate", "", "")
+		require.NoError(t, flags.Parse(args))
+		return checkWatchImmediateStrategy(flag.NewContext(context.Background(), flags), appConfig)
+	}
+	immediate := &appconfig.Config{Deploy: &appconfig.Deploy{Strategy: "immediate"}}
+
+	assert.NoError(t, check(&appconfig.Config{}))
+	assert.NoError(t, check(&appconfig.Config{}, "--strategy", "immediate", "--watch-immediate", "30s"))
+	assert.NoError(t, check(immediate, "--watch-immediate", "30s"))
+
+	err := check(&appconfig.Config{}, "--watch-immediate", "30s")
+	assert.EqualError(t, err, "--watch-immediate only applies to the immediate strategy, but this deploy uses the rolling strategy")
+	assert.Equal(t, "Deploy with --strategy immediate, or drop --watch-immediate", flyerr.GetErrorSuggestion(err))
+
+	err = check(immediate, "--strategy", "canary", "--watch-immediate", "30s")
+	assert.ErrorContains(t, err, "uses the canary strategy")
+}
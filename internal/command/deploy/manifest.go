@@ -13,6 +13,7 @@ import (
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/internal/gitinfo"
 	"github.com/superfly/flyctl/internal/sentry"
 	"github.com/superfly/flyctl/iostreams"
 )
@@ -29,6 +30,10 @@ type DeployManifest struct {
 	SkipDNSChecks         bool                      `json:"skip_dns_checks,omitempty"`
 	SkipReleaseCommand    bool                      `json:"skip_release_command,omitempty"`
 	MaxUnavailable        *float64                  `json:"max_unavailable,omitempty"`
+	AbortThreshold        float64                   `json:"abort_threshold,omitempty"`
+	WatchImmediate        time.Duration             `json:"watch_immediate,omitempty"`
+	WaitForMachines       int                       `json:"wait_for_machines,omitempty"`
+	Git                   *gitinfo.Info             `json:"git,omitempty"`
 	RestartOnly           bool                      `json:"restart_only,omitempty"`
 	WaitTimeout           *time.Duration            `json:"wait_timeout,omitempty"`
 	StopSignal            string                    `json:"stop_signal,omitempty"`
@@ -45,6 +50,7 @@ type DeployManifest struct {
 	OnlyMachines          map[string]bool           `json:"only_machines,omitempty"`
 	ProcessGroups         map[string]bool           `json:"process_groups,omitempty"`
 	MaxConcurrent         int                       `json:"max_concurrent,omitempty"`
+	FailureLogConcurrency int                       `json:"failure_log_concurrency,omitempty"`
 	VolumeInitialSize     int                       `json:"volume_initial_size,omitempty"`
 	RestartPolicy         *fly.MachineRestartPolicy `json:"restart_policy,omitempty"`
 	RestartMaxRetries     int                       `json:"restart_max_retrie,omitempty"`
@@ -64,6 +70,10 @@ func NewManifest(AppName string, config *appconfig.Config, args MachineDeploymen
 		SkipDNSChecks:         args.SkipDNSChecks,
 		SkipReleaseCommand:    args.SkipReleaseCommand,
 		MaxUnavailable:        args.MaxUnavailable,
+		AbortThreshold:        args.AbortThreshold,
+		WatchImmediate:        args.WatchImmediate,
+		WaitForMachines:       args.WaitForMachines,
+		Git:                   args.Git,
 		RestartOnly:           args.RestartOnly,
 		WaitTimeout:           args.WaitTimeout,
 		StopSignal:            args.StopSignal,
@@ -79,6 +89,7 @@ func NewManifest(AppName string, config *appconfig.Config, args MachineDeploymen
 		OnlyMachines:          args.OnlyMachines,
 		ProcessGroups:         args.ProcessGroups,
 		MaxConcurrent:         args.MaxConcurrent,
+		FailureLogConcurrency: args.FailureLogConcurrency,
 		VolumeInitialSize:     args.VolumeInitialSize,
 		RestartPolicy:         args.RestartPolicy,
 		RestartMaxRetries:     args.RestartMaxRetries,
@@ -0,0 +1,172 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/agent"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/buildinfo"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyerr"
+	"github.com/superfly/flyctl/internal/flyutil"
+)
+
+const defaultSmokeTestTimeout = time.Minute
+
+var (
+	// smokeTestInterval is the time between the requests of a smoke test.
+	smokeTestInterval = 2 * time.Second
+	// smokeTestRequestTimeout bounds each request of a smoke test.
+	smokeTestRequestTimeout = 10 * time.Second
+)
+
+// smokeTest is the HTTP check of --smoke-test, run once a deploy succeeded.
+type smokeTest struct {
+	url     *url.URL
+	client  *http.Client
+	timeout time.Duration
+}
+
+// newSmokeTest returns the smoke test requested with --smoke-test for the
+// app, or nil if there's none. With --smoke-test-private the app is reached
+// through its Flycast address in the organization's private network, over
+// the agent's tunnel.
+func newSmokeTest(ctx context.Context, cfg *appconfig.Config, app *fly.AppCompact) (*smokeTest, error) {
+	if !flag.IsSpecified(ctx, "smoke-test") {
+		return nil, nil
+	}
+	if flag.GetDetach(ctx) {
+		return nil, fmt.Errorf("--smoke-test can't be used with --detach, as it runs once the deploy completed")
+	}
+
+	ref, err := url.Parse(flag.GetString(ctx, "smoke-test"))
+	if err != nil || ref.IsAbs() || ref.Host != "" {
+		return nil, fmt.Errorf("invalid --smoke-test path %q: it must be a path like /healthz", flag.GetString(ctx, "smoke-test"))
+	}
+	if !strings.HasPrefix(ref.Path, "/") {
+		ref.Path = "/" + ref.Path
+	}
+
+	timeout := defaultSmokeTestTimeout
+	if d, err := parseDurationFlag(ctx, "smoke-test-timeout"); err != nil {
+		return nil, err
+	} else if d != nil {
+		timeout = *d
+	}
+
+	st := &smokeTest{
+		client:  &http.Client{Timeout: smokeTestRequestTimeout},
+		timeout: timeout,
+	}
+
+	if !flag.GetBool(ctx, "smoke-test-private") {
+		base := cfg.URL()
+		if base == nil {
+			return nil, flyerr.GenericErr{
+				Err:     "--smoke-test needs the app to have a public HTTP service",
+				Suggest: "Add an [http_service] to your fly.toml, or use --smoke-test-private to test the app over its Flycast address",
+			}
+		}
+		st.url = base.ResolveReference(ref)
+		return st, nil
+	}
+
+	dialContext, err := privateDialContext(ctx, app)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the private network for the smoke test: %w", err)
+	}
+	st.url = (&url.URL{Scheme: "http", Host: app.Name + ".flycast", Path: "/"}).ResolveReference(ref)
+	st.client.Transport = &http.Transport{DialContext: dialContext}
+	return st, nil
+}
+
+// privateDialContext returns a dial function that resolves and dials
+// addresses in the app organization's private network over the agent's
+// tunnel.
+func privateDialContext(ctx context.Context, app *fly.AppCompact) (func(context.Context, string, string) (net.Conn, error), error) {
+	agentclient, err := agent.Establish(ctx, flyutil.ClientFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	dialer, err := agentclient.ConnectToTunnel(ctx, app.Organization.Slug, "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		ip, err := agentclient.Resolve(ctx, app.Organization.Slug, host, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", host, err)
+		}
+		return dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
+	}, nil
+}
+
+// run requests the smoke test URL until it answers with a 2xx status, or
+// fails once the smoke test timeout passed. The status of each request is
+// reported to out.
+func (st *smokeTest) run(ctx context.Context, out io.Writer) error {
+	fmt.Fprintf(out, "\nRunning smoke test: GET %s\n", st.url)
+
+	ctx, cancel := context.WithTimeout(ctx, st.timeout)
+	defer cancel()
+
+	var last string
+	for {
+		status, err := st.get(ctx)
+		switch {
+		case err == nil && status >= 200 && status < 300:
+			fmt.Fprintf(out, "  ✔ Smoke test passed: %d %s\n", status, http.StatusText(status))
+			return nil
+		case err == nil:
+			last = fmt.Sprintf("%d %s", status, http.StatusText(status))
+		case ctx.Err() != nil && last != "":
+			// The last request was cut short by the timeout; keep the
+			// result of the previous one.
+		default:
+			last = err.Error()
+		}
+		if ctx.Err() == nil {
+			fmt.Fprintf(out, "  Smoke test got %s, retrying\n", last)
+			select {
+			case <-ctx.Done():
+			case <-time.After(smokeTestInterval):
+				continue
+			}
+		}
+
+		return flyerr.GenericErr{
+			Err:     fmt.Sprintf("smoke test failed: GET %s didn't return a 2xx status within %s, last result: %s", st.url, st.timeout, last),
+			Suggest: "The app was deployed but doesn't look healthy. Check its logs with 'fly logs', or give it longer to start with --smoke-test-timeout",
+		}
+	}
+}
+
+// get requests the smoke test URL once and returns its status code.
+func (st *smokeTest) get(ctx context.Context) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, st.url.String(), http.NoBody)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", buildinfo.UserAgent())
+
+	res, err := st.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+	return res.StatusCode, nil
+}
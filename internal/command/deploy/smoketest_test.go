@@ -0,0 +1,95 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/flag"
+)
+
+func TestNewSmokeTest(t *testing.T) {
+	newCtx := func(args ...string) context.Context {
+		flags := pflag.NewFlagSet("deploy", pflag.ContinueOnError)
+		flags.String("smoke-test", "", "")
+		flags.String("smoke-test-timeout", defaultSmokeTestTimeout.String(), "")
+		flags.Bool("smoke-test-private", false, "")
+		flags.Bool("detach", false, "")
+		require.NoError(t, flags.Parse(args))
+		return flag.NewContext(context.Background(), flags)
+	}
+	cfg := &appconfig.Config{
+		AppName:     "my-app",
+		HTTPService: &appconfig.HTTPService{InternalPort: 8080},
+	}
+	app := &fly.AppCompact{Name: "my-app"}
+
+	st, err := newSmokeTest(newCtx(), cfg, app)
+	require.NoError(t, err)
+	assert.Nil(t, st)
+
+	st, err = newSmokeTest(newCtx("--smoke-test", "healthz?deep=1", "--smoke-test-timeout", "30"), cfg, app)
+	require.NoError(t, err)
+	assert.Equal(t, "https://my-app.fly.dev/healthz?deep=1", st.url.String())
+	assert.Equal(t, 30*time.Second, st.timeout)
+
+	_, err = newSmokeTest(newCtx("--smoke-test", "https://example.com/"), cfg, app)
+	assert.ErrorContains(t, err, "invalid --smoke-test path")
+
+	_, err = newSmokeTest(newCtx("--smoke-test", "/healthz", "--detach"), cfg, app)
+	assert.ErrorContains(t, err, "--detach")
+
+	_, err = newSmokeTest(newCtx("--smoke-test", "/healthz"), &appconfig.Config{AppName: "my-app"}, app)
+	assert.ErrorContains(t, err, "public HTTP service")
+}
+
+func TestSmokeTestRun(t *testing.T) {
+	defer func(interval time.Duration) { smokeTestInterval = interval }(smokeTestInterval)
+	smokeTestInterval = 10 * time.Millisecond
+
+	newSmoke := func(t *testing.T, handler http.HandlerFunc, timeout time.Duration) *smokeTest {
+		server := httptest.NewServer(handler)
+		t.Cleanup(server.Close)
+		u, err := url.Parse(server.URL + "/healthz")
+		require.NoError(t, err)
+		return &smokeTest{url: u, client: server.Client(), timeout: timeout}
+	}
+
+	t.Run("passes once the app is healthy", func(t *testing.T) {
+		var requests atomic.Int32
+		st := newSmoke(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/healthz", r.URL.Path)
+			if requests.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}, time.Minute)
+
+		var out bytes.Buffer
+		require.NoError(t, st.run(context.Background(), &out))
+		assert.Equal(t, int32(3), requests.Load())
+		assert.Contains(t, out.String(), "Smoke test got 503 Service Unavailable, retrying")
+		assert.Contains(t, out.String(), "Smoke test passed: 200 OK")
+	})
+
+	t.Run("fails with the last status", func(t *testing.T) {
+		st := newSmoke(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}, 100*time.Millisecond)
+
+		var out bytes.Buffer
+		err := st.run(context.Background(), &out)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "smoke test failed")
+		assert.Contains(t, err.Error(), "last result: 500 Internal Server Error")
+	})
+}
@@ -0,0 +1,135 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/superfly/flyctl/internal/build/imgsrc"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// deployTimer records how long each phase of a deploy took. A nil
+// *deployTimer is valid and records nothing, which is what you get
+// unless --timing is set.
+type deployTimer struct {
+	mu      sync.Mutex
+	started time.Time
+	phases  []phaseTiming
+}
+
+type phaseTiming struct {
+	Phase      string `json:"phase"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+type timerContextKey struct{}
+
+func newDeployTimer(enabled bool) *deployTimer {
+	if !enabled {
+		return nil
+	}
+	return &deployTimer{started: time.Now()}
+}
+
+func withDeployTimer(ctx context.Context, t *deployTimer) context.Context {
+	return context.WithValue(ctx, timerContextKey{}, t)
+}
+
+func deployTimerFromContext(ctx context.Context) *deployTimer {
+	t, _ := ctx.Value(timerContextKey{}).(*deployTimer)
+	return t
+}
+
+// track starts timing phase and returns a func that records it when called.
+func (t *deployTimer) track(phase string) func() {
+	if t == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		t.add(phase, time.Since(start))
+	}
+}
+
+func (t *deployTimer) add(phase string, d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.phases = append(t.phases, phaseTiming{Phase: phase, DurationMs: d.Milliseconds()})
+}
+
+// addBuild records the breakdown of a build performed by the resolver.
+// Steps the builder didn't go through are reported as -1 and skipped.
+func (t *deployTimer) addBuild(img *imgsrc.DeploymentImage) {
+	if t == nil || img == nil || img.Timings == nil {
+		return
+	}
+	for _, step := range []struct {
+		name string
+		ms   int64
+	}{
+		{"build: builder init", img.Timings.BuilderInitMs},
+		{"build: context", img.Timings.ContextBuildMs},
+		{"build: image", img.Timings.ImageBuildMs},
+		{"build: push", img.Timings.PushMs},
+	} {
+		if step.ms >= 0 {
+			t.add(step.name, time.Duration(step.ms)*time.Millisecond)
+		}
+	}
+}
+
+type timingSummary struct {
+	Phases  []phaseTiming `json:"phases"`
+	TotalMs int64         `json:"total_ms"`
+}
+
+func (t *deployTimer) summary() timingSummary {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return timingSummary{
+		Phases:  append([]phaseTiming(nil), t.phases...),
+		TotalMs: time.Since(t.started).Milliseconds(),
+	}
+}
+
+// report renders the summary once the deploy is done. The JSON summary goes
+// to stderr, so that it doesn't mix with the deploy's progress on stdout.
+func (t *deployTimer) report(ctx context.Context) error {
+	streams := iostreams.FromContext(ctx)
+	if config.FromContext(ctx).JSONOutput {
+		return t.render(streams.ErrOut, true)
+	}
+	return t.render(streams.Out, false)
+}
+
+func (t *deployTimer) render(w io.Writer, jsonOutput bool) error {
+	if t == nil {
+		return nil
+	}
+
+	summary := t.summary()
+	if jsonOutput {
+		return render.JSON(w, summary)
+	}
+
+	rows := make([][]string, 0, len(summary.Phases)+1)
+	for _, p := range summary.Phases {
+		rows = append(rows, []string{p.Phase, formatMs(p.DurationMs)})
+	}
+	rows = append(rows, []string{"total", formatMs(summary.TotalMs)})
+
+	fmt.Fprintln(w)
+	return render.Table(w, "Deploy timing", rows, "Phase", "Duration")
+}
+
+func formatMs(ms int64) string {
+	return (time.Duration(ms) * time.Millisecond).Round(100 * time.Millisecond).String()
+}
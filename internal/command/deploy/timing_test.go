@@ -0,0 +1,87 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/build/imgsrc"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestDeployTimerDisabled(t *testing.T) {
+	timer := newDeployTimer(false)
+	assert.Nil(t, timer)
+
+	// A disabled timer is safe to use and renders nothing.
+	timer.track("build")()
+	timer.addBuild(&imgsrc.DeploymentImage{Timings: &fly.BuildTimingsInput{}})
+
+	var buf bytes.Buffer
+	require.NoError(t, timer.render(&buf, false))
+	assert.Empty(t, buf.String())
+	assert.Nil(t, deployTimerFromContext(context.Background()))
+}
+
+func TestDeployTimerRender(t *testing.T) {
+	timer := newDeployTimer(true)
+	ctx := withDeployTimer(context.Background(), timer)
+	require.Same(t, timer, deployTimerFromContext(ctx))
+
+	timer.add("build", 3*time.Second)
+	timer.addBuild(&imgsrc.DeploymentImage{Timings: &fly.BuildTimingsInput{
+		BuilderInitMs:  500,
+		ContextBuildMs: -1,
+		ImageBuildMs:   2000,
+		PushMs:         400,
+	}})
+	timer.add("rollout", 1500*time.Millisecond)
+
+	var out bytes.Buffer
+	require.NoError(t, timer.render(&out, false))
+	assert.Contains(t, out.String(), "build: builder init")
+	assert.Contains(t, out.String(), "1.5s")
+	assert.NotContains(t, out.String(), "build: context")
+
+	var js bytes.Buffer
+	require.NoError(t, timer.render(&js, true))
+
+	var summary timingSummary
+	require.NoError(t, json.Unmarshal(js.Bytes(), &summary))
+	assert.Equal(t, []phaseTiming{
+		{Phase: "build", DurationMs: 3000},
+		{Phase: "build: builder init", DurationMs: 500},
+		{Phase: "build: image", DurationMs: 2000},
+		{Phase: "build: push", DurationMs: 400},
+		{Phase: "rollout", DurationMs: 1500},
+	}, summary.Phases)
+}
+
+func TestDeployTimerReportJSONToStderr(t *testing.T) {
+	timer := newDeployTimer(true)
+	timer.add("rollout", time.Second)
+
+	ios, _, out, errOut := iostreams.Test()
+	ctx := iostreams.NewContext(context.Background(), ios)
+	ctx = config.NewContext(ctx, &config.Config{JSONOutput: true})
+	require.NoError(t, timer.report(ctx))
+
+	assert.Empty(t, out.String())
+	var summary timingSummary
+	require.NoError(t, json.Unmarshal(errOut.Bytes(), &summary))
+	assert.Equal(t, []phaseTiming{{Phase: "rollout", DurationMs: 1000}}, summary.Phases)
+
+	ios, _, out, errOut = iostreams.Test()
+	ctx = iostreams.NewContext(context.Background(), ios)
+	ctx = config.NewContext(ctx, &config.Config{})
+	require.NoError(t, timer.report(ctx))
+
+	assert.Contains(t, out.String(), "Deploy timing")
+	assert.Empty(t, errOut.String())
+}
@@ -0,0 +1,169 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/build/imgsrc"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// preflight collects the outcome of the checks run by deploy --verify-only.
+type preflight struct {
+	results []preflightResult
+}
+
+type preflightResult struct {
+	Check  string `json:"check"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+const (
+	preflightPass = "pass"
+	preflightFail = "fail"
+	preflightSkip = "skip"
+)
+
+// check runs fn and records its outcome under name.
+func (p *preflight) check(name string, fn func() (string, error)) bool {
+	detail, err := fn()
+	if err != nil {
+		p.fail(name, err)
+		return false
+	}
+	p.results = append(p.results, preflightResult{Check: name, Status: preflightPass, Detail: detail})
+	return true
+}
+
+func (p *preflight) fail(name string, err error) {
+	p.results = append(p.results, preflightResult{Check: name, Status: preflightFail, Detail: err.Error()})
+}
+
+func (p *preflight) skip(name, reason string) {
+	p.results = append(p.results, preflightResult{Check: name, Status: preflightSkip, Detail: reason})
+}
+
+func (p *preflight) failed() int {
+	n := 0
+	for _, r := range p.results {
+		if r.Status == preflightFail {
+			n++
+		}
+	}
+	return n
+}
+
+// render writes the results to w and returns an error when any check failed.
+func (p *preflight) render(w io.Writer, asJSON bool) error {
+	if asJSON {
+		if err := render.JSON(w, p.results); err != nil {
+			return err
+		}
+	} else {
+		rows := make([][]string, 0, len(p.results))
+		for _, r := range p.results {
+			rows = append(rows, []string{r.Check, r.Status, r.Detail})
+		}
+		if err := render.Table(w, "Preflight checks", rows, "Check", "Result", "Detail"); err != nil {
+			return err
+		}
+	}
+
+	if n := p.failed(); n > 0 {
+		return fmt.Errorf("%d of %d preflight checks failed", n, len(p.results))
+	}
+	return nil
+}
+
+// verifyDeploy runs the checks a deploy would go through before building and
+// releasing, without building, pushing or changing the app.
+func verifyDeploy(ctx context.Context) error {
+	var (
+		p       preflight
+		cfg     *appconfig.Config
+		appName = appconfig.NameFromContext(ctx)
+	)
+
+	configOK := p.check("app config", func() (detail string, err error) {
+		if cfg, err = determineAppConfig(ctx); err != nil {
+			return "", err
+		}
+		if appconfig.ConfigFromContext(ctx) != nil {
+			return cfg.ConfigFilePath(), nil
+		}
+		return "fetched from the deployed app", nil
+	})
+
+	const needsConfig = "needs a valid app config"
+	if !configOK {
+		p.skip("image source", needsConfig)
+		p.skip("builder", needsConfig)
+	} else if ref, err := fetchImageRef(ctx, cfg); err != nil {
+		p.fail("image source", err)
+		p.skip("builder", "needs an image source")
+	} else if ref != "" {
+		p.check("image source", func() (string, error) {
+			return ref, imgsrc.CheckImageResolvable(ctx, ref)
+		})
+		p.skip("builder", "deploying a prebuilt image")
+	} else {
+		p.check("image source", func() (string, error) { return buildSource(ctx, cfg) })
+		p.check("builder", func() (string, error) {
+			if !flag.GetLocalOnly(ctx) {
+				return "remote builder, started on demand", nil
+			}
+			return "local docker daemon", imgsrc.CheckLocalDaemon(ctx)
+		})
+	}
+
+	p.check("registry auth", func() (string, error) {
+		return "push access to " + appName, imgsrc.CheckRegistryAuth(ctx, appName)
+	})
+
+	return p.render(iostreams.FromContext(ctx).Out, config.FromContext(ctx).JSONOutput)
+}
+
+// buildSource describes what a source build of cfg would build from, and
+// fails when there's nothing to build.
+func buildSource(ctx context.Context, cfg *appconfig.Config) (string, error) {
+	path, err := resolveDockerfilePath(ctx, cfg)
+	if err != nil {
+		return "", err
+	}
+	if path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("dockerfile %s: %w", path, err)
+		}
+		return "dockerfile " + path, nil
+	}
+
+	if builder := flag.GetString(ctx, flag.BuildpacksBuilder); builder != "" {
+		return "buildpacks builder " + builder, nil
+	}
+	if build := cfg.Build; build != nil {
+		switch {
+		case build.Builder != "":
+			return "buildpacks builder " + build.Builder, nil
+		case build.Builtin != "":
+			return "builtin " + build.Builtin, nil
+		}
+	}
+
+	if found := imgsrc.ResolveDockerfile(state.WorkingDirectory(ctx)); found != "" {
+		return "dockerfile " + found, nil
+	}
+	if flag.GetBool(ctx, "nixpacks") {
+		return "nixpacks", nil
+	}
+
+	return "", errors.New("no Dockerfile, buildpacks builder or image configured")
+}
@@ -0,0 +1,68 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/state"
+)
+
+func TestPreflightRender(t *testing.T) {
+	var p preflight
+	assert.True(t, p.check("app config", func() (string, error) { return "fly.toml", nil }))
+	assert.False(t, p.check("image source", func() (string, error) { return "", errors.New("no Dockerfile") }))
+	p.skip("builder", "needs an image source")
+
+	var buf bytes.Buffer
+	err := p.render(&buf, false)
+	assert.EqualError(t, err, "1 of 3 preflight checks failed")
+	assert.Contains(t, buf.String(), "no Dockerfile")
+	assert.Contains(t, buf.String(), "skip")
+
+	buf.Reset()
+	_ = p.render(&buf, true)
+	assert.Contains(t, buf.String(), `"status": "fail"`)
+
+	p = preflight{}
+	p.check("app config", func() (string, error) { return "", nil })
+	assert.NoError(t, p.render(&bytes.Buffer{}, false))
+}
+
+func TestBuildSource(t *testing.T) {
+	dir := t.TempDir()
+
+	newCtx := func(args ...string) context.Context {
+		flags := pflag.NewFlagSet("deploy", pflag.ContinueOnError)
+		flags.String("dockerfile", "", "")
+		flags.String(flag.BuildpacksBuilder, "", "")
+		flags.Bool("nixpacks", false, "")
+		require.NoError(t, flags.Parse(args))
+		return state.WithWorkingDirectory(flag.NewContext(context.Background(), flags), dir)
+	}
+	cfg := appconfig.NewConfig()
+	cfg.SetConfigFilePath(filepath.Join(dir, "fly.toml"))
+
+	_, err := buildSource(newCtx(), cfg)
+	assert.EqualError(t, err, "no Dockerfile, buildpacks builder or image configured")
+
+	src, err := buildSource(newCtx("--buildpacks-builder", "paketobuildpacks/builder:base"), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "buildpacks builder paketobuildpacks/builder:base", src)
+
+	_, err = buildSource(newCtx("--dockerfile", filepath.Join(dir, "Missing.Dockerfile")), cfg)
+	assert.ErrorContains(t, err, "Missing.Dockerfile")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM scratch\n"), 0o644))
+	src, err = buildSource(newCtx(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "dockerfile "+filepath.Join(dir, "Dockerfile"), src)
+}
@@ -10,7 +10,6 @@ import (
 	"sort"
 	"time"
 
-	"github.com/briandowns/spinner"
 	"github.com/samber/lo"
 	"github.com/skratchdot/open-golang/open"
 	fly "github.com/superfly/fly-go"
@@ -20,6 +19,7 @@ import (
 	"github.com/superfly/flyctl/internal/flyutil"
 	"github.com/superfly/flyctl/internal/prompt"
 	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/internal/spinner"
 	"github.com/superfly/flyctl/iostreams"
 	"github.com/superfly/flyctl/scanner"
 )
@@ -50,6 +50,14 @@ var SharedFlags = flag.Set{
 	flag.Yes(),
 }
 
+// NoSetSecretFlag lets extension create commands skip setting the
+// provisioned extension's secrets on the target app.
+var NoSetSecretFlag = flag.Bool{
+	Name:        "no-set-secret",
+	Description: "Don't set the extension's secrets on the app; print them instead",
+	Aliases:     []string{"no-set-secrets"},
+}
+
 func ProvisionExtension(ctx context.Context, params ExtensionParams) (extension Extension, err error) {
 	client := flyutil.ClientFromContext(ctx).GenqClient()
 	io := iostreams.FromContext(ctx)
@@ -295,10 +303,7 @@ func WaitForProvision(ctx context.Context, name string, provider string) error {
 	io := iostreams.FromContext(ctx)
 	client := flyutil.ClientFromContext(ctx).GenqClient()
 
-	s := spinner.New(spinner.CharSets[9], 200*time.Millisecond)
-	s.Writer = io.ErrOut
-	s.Prefix = "Waiting for provisioning to complete "
-	s.Start()
+	s := spinner.Run(io, "Waiting for provisioning to complete")
 
 	defer s.Stop()
 	timeout := time.After(4 * time.Minute)
@@ -463,6 +468,10 @@ func setSecretsFromExtension(ctx context.Context, app *gql.AppData, extension *E
 			setSecrets = false
 		}
 
+		if flag.GetBool(ctx, NoSetSecretFlag.Name) {
+			setSecrets = false
+		}
+
 	} else {
 		setSecrets = false
 	}
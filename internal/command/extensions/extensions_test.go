@@ -0,0 +1,22 @@
+package extensions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateCommandsTakeNoSetSecret(t *testing.T) {
+	var creates int
+	for _, provider := range New().Commands() {
+		for _, cmd := range provider.Commands() {
+			if cmd.Name() != "create" {
+				continue
+			}
+			creates++
+			assert.NotNil(t, cmd.Flags().Lookup("no-set-secret"), provider.Name())
+			assert.NotNil(t, cmd.Flags().Lookup("no-set-secrets"), provider.Name())
+		}
+	}
+	assert.NotZero(t, creates)
+}
@@ -40,6 +40,7 @@ func create() (cmd *cobra.Command) {
 			Name:        "output",
 			Description: "The output path to save the kubeconfig file",
 		},
+		extensions_core.NoSetSecretFlag,
 	)
 	return cmd
 }
@@ -24,6 +24,7 @@ func create() (cmd *cobra.Command) {
 		flag.App(),
 		flag.AppConfig(),
 		extensions_core.SharedFlags,
+		extensions_core.NoSetSecretFlag,
 	)
 	return cmd
 }
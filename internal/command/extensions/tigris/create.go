@@ -26,6 +26,7 @@ func create() (cmd *cobra.Command) {
 		flag.AppConfig(),
 		flag.Org(),
 		extensions_core.SharedFlags,
+		extensions_core.NoSetSecretFlag,
 		SharedFlags,
 		flag.String{
 			Name:        "name",
@@ -25,6 +25,7 @@ func create() (cmd *cobra.Command) {
 		flag.Org(),
 		flag.Region(),
 		extensions_core.SharedFlags,
+		extensions_core.NoSetSecretFlag,
 		flag.String{
 			Name:        "name",
 			Shorthand:   "n",
@@ -2,26 +2,39 @@
 package history
 
 import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
 	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
 
+	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/internal/format"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
 )
 
 func New() (cmd *cobra.Command) {
 	const (
-		long = `List the history of changes in the application. Includes autoscaling
-events and their results.
+		long = `List the recent activity of the application, most recent first: its
+releases, and the events of its machines such as launches, updates, restarts
+and exits.
 `
-		short = "List an app's change history"
+		short = "List an app's recent activity"
 	)
 
-	cmd = command.New("history", short, long, nil,
+	cmd = command.New("history", short, long, run,
 		command.RequireSession,
 		command.RequireAppName,
 	)
-	cmd.Deprecated = "Use `flyctl apps releases` instead"
-	cmd.Hidden = true
 
 	cmd.Args = cobra.NoArgs
 
@@ -29,7 +42,110 @@ events and their results.
 		flag.App(),
 		flag.AppConfig(),
 		flag.JSONOutput(),
+		flag.Int{
+			Name:        "limit",
+			Description: "Maximum number of events to list",
+			Default:     25,
+		},
 	)
 
 	return
 }
+
+// event is an entry of an app's history.
+type event struct {
+	Time        time.Time `json:"time"`
+	Type        string    `json:"type"`
+	User        string    `json:"user"`
+	Description string    `json:"description"`
+}
+
+func run(ctx context.Context) error {
+	var (
+		appName = appconfig.NameFromContext(ctx)
+		client  = flyutil.ClientFromContext(ctx)
+		out     = iostreams.FromContext(ctx).Out
+		limit   = flag.GetInt(ctx, "limit")
+	)
+
+	if limit < 1 {
+		return fmt.Errorf("--limit must be at least 1, got %d", limit)
+	}
+
+	releases, err := client.GetAppReleasesMachines(ctx, appName, "", limit)
+	if err != nil {
+		return fmt.Errorf("failed retrieving app releases %s: %w", appName, err)
+	}
+
+	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{
+		AppName: appName,
+	})
+	if err != nil {
+		return err
+	}
+	machines, err := flapsClient.List(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed retrieving machines of %s: %w", appName, err)
+	}
+
+	events := collectEvents(releases, machines, limit)
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(out, events)
+	}
+
+	rows := make([][]string, 0, len(events))
+	for _, e := range events {
+		rows = append(rows, []string{
+			format.RelativeTime(e.Time),
+			e.Type,
+			e.User,
+			e.Description,
+		})
+	}
+	return render.Table(out, "", rows, "When", "Type", "User", "Description")
+}
+
+// collectEvents merges the releases and machine events into the most recent
+// limit events, newest first.
+func collectEvents(releases []fly.Release, machines []*fly.Machine, limit int) []event {
+	var events []event
+
+	for _, release := range releases {
+		description := fmt.Sprintf("v%d %s", release.Version, release.Status)
+		if release.Description != "" {
+			description += ": " + release.Description
+		}
+		events = append(events, event{
+			Time:        release.CreatedAt,
+			Type:        "release",
+			User:        release.User.Email,
+			Description: description,
+		})
+	}
+
+	for _, machine := range machines {
+		for _, e := range machine.Events {
+			description := fmt.Sprintf("Machine %s %s", machine.ID, e.Status)
+			if e.Type == "exit" && e.Request != nil {
+				if code, err := e.Request.GetExitCode(); err == nil {
+					description += fmt.Sprintf(" (exit code %d)", code)
+				}
+			}
+			events = append(events, event{
+				Time:        e.Time().UTC(),
+				Type:        e.Type,
+				User:        e.Source,
+				Description: description,
+			})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.After(events[j].Time)
+	})
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events
+}
@@ -0,0 +1,41 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	fly "github.com/superfly/fly-go"
+)
+
+func TestCollectEvents(t *testing.T) {
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return base.Add(time.Duration(minutes) * time.Minute) }
+	millis := func(minutes int) int64 { return at(minutes).UnixMilli() }
+
+	releases := []fly.Release{
+		{Version: 1, Status: "complete", Description: "Deploy image", User: fly.User{Email: "ops@example.com"}, CreatedAt: at(0)},
+		{Version: 2, Status: "failed", User: fly.User{Email: "dev@example.com"}, CreatedAt: at(30)},
+	}
+	machines := []*fly.Machine{{
+		ID: "148e21ea7e6989",
+		Events: []*fly.MachineEvent{
+			{Type: "launch", Status: "created", Source: "user", Timestamp: millis(1)},
+			{Type: "exit", Status: "stopped", Source: "flyd", Timestamp: millis(20), Request: &fly.MachineRequest{
+				ExitEvent: &fly.MachineExitEvent{ExitCode: 137},
+			}},
+		},
+	}}
+
+	events := collectEvents(releases, machines, 10)
+	assert.Equal(t, []event{
+		{Time: at(30), Type: "release", User: "dev@example.com", Description: "v2 failed"},
+		{Time: at(20), Type: "exit", User: "flyd", Description: "Machine 148e21ea7e6989 stopped (exit code 137)"},
+		{Time: at(1), Type: "launch", User: "user", Description: "Machine 148e21ea7e6989 created"},
+		{Time: at(0), Type: "release", User: "ops@example.com", Description: "v1 complete: Deploy image"},
+	}, events)
+
+	limited := collectEvents(releases, machines, 2)
+	assert.Len(t, limited, 2)
+	assert.Equal(t, at(20), limited[1].Time)
+}
@@ -127,6 +127,9 @@ func runAllocateIPAddress(ctx context.Context, addrType string, org *fly.Organiz
 	}
 
 	region := flag.GetRegion(ctx)
+	if err := prompt.ValidateRegion(ctx, region); err != nil {
+		return err
+	}
 
 	ipAddress, err := client.AllocateIPAddress(ctx, appName, addrType, region, org, network)
 	if err != nil {
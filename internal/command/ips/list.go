@@ -10,8 +10,6 @@ import (
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/flyutil"
-	"github.com/superfly/flyctl/internal/render"
-	"github.com/superfly/flyctl/iostreams"
 )
 
 func newList() *cobra.Command {
@@ -38,7 +36,6 @@ func newList() *cobra.Command {
 func runIPAddressesList(ctx context.Context) error {
 	cfg := config.FromContext(ctx)
 	client := flyutil.ClientFromContext(ctx)
-	out := iostreams.FromContext(ctx).Out
 
 	appName := appconfig.NameFromContext(ctx)
 	ipAddresses, err := client.GetIPAddresses(ctx, appName)
@@ -47,7 +44,7 @@ func runIPAddressesList(ctx context.Context) error {
 	}
 
 	if cfg.JSONOutput {
-		return render.JSON(out, ipAddresses)
+		return renderListJSON(ctx, ipAddresses)
 	}
 
 	renderListTable(ctx, ipAddresses)
@@ -2,18 +2,26 @@ package ips
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
 	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/agent"
 	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/iostreams"
 )
 
 func newPrivate() *cobra.Command {
 	const (
-		long  = `List instances private IP addresses, accessible from within the Fly network`
+		long = `List instances private IP addresses, accessible from within the Fly network.
+
+With --resolve, also list the .internal DNS name of each instance, resolved
+through the agent's tunnel to the app's private network.`
 		short = `List instances private IP addresses`
 	)
 
@@ -26,6 +34,10 @@ func newPrivate() *cobra.Command {
 		flag.App(),
 		flag.AppConfig(),
 		flag.JSONOutput(),
+		flag.Bool{
+			Name:        "resolve",
+			Description: "Resolve and list the <id>.vm.<app>.internal DNS name of each instance",
+		},
 	)
 	return cmd
 }
@@ -44,7 +56,48 @@ func runPrivateIPAddressesList(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	renderPrivateTableMachines(ctx, machines)
+
+	var names map[string]string
+	if flag.GetBool(ctx, "resolve") {
+		names, err = resolvePrivateNames(ctx, appName, machines)
+		if err != nil {
+			io := iostreams.FromContext(ctx)
+			fmt.Fprintf(io.ErrOut, "%s could not resolve the DNS names, listing the IP addresses only: %v\n", io.ColorScheme().WarningIcon(), err)
+		}
+	}
+	renderPrivateTableMachines(ctx, appName, machines, names)
 
 	return nil
 }
+
+// privateDNSName returns the .internal DNS name of the machine.
+func privateDNSName(appName string, machine *fly.Machine) string {
+	return fmt.Sprintf("%s.vm.%s.internal", machine.ID, appName)
+}
+
+// resolvePrivateNames resolves the .internal DNS name of each machine through
+// the agent, and returns the addresses they resolved to by machine ID. A name
+// that doesn't resolve is left out; an error means the agent isn't available.
+func resolvePrivateNames(ctx context.Context, appName string, machines []*fly.Machine) (map[string]string, error) {
+	client := flyutil.ClientFromContext(ctx)
+
+	app, err := client.GetAppBasic(ctx, appName)
+	if err != nil {
+		return nil, fmt.Errorf("get app: %w", err)
+	}
+
+	agentclient, err := agent.Establish(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string, len(machines))
+	for _, machine := range machines {
+		addr, err := agentclient.Resolve(ctx, app.Organization.Slug, privateDNSName(appName, machine), "")
+		if err != nil {
+			continue
+		}
+		names[machine.ID] = addr
+	}
+	return names, nil
+}
@@ -11,6 +11,41 @@ import (
 	"github.com/superfly/flyctl/iostreams"
 )
 
+// ipAddressJSON is the JSON form of an IP address, labeled with its kind.
+type ipAddressJSON struct {
+	fly.IPAddress
+	// Kind is one of v4-dedicated, v4-shared, v6 or private, or the raw
+	// Type of an address of an unknown type. Dedicated IPv4 addresses are
+	// billed, shared ones aren't.
+	Kind string
+}
+
+// ipAddressKind returns the Kind label of the address.
+func ipAddressKind(ipAddr fly.IPAddress) string {
+	switch ipAddr.Type {
+	case "v4":
+		return "v4-dedicated"
+	case "shared_v4":
+		return "v4-shared"
+	case "v6":
+		return "v6"
+	case "private_v6":
+		return "private"
+	default:
+		return ipAddr.Type
+	}
+}
+
+func renderListJSON(ctx context.Context, ipAddresses []fly.IPAddress) error {
+	addrs := make([]ipAddressJSON, 0, len(ipAddresses))
+	for _, ipAddr := range ipAddresses {
+		addrs = append(addrs, ipAddressJSON{IPAddress: ipAddr, Kind: ipAddressKind(ipAddr)})
+	}
+
+	out := iostreams.FromContext(ctx).Out
+	return render.JSON(out, addrs)
+}
+
 func renderListTable(ctx context.Context, ipAddresses []fly.IPAddress) {
 	rows := make([][]string, 0, len(ipAddresses))
 
@@ -42,15 +77,35 @@ func renderListTable(ctx context.Context, ipAddresses []fly.IPAddress) {
 	render.Table(out, "", rows, "Version", "IP", "Type", "Region", "Created At")
 }
 
-func renderPrivateTableMachines(ctx context.Context, machines []*fly.Machine) {
+// renderPrivateTableMachines renders the private IPs of the machines. With
+// names, the addresses their DNS names resolved to by machine ID, it also
+// renders the DNS names, flagging the ones that didn't resolve to the
+// machine's IP.
+func renderPrivateTableMachines(ctx context.Context, appName string, machines []*fly.Machine, names map[string]string) {
 	rows := make([][]string, 0, len(machines))
 
 	for _, machine := range machines {
-		rows = append(rows, []string{machine.ID, machine.Region, machine.PrivateIP})
+		row := []string{machine.ID, machine.Region, machine.PrivateIP}
+		if names != nil {
+			name := privateDNSName(appName, machine)
+			switch addr, ok := names[machine.ID]; {
+			case !ok:
+				name += " (not resolved)"
+			case addr != machine.PrivateIP:
+				name += " (resolves to " + addr + ")"
+			}
+			row = append(row, name)
+		}
+		rows = append(rows, row)
+	}
+
+	cols := []string{"ID", "Region", "IP"}
+	if names != nil {
+		cols = append(cols, "DNS Name")
 	}
 
 	out := iostreams.FromContext(ctx).Out
-	render.Table(out, "", rows, "ID", "Region", "IP")
+	render.Table(out, "", rows, cols...)
 }
 
 func renderSharedTable(ctx context.Context, ip net.IP) {
@@ -0,0 +1,61 @@
+package ips
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestRenderPrivateTableMachines(t *testing.T) {
+	machines := []*fly.Machine{
+		{ID: "m1", Region: "ord", PrivateIP: "fdaa::1"},
+		{ID: "m2", Region: "ams", PrivateIP: "fdaa::2"},
+		{ID: "m3", Region: "ams", PrivateIP: "fdaa::3"},
+	}
+
+	ios, _, out, _ := iostreams.Test()
+	ctx := iostreams.NewContext(context.Background(), ios)
+	renderPrivateTableMachines(ctx, "my-app", machines, nil)
+	assert.NotContains(t, out.String(), "DNS NAME")
+	assert.NotContains(t, out.String(), ".internal")
+
+	out.Reset()
+	renderPrivateTableMachines(ctx, "my-app", machines, map[string]string{"m1": "fdaa::1", "m2": "fdaa::9"})
+	assert.Contains(t, out.String(), "DNS NAME")
+	assert.Contains(t, out.String(), "fdaa::1\tm1.vm.my-app.internal ")
+	assert.Contains(t, out.String(), "m2.vm.my-app.internal (resolves to fdaa::9)")
+	assert.Contains(t, out.String(), "m3.vm.my-app.internal (not resolved)")
+}
+
+func TestRenderListJSON(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	ipAddresses := []fly.IPAddress{
+		{ID: "ip1", Address: "1.2.3.4", Type: "v4", Region: "global", CreatedAt: createdAt},
+		{Address: "5.6.7.8", Type: "shared_v4", Region: "global"},
+		{ID: "ip3", Address: "2a09::1", Type: "v6", Region: "global", CreatedAt: createdAt},
+		{ID: "ip4", Address: "fdaa::1", Type: "private_v6", Region: "global", CreatedAt: createdAt},
+	}
+
+	ios, _, out, _ := iostreams.Test()
+	ctx := iostreams.NewContext(context.Background(), ios)
+	require.NoError(t, renderListJSON(ctx, ipAddresses))
+
+	var got []map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+	require.Len(t, got, len(ipAddresses))
+
+	for i, kind := range []string{"v4-dedicated", "v4-shared", "v6", "private"} {
+		assert.Equal(t, kind, got[i]["Kind"])
+		assert.Equal(t, ipAddresses[i].Address, got[i]["Address"])
+		assert.Equal(t, ipAddresses[i].Type, got[i]["Type"])
+		assert.Equal(t, "global", got[i]["Region"])
+		assert.Contains(t, got[i], "CreatedAt")
+	}
+	assert.Equal(t, "2024-05-01T12:00:00Z", got[0]["CreatedAt"])
+}
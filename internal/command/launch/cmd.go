@@ -28,6 +28,7 @@ import (
 	"github.com/superfly/flyctl/internal/state"
 	"github.com/superfly/flyctl/internal/tracing"
 	"github.com/superfly/flyctl/iostreams"
+	"github.com/superfly/flyctl/scanner"
 	"go.opentelemetry.io/otel/attribute"
 )
 
@@ -37,7 +38,7 @@ func New() (cmd *cobra.Command) {
 		short = `Create and configure a new app from source code or a Docker image`
 	)
 
-	cmd = command.New("launch", short, long, run, command.RequireSession, command.LoadAppConfigIfPresent)
+	cmd = command.New("launch", short, long, run, command.RequireSession, deploy.ValidateCacheFlags, command.LoadAppConfigIfPresent)
 	cmd.Args = cobra.NoArgs
 
 	flag.Add(cmd,
@@ -145,6 +146,18 @@ func New() (cmd *cobra.Command) {
 			Description: "Automatically suspend the app after a period of inactivity. Valid values are 'off', 'stop', and 'suspend",
 			Default:     "stop",
 		},
+		flag.String{
+			Name:        "scanner",
+			Description: "Detect the app's framework with the named scanner only, failing if it doesn't match. See --show-scanners for the names",
+		},
+		flag.Bool{
+			Name:        "no-scanner",
+			Description: "Don't detect the app's framework, and launch a blank app built from its Dockerfile if it has one",
+		},
+		flag.Bool{
+			Name:        "show-scanners",
+			Description: "List the scanners used to detect the app's framework, in the order they're tried, and exit",
+		},
 	)
 
 	cmd.AddCommand(NewPlan())
@@ -244,6 +257,13 @@ func setupFromTemplate(ctx context.Context) (context.Context, *appconfig.Config,
 func run(ctx context.Context) (err error) {
 	io := iostreams.FromContext(ctx)
 
+	if flag.GetBool(ctx, "show-scanners") {
+		for _, s := range scanner.Scanners() {
+			fmt.Fprintln(io.Out, s.Name())
+		}
+		return nil
+	}
+
 	tp, err := tracing.InitTraceProviderWithoutApp(ctx)
 	if err != nil {
 		fmt.Fprintf(io.ErrOut, "failed to initialize tracing library: =%v", err)
@@ -300,7 +320,7 @@ func run(ctx context.Context) (err error) {
 	}
 
 	incompleteLaunchManifest := false
-	canEnterUi := !flag.GetBool(ctx, "manifest") && io.IsInteractive() && !env.IsCI()
+	canEnterUi := !flag.GetBool(ctx, "manifest") && io.CanPrompt() && !env.IsCI()
 
 	recoverableErrors := recoverableErrorBuilder{canEnterUi: canEnterUi}
 
@@ -17,6 +17,7 @@ import (
 	"github.com/superfly/flyctl/internal/flapsutil"
 	"github.com/superfly/flyctl/internal/flyutil"
 	"github.com/superfly/flyctl/internal/tracing"
+	"github.com/superfly/flyctl/internal/urls"
 	"github.com/superfly/flyctl/iostreams"
 )
 
@@ -55,7 +56,7 @@ func (state *launchState) Launch(ctx context.Context) error {
 		}
 
 		fmt.Fprintf(io.Out, "Created app '%s' in organization '%s'\n", app.Name, app.Organization.Slug)
-		fmt.Fprintf(io.Out, "Admin URL: https://fly.io/apps/%s\n", app.Name)
+		fmt.Fprintf(io.Out, "Admin URL: %s\n", urls.App(app.Name))
 		fmt.Fprintf(io.Out, "Hostname: %s.fly.dev\n", app.Name)
 
 		if planStep == "create" {
@@ -434,11 +434,8 @@ func determineBaseAppConfig(ctx context.Context) (*appconfig.Config, bool, error
 		if !flag.IsSpecified(ctx, "copy-config") && !attach && !flag.GetYes(ctx) {
 			var err error
 			copyConfig, err = prompt.Confirm(ctx, "Would you like to copy its configuration to the new app?")
-			switch {
-			case prompt.IsNonInteractive(err) && !flag.GetYes(ctx):
-				return nil, false, err
-			case err != nil:
-				return nil, false, err
+			if err != nil {
+				return nil, false, prompt.Require(err, "--copy-config flag")
 			}
 		}
 
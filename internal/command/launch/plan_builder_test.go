@@ -0,0 +1,62 @@
+package launch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestDetermineBaseAppConfigClosedStdin(t *testing.T) {
+	ios, _, _, err := iostreams.TestClosedStdin()
+	require.NoError(t, err)
+	defer ios.In.Close()
+
+	existing := appconfig.NewConfig()
+	existing.AppName = "existing-app"
+
+	newCtx := func(args ...string) context.Context {
+		fs := pflag.NewFlagSet("launch", pflag.ContinueOnError)
+		fs.Bool("copy-config", false, "")
+		fs.Bool("attach", false, "")
+		fs.Bool("yes", false, "")
+		require.NoError(t, fs.Parse(args))
+
+		ctx := iostreams.NewContext(context.Background(), ios)
+		ctx = appconfig.WithConfig(ctx, existing)
+		return flag.NewContext(ctx, fs)
+	}
+
+	// Asking whether to copy the existing fly.toml must fail naming the flag
+	// to pass, not wait for an answer that can never come.
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := determineBaseAppConfig(newCtx())
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		assert.True(t, prompt.IsNonInteractive(err))
+		assert.EqualError(t, err, "--copy-config flag must be specified when not running interactively")
+	case <-time.After(5 * time.Second):
+		t.Fatal("launch waited for input on a closed stdin")
+	}
+
+	cfg, copied, err := determineBaseAppConfig(newCtx("--copy-config"))
+	require.NoError(t, err)
+	assert.True(t, copied)
+	assert.Same(t, existing, cfg)
+
+	cfg, copied, err = determineBaseAppConfig(newCtx("--yes"))
+	require.NoError(t, err)
+	assert.False(t, copied)
+	assert.NotSame(t, existing, cfg)
+}
@@ -33,6 +33,15 @@ func determineSourceInfo(ctx context.Context, appConfig *appconfig.Config, copyC
 		scannerConfig.Mode = "clone"
 	}
 
+	scannerName := flag.GetString(ctx, "scanner")
+	noScanner := flag.GetBool(ctx, "no-scanner")
+	switch {
+	case scannerName != "" && noScanner:
+		return nil, nil, fmt.Errorf("--scanner and --no-scanner can't be used together")
+	case (scannerName != "" || noScanner) && (flag.GetString(ctx, "image") != "" || flag.GetString(ctx, "dockerfile") != ""):
+		return nil, nil, fmt.Errorf("--scanner and --no-scanner can't be used with --image or --dockerfile, which skip scanning the source code")
+	}
+
 	if img := flag.GetString(ctx, "image"); img != "" {
 		fmt.Fprintln(io.Out, "Using image", img)
 		build.Image = img
@@ -58,18 +67,29 @@ func determineSourceInfo(ctx context.Context, appConfig *appconfig.Config, copyC
 		return srcInfo, build, nil
 	}
 
-	if strategies := appConfig.BuildStrategies(); len(strategies) > 0 {
+	if strategies := appConfig.BuildStrategies(); len(strategies) > 0 && scannerName == "" {
 		fmt.Fprintf(io.Out, "Using build strategies '%s'. Remove [build] from fly.toml to force a rescan\n", aurora.Yellow(strategies))
 		return srcInfo, appConfig.Build, nil
 	}
 
+	if noScanner {
+		fmt.Fprintln(io.Out, "Skipping source code scanning. Continuing with a blank app, built from its Dockerfile if it has one.")
+		return nil, nil, nil
+	}
+
 	planStep := plan.GetPlanStep(ctx)
 
-	if planStep == "" || planStep == "generate" {
-		fmt.Fprintln(io.Out, "Scanning source code")
+	if scannerName != "" {
+		if planStep == "" || planStep == "generate" {
+			fmt.Fprintf(io.Out, "Scanning source code with the %s scanner\n", scannerName)
+		}
+		srcInfo, err = scanner.ScanWith(scannerName, workingDir, scannerConfig)
+	} else {
+		if planStep == "" || planStep == "generate" {
+			fmt.Fprintln(io.Out, "Scanning source code")
+		}
+		srcInfo, err = scanner.Scan(workingDir, scannerConfig)
 	}
-
-	srcInfo, err = scanner.Scan(workingDir, scannerConfig)
 	if err != nil {
 		return nil, nil, err
 	}
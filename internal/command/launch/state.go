@@ -181,7 +181,7 @@ func (state *launchState) validateExtensions(ctx context.Context) error {
 	// When that happens we can just errors.Join(a(), b(), c()...)
 
 	io := iostreams.FromContext(ctx)
-	noConfirm := !io.IsInteractive() || flag.GetBool(ctx, "now")
+	noConfirm := !io.CanPrompt() || flag.GetBool(ctx, "now")
 
 	org, err := state.Org(ctx)
 	if err != nil {
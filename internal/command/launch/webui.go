@@ -5,18 +5,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/azazeal/pause"
-	"github.com/briandowns/spinner"
 	"github.com/skratchdot/open-golang/open"
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/flyctl/helpers"
 	"github.com/superfly/flyctl/internal/command/launch/plan"
 	"github.com/superfly/flyctl/internal/logger"
+	"github.com/superfly/flyctl/internal/spinner"
 	state2 "github.com/superfly/flyctl/internal/state"
 	"github.com/superfly/flyctl/internal/tracing"
 	"github.com/superfly/flyctl/iostreams"
@@ -49,7 +48,7 @@ func (state *launchState) EditInWebUi(ctx context.Context) error {
 
 	logger := logger.FromContext(ctx)
 
-	finalSession, err := waitForCLISession(ctx, logger, io.ErrOut, session.ID)
+	finalSession, err := waitForCLISession(ctx, logger, io, session.ID)
 	switch {
 	case errors.Is(err, context.DeadlineExceeded):
 		return errors.New("session expired, please try again")
@@ -126,14 +125,12 @@ outer:
 }
 
 // TODO: this does NOT break on interrupts
-func waitForCLISession(parent context.Context, logger *logger.Logger, w io.Writer, id string) (session fly.CLISession, err error) {
+func waitForCLISession(parent context.Context, logger *logger.Logger, streams *iostreams.IOStreams, id string) (session fly.CLISession, err error) {
 	ctx, cancel := context.WithTimeout(parent, 15*time.Minute)
 	defer cancel()
 
-	s := spinner.New(spinner.CharSets[11], 100*time.Millisecond)
-	s.Writer = w
-	s.Prefix = "Waiting for launch data..."
-	s.Start()
+	s := spinner.Run(streams, "Waiting for launch data...")
+	defer s.Stop()
 
 	for ctx.Err() == nil {
 		if session, err = fly.GetCLISessionState(ctx, id); err != nil {
@@ -146,8 +143,7 @@ func waitForCLISession(parent context.Context, logger *logger.Logger, w io.Write
 
 		logger.Debug("retrieved launch data.")
 
-		s.FinalMSG = "Waiting for launch data... Done\n"
-		s.Stop()
+		s.StopWithMessage("Waiting for launch data... Done")
 
 		break
 	}
@@ -4,7 +4,9 @@ package logs
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"slices"
 	"time"
 
 	"github.com/azazeal/pause"
@@ -33,6 +35,11 @@ to all instances running in a specific region using the --region/-r flag.
 
 By default logs are continually streamed until the command is aborted.
 Use --no-tail to only fetch the logs in the buffer.
+
+Logs can be limited to a time window with --since and --until, which take
+a duration back from now like 10m or 2h, or an RFC3339 timestamp like
+2024-05-01T10:00:00Z. Only the logs still in the buffer can be shown.
+--until implies --no-tail.
 `
 		short = "View app logs"
 	)
@@ -60,6 +67,18 @@ Use --no-tail to only fetch the logs in the buffer.
 			Shorthand:   "n",
 			Description: "Do not continually stream logs",
 		},
+		flag.String{
+			Name:        "log-level",
+			Description: "Only show entries at this level or above: trace, debug, info, notice, warn, error or fatal. Entries with other levels are always shown",
+		},
+		flag.String{
+			Name:        "since",
+			Description: "Only show entries from this time on, as a duration back from now like 10m, or an RFC3339 timestamp",
+		},
+		flag.String{
+			Name:        "until",
+			Description: "Only show entries up to this time, as a duration back from now like 10m, or an RFC3339 timestamp. Implies --no-tail",
+		},
 	)
 	return
 }
@@ -67,11 +86,22 @@ Use --no-tail to only fetch the logs in the buffer.
 func run(ctx context.Context) error {
 	client := flyutil.ClientFromContext(ctx)
 
+	if level := flag.GetString(ctx, "log-level"); level != "" {
+		if err := render.ValidateLogLevel(level); err != nil {
+			return err
+		}
+	}
+
+	since, until, err := timeRange(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
 	opts := &logs.LogOptions{
 		AppName:    appconfig.NameFromContext(ctx),
 		RegionCode: config.FromContext(ctx).Region,
 		VMID:       flag.GetString(ctx, "machine"),
-		NoTail:     flag.GetBool(ctx, "no-tail"),
+		NoTail:     flag.GetBool(ctx, "no-tail") || !until.IsZero(),
 	}
 
 	var eg *errgroup.Group
@@ -91,12 +121,43 @@ func run(ctx context.Context) error {
 	}
 
 	eg.Go(func() error {
-		return printStreams(ctx, streams...)
+		return printStreams(ctx, since, until, streams...)
 	})
 
 	return eg.Wait()
 }
 
+// timeRange returns the times of --since and --until, relative to now when
+// they're durations. The times of the flags that aren't set are zero.
+func timeRange(ctx context.Context, now time.Time) (since, until time.Time, err error) {
+	if since, err = parseTimeFlag(ctx, "since", now); err != nil {
+		return
+	}
+	if until, err = parseTimeFlag(ctx, "until", now); err != nil {
+		return
+	}
+	if !since.IsZero() && !until.IsZero() && until.Before(since) {
+		err = fmt.Errorf("--until (%s) is before --since (%s)", until.Format(time.RFC3339), since.Format(time.RFC3339))
+	}
+	return
+}
+
+// parseTimeFlag parses the value of a time flag: a duration back from now,
+// or an RFC3339 timestamp.
+func parseTimeFlag(ctx context.Context, name string, now time.Time) (time.Time, error) {
+	v := flag.GetString(ctx, name)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --%s value %q: use a duration back from now like 10m or 1h30m, or an RFC3339 timestamp like 2024-05-01T10:00:00Z", name, v)
+}
+
 func poll(ctx context.Context, eg *errgroup.Group, client flyutil.Client, opts *logs.LogOptions) <-chan logs.LogEntry {
 	c := make(chan logs.LogEntry)
 
@@ -146,24 +207,44 @@ func nats(ctx context.Context, eg *errgroup.Group, client flyutil.Client, opts *
 	return c
 }
 
-func printStreams(ctx context.Context, streams ...<-chan logs.LogEntry) error {
+func printStreams(ctx context.Context, since, until time.Time, streams ...<-chan logs.LogEntry) error {
 	var eg *errgroup.Group
 	eg, ctx = errgroup.WithContext(ctx)
 
 	out := iostreams.FromContext(ctx).Out
 	json := config.FromContext(ctx).JSONOutput
 
+	var opts []render.LogOption
+	if level := flag.GetString(ctx, "log-level"); level != "" {
+		opts = append(opts, render.MinLogLevel(level))
+	}
+	if !since.IsZero() || !until.IsZero() {
+		opts = append(opts, render.LogTimeRange(since, until))
+	}
+
 	for _, stream := range streams {
 		stream := stream
 
 		eg.Go(func() error {
-			return printStream(ctx, out, stream, json)
+			return printStream(ctx, out, stream, json, opts...)
 		})
 	}
 	return eg.Wait()
 }
 
-func printStream(ctx context.Context, w io.Writer, stream <-chan logs.LogEntry, json bool) error {
+func printStream(ctx context.Context, w io.Writer, stream <-chan logs.LogEntry, json bool, opts ...render.LogOption) error {
+	// opts is shared between streams; copy it before adding to it.
+	opts = slices.Clone(opts)
+	if json {
+		opts = append(opts, render.JSONLines())
+	} else {
+		opts = append(opts,
+			render.HideAllocID(),
+			render.RemoveNewlines(),
+			render.HideRegion(),
+		)
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -173,18 +254,7 @@ func printStream(ctx context.Context, w io.Writer, stream <-chan logs.LogEntry,
 				return nil
 			}
 
-			var err error
-			if json {
-				err = render.JSON(w, entry)
-			} else {
-				err = render.LogEntry(w, entry,
-					render.HideAllocID(),
-					render.RemoveNewlines(),
-					render.HideRegion(),
-				)
-			}
-
-			if err != nil {
+			if err := render.LogEntry(w, entry, opts...); err != nil {
 				return err
 			}
 		}
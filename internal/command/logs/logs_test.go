@@ -0,0 +1,39 @@
+package logs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/internal/flag"
+)
+
+func TestTimeRange(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	newCtx := func(args ...string) context.Context {
+		flags := pflag.NewFlagSet("logs", pflag.ContinueOnError)
+		flags.String("since", "", "")
+		flags.String("until", "", "")
+		require.NoError(t, flags.Parse(args))
+		return flag.NewContext(context.Background(), flags)
+	}
+
+	since, until, err := timeRange(newCtx(), now)
+	require.NoError(t, err)
+	assert.True(t, since.IsZero())
+	assert.True(t, until.IsZero())
+
+	since, until, err = timeRange(newCtx("--since", "90m", "--until", "2024-05-01T13:00:00+02:00"), now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-90*time.Minute), since)
+	assert.True(t, until.Equal(time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC)), until)
+
+	_, _, err = timeRange(newCtx("--since", "yesterday"), now)
+	assert.ErrorContains(t, err, `invalid --since value "yesterday": use a duration back from now like 10m`)
+
+	_, _, err = timeRange(newCtx("--since", "10m", "--until", "1h"), now)
+	assert.ErrorContains(t, err, "--until (2024-05-01T11:00:00Z) is before --since (2024-05-01T11:50:00Z)")
+}
@@ -0,0 +1,337 @@
+package machine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/sftp"
+	"github.com/spf13/cobra"
+
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/command/ssh"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newCp() *cobra.Command {
+	const (
+		short = "Copy files between the local machine and a Machine"
+		long  = short + `. One of the source and destination must be a path on a
+Machine, written as <machine_id>:<path>; leave out the ID (:<path>) to select
+the Machine interactively. Directories are copied recursively.
+
+The files are transferred with SFTP over the app's private network, so the
+Machine must be started and run an SSH server, like 'fly ssh console' needs.`
+		usage = "cp <source> <destination>"
+	)
+
+	cmd := command.New(usage, short, long, runCp,
+		command.RequireSession,
+		command.LoadAppNameIfPresent,
+	)
+
+	flag.Add(
+		cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.String{
+			Name:        "user",
+			Shorthand:   "u",
+			Description: "Unix username to connect as",
+			Default:     ssh.DefaultSshUsername,
+		},
+		flag.Bool{
+			Name:        "quiet",
+			Shorthand:   "q",
+			Description: "Don't print progress indicators",
+		},
+	)
+
+	cmd.Args = cobra.ExactArgs(2)
+
+	return cmd
+}
+
+// copyTarget is one side of a copy: a local path, or a path on a machine.
+type copyTarget struct {
+	remote    bool
+	machineID string
+	path      string
+}
+
+// parseCopyTarget parses a cp argument. <machine_id>:<path> and :<path> are
+// paths on a machine; anything else, including Windows drive letters like
+// C:\dir, is a local path.
+func parseCopyTarget(arg string) copyTarget {
+	id, p, found := strings.Cut(arg, ":")
+	if !found || len(id) == 1 || strings.ContainsAny(id, `/\.`) {
+		return copyTarget{path: arg}
+	}
+	return copyTarget{remote: true, machineID: id, path: p}
+}
+
+func runCp(ctx context.Context) error {
+	var (
+		args = flag.Args(ctx)
+		io   = iostreams.FromContext(ctx)
+	)
+
+	src, dst := parseCopyTarget(args[0]), parseCopyTarget(args[1])
+	switch {
+	case src.remote == dst.remote:
+		return errors.New("exactly one of the source and destination must be a path on a Machine, as <machine_id>:<path>")
+	case src.remote && src.path == "", dst.remote && dst.path == "":
+		return errors.New("the path on the Machine can't be empty")
+	}
+
+	remote := dst
+	if src.remote {
+		remote = src
+	} else if _, err := os.Stat(src.path); err != nil {
+		return fmt.Errorf("source %s: %w", src.path, err)
+	}
+
+	machine, ctx, err := selectOneMachine(ctx, "", remote.machineID, remote.machineID != "")
+	if err != nil {
+		return err
+	}
+	if machine.State != "started" {
+		return fmt.Errorf("machine %s is %s; files can only be copied to or from a started machine", machine.ID, machine.State)
+	}
+
+	client := flyutil.ClientFromContext(ctx)
+	appName := appconfig.NameFromContext(ctx)
+
+	app, err := client.GetAppCompact(ctx, appName)
+	if err != nil {
+		return fmt.Errorf("get app: %w", err)
+	}
+	network, err := client.GetAppNetwork(ctx, appName)
+	if err != nil {
+		return fmt.Errorf("get app network: %w", err)
+	}
+
+	quiet := flag.GetBool(ctx, "quiet")
+	_, dialer, err := ssh.BringUpAgent(ctx, client, app, *network, quiet)
+	if err != nil {
+		return err
+	}
+
+	conn, err := ssh.Connect(&ssh.ConnectParams{
+		Ctx:            ctx,
+		Org:            app.Organization,
+		Dialer:         dialer,
+		Username:       flag.GetString(ctx, "user"),
+		DisableSpinner: quiet,
+		AppNames:       []string{app.Name},
+	}, machine.PrivateIP)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ftp, err := sftp.NewClient(conn.Client, sftp.UseConcurrentReads(true), sftp.UseConcurrentWrites(true))
+	if err != nil {
+		return fmt.Errorf("start sftp session: %w", err)
+	}
+	defer ftp.Close()
+
+	c := &copier{ftp: ftp}
+	if !quiet && io.IsStderrTTY() {
+		c.progress = io.ErrOut
+	}
+
+	if src.remote {
+		err = c.download(src.path, dst.path)
+	} else {
+		err = c.upload(src.path, dst.path)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(io.Out, "Copied %d file(s), %s\n", c.files, humanize.Bytes(uint64(c.bytes)))
+	return nil
+}
+
+// copier copies files and directories over an SFTP session.
+type copier struct {
+	ftp *sftp.Client
+
+	// progress, when set, receives progress updates for large files.
+	progress io.Writer
+
+	files int
+	bytes int64
+}
+
+// upload copies the local file or directory src to dst on the machine. When
+// dst is an existing directory, src is copied into it.
+func (c *copier) upload(src, dst string) error {
+	if fi, err := c.ftp.Stat(dst); err == nil && fi.IsDir() {
+		dst = path.Join(dst, filepath.Base(src))
+	}
+
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := path.Join(dst, filepath.ToSlash(rel))
+
+		if d.IsDir() {
+			if err := c.ftp.MkdirAll(target); err != nil {
+				return fmt.Errorf("create remote directory %s: %w", target, err)
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		return c.uploadFile(p, target)
+	})
+}
+
+func (c *copier) uploadFile(src, dst string) error {
+	lf, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer lf.Close()
+
+	fi, err := lf.Stat()
+	if err != nil {
+		return err
+	}
+
+	rf, err := c.ftp.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	if err != nil {
+		return fmt.Errorf("create remote file %s: %w", dst, err)
+	}
+	defer rf.Close()
+
+	n, err := io.Copy(rf, c.track(lf, dst, fi.Size()))
+	if err != nil {
+		return fmt.Errorf("copy %s to %s: %w", src, dst, err)
+	}
+	if err := rf.Chmod(fi.Mode().Perm()); err != nil {
+		return fmt.Errorf("set mode of remote file %s: %w", dst, err)
+	}
+
+	c.files++
+	c.bytes += n
+	return nil
+}
+
+// download copies the file or directory src on the machine to the local dst.
+// When dst is an existing directory, src is copied into it.
+func (c *copier) download(src, dst string) error {
+	src = path.Clean(src)
+	if _, err := c.ftp.Stat(src); err != nil {
+		return fmt.Errorf("source %s: %w", src, err)
+	}
+	if fi, err := os.Stat(dst); err == nil && fi.IsDir() {
+		dst = filepath.Join(dst, path.Base(src))
+	}
+
+	walker := c.ftp.Walk(src)
+	for walker.Step() {
+		if err := walker.Err(); err != nil {
+			return err
+		}
+
+		rel := strings.TrimPrefix(strings.TrimPrefix(walker.Path(), src), "/")
+		target := filepath.Join(dst, filepath.FromSlash(rel))
+
+		fi := walker.Stat()
+		switch {
+		case fi.IsDir():
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case fi.Mode().IsRegular():
+			if err := c.downloadFile(walker.Path(), target, fi); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *copier) downloadFile(src, dst string, fi fs.FileInfo) error {
+	rf, err := c.ftp.Open(src)
+	if err != nil {
+		return fmt.Errorf("open remote file %s: %w", src, err)
+	}
+	defer rf.Close()
+
+	lf, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fi.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	defer lf.Close()
+
+	n, err := io.Copy(lf, c.track(rf, src, fi.Size()))
+	if err != nil {
+		return fmt.Errorf("copy %s to %s: %w", src, dst, err)
+	}
+
+	c.files++
+	c.bytes += n
+	return lf.Close()
+}
+
+// progressThreshold is the size from which file transfers report progress.
+const progressThreshold = 1 << 20
+
+func (c *copier) track(r io.Reader, name string, size int64) io.Reader {
+	if c.progress == nil || size < progressThreshold {
+		return r
+	}
+	return &progressReader{r: r, out: c.progress, name: name, total: size}
+}
+
+// progressReader reports how much of a file has been read, at most a few
+// times per second.
+type progressReader struct {
+	r     io.Reader
+	out   io.Writer
+	name  string
+	total int64
+	done  int64
+	last  time.Time
+
+	finished bool
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if p.finished {
+		return n, err
+	}
+	p.done += int64(n)
+
+	p.finished = err != nil || p.done >= p.total
+	if p.finished || time.Since(p.last) > 200*time.Millisecond {
+		p.last = time.Now()
+		fmt.Fprintf(p.out, "\r%s: %s / %s (%d%%)", p.name, humanize.Bytes(uint64(p.done)), humanize.Bytes(uint64(p.total)), p.done*100/p.total)
+		if p.finished {
+			fmt.Fprintln(p.out)
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,87 @@
+package machine
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCopyTarget(t *testing.T) {
+	cases := map[string]copyTarget{
+		"e2865013b23768:/data/app.log": {remote: true, machineID: "e2865013b23768", path: "/data/app.log"},
+		":/etc/app.conf":               {remote: true, path: "/etc/app.conf"},
+		"./local.txt":                  {path: "./local.txt"},
+		"dir/file:with-colon":          {path: "dir/file:with-colon"},
+		`C:\Users\me\file.txt`:         {path: `C:\Users\me\file.txt`},
+		"file.txt":                     {path: "file.txt"},
+	}
+	for arg, want := range cases {
+		assert.Equal(t, want, parseCopyTarget(arg), arg)
+	}
+}
+
+// newTestSFTPClient returns a client talking to an in-memory SFTP server.
+func newTestSFTPClient(t *testing.T) *sftp.Client {
+	t.Helper()
+
+	serverConn, clientConn := net.Pipe()
+	server := sftp.NewRequestServer(serverConn, sftp.InMemHandler())
+	go server.Serve()
+	t.Cleanup(func() { server.Close() })
+
+	client, err := sftp.NewClientPipe(clientConn, clientConn)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestCopierRoundTrip(t *testing.T) {
+	ftp := newTestSFTPClient(t)
+
+	src := filepath.Join(t.TempDir(), "conf")
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "nested"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "app.toml"), []byte("port = 8080\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "nested", "big.bin"), bytes.Repeat([]byte{1}, progressThreshold+1), 0o644))
+
+	var progress bytes.Buffer
+	up := &copier{ftp: ftp, progress: &progress}
+	require.NoError(t, ftp.MkdirAll("/etc"))
+	require.NoError(t, up.upload(src, "/etc"))
+	assert.Equal(t, 2, up.files)
+	assert.Contains(t, progress.String(), "/etc/conf/nested/big.bin")
+
+	rf, err := ftp.Open("/etc/conf/app.toml")
+	require.NoError(t, err)
+	buf, err := io.ReadAll(rf)
+	require.NoError(t, err)
+	assert.Equal(t, "port = 8080\n", string(buf))
+
+	dst := t.TempDir()
+	down := &copier{ftp: ftp}
+	require.NoError(t, down.download("/etc/conf", dst))
+	assert.Equal(t, 2, down.files)
+
+	buf, err = os.ReadFile(filepath.Join(dst, "conf", "app.toml"))
+	require.NoError(t, err)
+	assert.Equal(t, "port = 8080\n", string(buf))
+
+	fi, err := os.Stat(filepath.Join(dst, "conf", "nested", "big.bin"))
+	require.NoError(t, err)
+	assert.EqualValues(t, progressThreshold+1, fi.Size())
+
+	// A single file is written to the destination path itself.
+	single := filepath.Join(t.TempDir(), "app.toml")
+	require.NoError(t, (&copier{ftp: ftp}).download("/etc/conf/app.toml", single))
+	buf, err = os.ReadFile(single)
+	require.NoError(t, err)
+	assert.Equal(t, "port = 8080\n", string(buf))
+
+	assert.ErrorContains(t, (&copier{ftp: ftp}).download("/missing", dst), "source /missing")
+}
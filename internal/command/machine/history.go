@@ -0,0 +1,101 @@
+package machine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/state"
+)
+
+// maxSavedMachineConfigs is how many previous configs are kept per machine.
+const maxSavedMachineConfigs = 5
+
+// savedMachineConfig is a machine config as it was before an update.
+type savedMachineConfig struct {
+	SavedAt    time.Time          `json:"saved_at"`
+	InstanceID string             `json:"instance_id"`
+	Config     *fly.MachineConfig `json:"config"`
+}
+
+// machineHistoryPath returns the file holding the saved configs of a
+// machine, under the flyctl config directory.
+func machineHistoryPath(ctx context.Context, appName, machineID string) string {
+	return filepath.Join(state.ConfigDirectory(ctx), "machine-history", appName, machineID+".json")
+}
+
+// loadMachineHistory returns the saved configs of a machine, oldest first.
+func loadMachineHistory(ctx context.Context, appName, machineID string) ([]savedMachineConfig, error) {
+	buf, err := os.ReadFile(machineHistoryPath(ctx, appName, machineID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var history []savedMachineConfig
+	if err := json.Unmarshal(buf, &history); err != nil {
+		return nil, fmt.Errorf("invalid machine config history: %w", err)
+	}
+	return history, nil
+}
+
+func storeMachineHistory(ctx context.Context, appName, machineID string, history []savedMachineConfig) error {
+	path := machineHistoryPath(ctx, appName, machineID)
+	if len(history) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	buf, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf, 0o600)
+}
+
+// pushMachineConfig saves config as the latest previous config of a machine,
+// dropping the oldest ones beyond maxSavedMachineConfigs.
+func pushMachineConfig(ctx context.Context, appName, machineID string, saved savedMachineConfig) error {
+	history, err := loadMachineHistory(ctx, appName, machineID)
+	if err != nil {
+		return err
+	}
+
+	history = append(history, saved)
+	if n := len(history) - maxSavedMachineConfigs; n > 0 {
+		history = history[n:]
+	}
+	return storeMachineHistory(ctx, appName, machineID, history)
+}
+
+// lastMachineConfig returns the most recently saved config of a machine, or
+// nil when there is none.
+func lastMachineConfig(ctx context.Context, appName, machineID string) (*savedMachineConfig, error) {
+	history, err := loadMachineHistory(ctx, appName, machineID)
+	if err != nil || len(history) == 0 {
+		return nil, err
+	}
+	return &history[len(history)-1], nil
+}
+
+// dropLastMachineConfig removes the most recently saved config of a machine,
+// once it has been rolled back to.
+func dropLastMachineConfig(ctx context.Context, appName, machineID string) error {
+	history, err := loadMachineHistory(ctx, appName, machineID)
+	if err != nil || len(history) == 0 {
+		return err
+	}
+	return storeMachineHistory(ctx, appName, machineID, history[:len(history)-1])
+}
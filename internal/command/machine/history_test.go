@@ -0,0 +1,50 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/state"
+)
+
+func TestMachineHistory(t *testing.T) {
+	ctx := state.WithConfigDirectory(context.Background(), t.TempDir())
+
+	saved, err := lastMachineConfig(ctx, "app", "m1")
+	require.NoError(t, err)
+	assert.Nil(t, saved)
+
+	for i := 0; i < maxSavedMachineConfigs+2; i++ {
+		err := pushMachineConfig(ctx, "app", "m1", savedMachineConfig{
+			SavedAt:    time.Now(),
+			InstanceID: fmt.Sprintf("v%d", i),
+			Config:     &fly.MachineConfig{Image: fmt.Sprintf("image:%d", i)},
+		})
+		require.NoError(t, err)
+	}
+
+	history, err := loadMachineHistory(ctx, "app", "m1")
+	require.NoError(t, err)
+	require.Len(t, history, maxSavedMachineConfigs)
+	assert.Equal(t, "v2", history[0].InstanceID)
+
+	saved, err = lastMachineConfig(ctx, "app", "m1")
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.Equal(t, "image:6", saved.Config.Image)
+
+	require.NoError(t, dropLastMachineConfig(ctx, "app", "m1"))
+	saved, err = lastMachineConfig(ctx, "app", "m1")
+	require.NoError(t, err)
+	assert.Equal(t, "image:5", saved.Config.Image)
+
+	// Other machines have their own history.
+	saved, err = lastMachineConfig(ctx, "app", "m2")
+	require.NoError(t, err)
+	assert.Nil(t, saved)
+}
@@ -3,11 +3,14 @@ package machine
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/prompt"
 	"github.com/superfly/flyctl/iostreams"
 )
 
@@ -31,6 +34,13 @@ func newKill() *cobra.Command {
 		flag.App(),
 		flag.AppConfig(),
 		selectFlag,
+		flag.Yes(),
+		flag.Duration{
+			Name:        "wait-timeout",
+			Shorthand:   "w",
+			Description: "Time duration to wait for the machine to stop after the kill signal was sent.",
+			Default:     30 * time.Second,
+		},
 	)
 
 	return cmd
@@ -50,7 +60,23 @@ func runMachineKill(ctx context.Context) (err error) {
 	if current.State == "destroyed" {
 		return fmt.Errorf("machine %s has already been destroyed", current.ID)
 	}
-	fmt.Fprintf(io.Out, "machine %s was found and is currently in a %s state, attempting to kill...\n", current.ID, current.State)
+	fmt.Fprintf(io.Out, "machine %s was found and is currently in a %s state\n", current.ID, current.State)
+
+	if !flag.GetYes(ctx) {
+		fmt.Fprintln(io.ErrOut, io.ColorScheme().Yellow("Killing a machine skips graceful shutdown, any in-flight work will be lost."))
+
+		confirmed, err := prompt.Confirmf(ctx, "Kill machine %s?", current.ID)
+		switch {
+		case prompt.IsNonInteractive(err):
+			return prompt.NonInteractiveError("yes flag must be specified when not running interactively")
+		case err != nil:
+			return err
+		case !confirmed:
+			return nil
+		}
+	}
+
+	fmt.Fprintf(io.Out, "attempting to kill machine %s...\n", current.ID)
 
 	err = flapsClient.Kill(ctx, current.ID)
 	if err != nil {
@@ -62,5 +88,14 @@ func runMachineKill(ctx context.Context) (err error) {
 
 	fmt.Fprintln(io.Out, "kill signal has been sent")
 
+	// The machine's state only changes once the kill takes effect, so wait
+	// for it rather than report the state it was in when the signal was sent.
+	if waitTimeout := flag.GetDuration(ctx, "wait-timeout"); waitTimeout != 0 {
+		if err := flapsClient.Wait(ctx, current, fly.MachineStateStopped, waitTimeout); err != nil {
+			return fmt.Errorf("machine %s did not stop within the wait timeout: %w", current.ID, err)
+		}
+		fmt.Fprintf(io.Out, "machine %s is now stopped\n", current.ID)
+	}
+
 	return nil
 }
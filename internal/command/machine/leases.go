@@ -12,6 +12,7 @@ import (
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/flapsutil"
+	mach "github.com/superfly/flyctl/internal/machine"
 	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/iostreams"
 )
@@ -32,6 +33,8 @@ func newLeases() *cobra.Command {
 	cmd.AddCommand(
 		newLeaseView(),
 		newLeaseClear(),
+		newLeaseAcquire(),
+		newLeaseRelease(),
 	)
 
 	return cmd
@@ -86,6 +89,178 @@ func newLeaseClear() *cobra.Command {
 	return cmd
 }
 
+// defaultLeaseHold is how long 'leases acquire' holds leases by default.
+const defaultLeaseHold = 30 * time.Minute
+
+func newLeaseAcquire() *cobra.Command {
+	const (
+		short = "Acquire machine leases"
+		long  = `Acquire a lease on machines, so that flyctl and other tooling respecting
+leases can't update, stop or restart them until the lease is released or
+expires. Use it to hold machines during manual maintenance. The lease nonce
+is needed to release the lease early with 'leases release'.
+`
+		usage = "acquire [machine-id]"
+	)
+
+	cmd := command.New(usage, short, long, runLeaseAcquire,
+		command.RequireSession,
+		command.LoadAppNameIfPresent,
+	)
+
+	cmd.Args = cobra.ArbitraryArgs
+
+	flag.Add(
+		cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.JSONOutput(),
+		selectFlag,
+		flag.String{
+			Name:        "ttl",
+			Description: "How long to hold the lease for, like 30m or 2h",
+			Default:     defaultLeaseHold.String(),
+		},
+	)
+
+	return cmd
+}
+
+func newLeaseRelease() *cobra.Command {
+	const (
+		short = "Release a machine lease"
+		long  = `Release a lease acquired with 'leases acquire', given its nonce. Use
+'leases clear' to clear a lease without knowing its nonce.
+`
+		usage = "release [machine-id]"
+	)
+
+	cmd := command.New(usage, short, long, runLeaseRelease,
+		command.RequireSession,
+		command.LoadAppNameIfPresent,
+	)
+
+	cmd.Args = cobra.MaximumNArgs(1)
+
+	flag.Add(
+		cmd,
+		flag.App(),
+		flag.AppConfig(),
+		selectFlag,
+		flag.String{
+			Name:        "nonce",
+			Description: "Nonce of the lease to release",
+		},
+	)
+
+	return cmd
+}
+
+func runLeaseAcquire(ctx context.Context) error {
+	var (
+		io   = iostreams.FromContext(ctx)
+		args = flag.Args(ctx)
+		cfg  = config.FromContext(ctx)
+	)
+
+	ttl, err := mach.ParseLeaseTTL(flag.GetString(ctx, "ttl"), "ttl")
+	if err != nil {
+		return err
+	}
+
+	machines, ctx, err := selectManyMachines(ctx, args)
+	if err != nil {
+		return err
+	}
+	if len(machines) == 0 {
+		return fmt.Errorf("no machines selected, pass machine IDs or use --select")
+	}
+	flapsClient := flapsutil.ClientFromContext(ctx)
+
+	leases, err := acquireMachineLeases(ctx, flapsClient, machines, ttl)
+	if err != nil {
+		return err
+	}
+
+	rows := [][]string{}
+	for _, machine := range machines {
+		lease := leases[machine.ID]
+		rows = append(rows, []string{
+			machine.ID,
+			lease.Data.Nonce,
+			time.Unix(lease.Data.ExpiresAt, 0).Format(time.RFC3339),
+		})
+	}
+
+	if cfg.JSONOutput {
+		return render.JSON(io.Out, leases)
+	}
+
+	_ = render.Table(io.Out, "", rows, "Machine", "Nonce", "Expires")
+	fmt.Fprintln(io.Out, "Release the leases with 'fly machine leases release <machine-id> --nonce <nonce>'")
+
+	return nil
+}
+
+// acquireMachineLeases acquires a lease on each machine. If one fails, the
+// leases already acquired are released, and the nonces of those that couldn't
+// be are part of the error so they can be released later.
+func acquireMachineLeases(ctx context.Context, flapsClient flapsutil.FlapsClient, machines []*fly.Machine, ttl time.Duration) (map[string]*fly.MachineLease, error) {
+	leases := make(map[string]*fly.MachineLease)
+	for _, machine := range machines {
+		lease, err := flapsClient.AcquireLease(ctx, machine.ID, fly.IntPointer(int(ttl.Seconds())))
+		if err == nil {
+			leases[machine.ID] = lease
+			continue
+		}
+
+		err = fmt.Errorf("failed to acquire lease on machine %s: %w", machine.ID, err)
+		var held []string
+		for _, m := range machines {
+			lease, ok := leases[m.ID]
+			if !ok {
+				continue
+			}
+			if rerr := flapsClient.ReleaseLease(ctx, m.ID, lease.Data.Nonce); rerr != nil {
+				held = append(held, fmt.Sprintf("%s (nonce %s)", m.ID, lease.Data.Nonce))
+			}
+		}
+		if len(held) > 0 {
+			err = fmt.Errorf("%w; the leases acquired on %s couldn't be released", err, strings.Join(held, ", "))
+		}
+		return nil, err
+	}
+	return leases, nil
+}
+
+func runLeaseRelease(ctx context.Context) error {
+	var (
+		io   = iostreams.FromContext(ctx)
+		args = flag.Args(ctx)
+	)
+
+	nonce := flag.GetString(ctx, "nonce")
+	if nonce == "" {
+		return fmt.Errorf("--nonce is required, use 'fly machine leases clear' to clear a lease without its nonce")
+	}
+
+	machineIDs, ctx, err := selectManyMachineIDs(ctx, args)
+	if err != nil {
+		return err
+	}
+	if len(machineIDs) != 1 {
+		return fmt.Errorf("a lease nonce belongs to a single machine, select one machine")
+	}
+	flapsClient := flapsutil.ClientFromContext(ctx)
+
+	if err := flapsClient.ReleaseLease(ctx, machineIDs[0], nonce); err != nil {
+		return fmt.Errorf("failed to release lease on machine %s: %w", machineIDs[0], err)
+	}
+	fmt.Fprintf(io.Out, "Lease released on machine %s\n", machineIDs[0])
+
+	return nil
+}
+
 func runLeaseView(ctx context.Context) (err error) {
 	var (
 		io   = iostreams.FromContext(ctx)
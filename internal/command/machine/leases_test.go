@@ -0,0 +1,41 @@
+package machine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/mock"
+)
+
+func TestAcquireMachineLeases(t *testing.T) {
+	machines := []*fly.Machine{{ID: "m1"}, {ID: "m2"}, {ID: "m3"}}
+	var released []string
+	client := &mock.FlapsClient{
+		AcquireLeaseFunc: func(ctx context.Context, machineID string, ttl *int) (*fly.MachineLease, error) {
+			if machineID == "m3" {
+				return nil, errors.New("machine is busy")
+			}
+			return &fly.MachineLease{Data: &fly.MachineLeaseData{Nonce: "nonce-" + machineID}}, nil
+		},
+		ReleaseLeaseFunc: func(ctx context.Context, machineID, nonce string) error {
+			if machineID == "m2" {
+				return errors.New("timeout")
+			}
+			released = append(released, machineID+"/"+nonce)
+			return nil
+		},
+	}
+
+	_, err := acquireMachineLeases(context.Background(), client, machines, time.Minute)
+	assert.EqualError(t, err, "failed to acquire lease on machine m3: machine is busy; the leases acquired on m2 (nonce nonce-m2) couldn't be released")
+	assert.Equal(t, []string{"m1/nonce-m1"}, released)
+
+	leases, err := acquireMachineLeases(context.Background(), client, machines[:2], time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "nonce-m2", leases["m2"].Data.Nonce)
+}
@@ -9,11 +9,13 @@ import (
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/fly-go/flaps"
 	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/cmdutil"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/flapsutil"
 	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/internal/urls"
 	"github.com/superfly/flyctl/iostreams"
 )
 
@@ -38,16 +40,38 @@ func newList() *cobra.Command {
 		flag.App(),
 		flag.AppConfig(),
 		flag.JSONOutput(),
+		flag.ListOutput(),
 		flag.Bool{
 			Name:        "quiet",
 			Shorthand:   "q",
 			Description: "Only list machine ids",
 		},
+		flag.StringArray{
+			Name:        "metadata",
+			Description: "Only list machines with the given metadata, in the form of key=value. Can be specified multiple times.",
+		},
 	)
 
 	return cmd
 }
 
+// listColumns are the columns of the list, for --columns and --sort.
+var listColumns = []string{
+	"ID",
+	"Name",
+	"State",
+	"Checks",
+	"Region",
+	"Role",
+	"Image",
+	"IP Address",
+	"Volume",
+	"Created",
+	"Last Updated",
+	"Process Group",
+	"Size",
+}
+
 func runMachineList(ctx context.Context) (err error) {
 	var (
 		appName = appconfig.NameFromContext(ctx)
@@ -56,6 +80,20 @@ func runMachineList(ctx context.Context) (err error) {
 		cfg     = config.FromContext(ctx)
 	)
 
+	listOpts, err := render.ListOptionsFromContext(ctx, listColumns...)
+	if err != nil {
+		return err
+	}
+	// Templates and CSV get the rows alone, without the surrounding notes.
+	if !listOpts.Tabular() {
+		silence = true
+	}
+
+	metadata, err := cmdutil.ParseKVStringsToMap(flag.GetStringArray(ctx, "metadata"))
+	if err != nil {
+		return fmt.Errorf("invalid metadata filter: %w", err)
+	}
+
 	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{
 		AppName: appName,
 	})
@@ -67,6 +105,7 @@ func runMachineList(ctx context.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	machines = filterByMetadata(machines, metadata)
 
 	if cfg.JSONOutput {
 		return render.JSON(io.Out, machines)
@@ -81,12 +120,12 @@ func runMachineList(ctx context.Context) (err error) {
 
 	rows := [][]string{}
 
-	listOfMachinesLink := io.CreateLink("View them in the UI here", fmt.Sprintf("https://fly.io/apps/%s/machines/", appName))
+	listOfMachinesLink := io.CreateLink("View them in the UI here", urls.App(appName, "machines"))
 
 	if !silence {
 		fmt.Fprintf(io.Out, "%d machines have been retrieved from app %s.\n%s\n\n", len(machines), appName, listOfMachinesLink)
 	}
-	if silence {
+	if silence && listOpts.Tabular() {
 		for _, machine := range machines {
 			rows = append(rows, []string{machine.ID})
 		}
@@ -158,26 +197,28 @@ func runMachineList(ctx context.Context) (err error) {
 			})
 		}
 
-		headers := []string{
-			"ID",
-			"Name",
-			"State",
-			"Checks",
-			"Region",
-			"Role",
-			"Image",
-			"IP Address",
-			"Volume",
-			"Created",
-			"Last Updated",
-			"Process Group",
-			"Size",
+		if err := render.List(io.Out, listOpts, appName, rows, listColumns...); err != nil {
+			return err
 		}
-
-		_ = render.Table(io.Out, appName, rows, headers...)
-		if unreachableMachines {
+		if unreachableMachines && !silence {
 			fmt.Fprintln(io.Out, "* These Machines' hosts could not be reached.")
 		}
 	}
 	return nil
 }
+
+// filterByMetadata returns the machines whose metadata contains every
+// key=value pair in filters.
+func filterByMetadata(machines []*fly.Machine, filters map[string]string) []*fly.Machine {
+	if len(filters) == 0 {
+		return machines
+	}
+	return lo.Filter(machines, func(m *fly.Machine, _ int) bool {
+		for k, v := range filters {
+			if m.Config == nil || m.Config.Metadata[k] != v {
+				return false
+			}
+		}
+		return true
+	})
+}
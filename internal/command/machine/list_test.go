@@ -0,0 +1,56 @@
+package machine
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+)
+
+func TestFilterByMetadata(t *testing.T) {
+	data, err := os.ReadFile("testdata/machines.json")
+	require.NoError(t, err)
+
+	var machines []*fly.Machine
+	require.NoError(t, json.Unmarshal(data, &machines))
+
+	ids := func(machines []*fly.Machine) []string {
+		return lo.Map(machines, func(m *fly.Machine, _ int) string { return m.ID })
+	}
+
+	testcases := []struct {
+		name    string
+		filters map[string]string
+		want    []string
+	}{
+		{
+			name: "no filters",
+			want: []string{"148ed193b95389", "3d8d9e1b267389", "e784e903a76e18", "1781973f5e4589"},
+		},
+		{
+			name:    "single filter",
+			filters: map[string]string{"role": "worker"},
+			want:    []string{"3d8d9e1b267389", "e784e903a76e18"},
+		},
+		{
+			name:    "filters are combined",
+			filters: map[string]string{"role": "worker", "tier": "batch"},
+			want:    []string{"e784e903a76e18"},
+		},
+		{
+			name:    "no match",
+			filters: map[string]string{"role": "db"},
+			want:    []string{},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, ids(filterByMetadata(machines, tc.filters)))
+		})
+	}
+}
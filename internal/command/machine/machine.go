@@ -3,6 +3,7 @@ package machine
 import (
 	"github.com/spf13/cobra"
 	"github.com/superfly/flyctl/internal/command"
+	mach "github.com/superfly/flyctl/internal/machine"
 )
 
 func New() *cobra.Command {
@@ -20,8 +21,12 @@ Machines REST fly.`
 
 	cmd.Aliases = []string{"machines", "m"}
 
+	leaseTTL := mach.LeaseTTLFlag()
+	cmd.PersistentFlags().String(leaseTTL.Name, leaseTTL.Default, leaseTTL.Description)
+
 	cmd.AddCommand(
 		newKill(),
+		newSignal(),
 		newList(),
 		newDestroy(),
 		newRun(),
@@ -32,12 +37,15 @@ Machines REST fly.`
 		newProxy(),
 		newClone(),
 		newUpdate(),
+		newRollback(),
 		newRestart(),
 		newLeases(),
 		newMachineExec(),
+		newCp(),
 		newMachineCordon(),
 		newMachineUncordon(),
 		newSuspend(),
+		newResume(),
 		newEgressIp(),
 	)
 
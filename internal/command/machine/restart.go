@@ -52,6 +52,7 @@ func newRestart() *cobra.Command {
 			Description: "Restarts app without waiting for health checks.",
 			Default:     false,
 		},
+		parallelismFlag(1),
 	)
 
 	return cmd
@@ -83,11 +84,14 @@ func runMachineRestart(ctx context.Context) error {
 		return err
 	}
 
-	// Restart each machine
-	for _, machine := range machines {
-		if err := mach.Restart(ctx, machine, input, machine.LeaseNonce); err != nil {
-			return fmt.Errorf("failed to restart machine %s: %w", machine.ID, err)
-		}
+	// Restart each machine. Restart sets the input's ID, so every machine
+	// gets its own copy.
+	err = mach.RunConcurrently(ctx, machines, flag.GetInt(ctx, "parallelism"), func(ctx context.Context, machine *fly.Machine) error {
+		in := *input
+		return mach.Restart(ctx, machine, &in, machine.LeaseNonce)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restart machines: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,113 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	mach "github.com/superfly/flyctl/internal/machine"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newResume() *cobra.Command {
+	const (
+		short = "Resume one or more suspended Fly machines"
+		long  = short + `. The machines continue from the memory state they
+were suspended with.
+`
+
+		usage = "resume [<id>...]"
+	)
+
+	cmd := command.New(usage, short, long, runMachineResume,
+		command.RequireSession,
+		command.LoadAppNameIfPresent,
+	)
+
+	cmd.Args = cobra.ArbitraryArgs
+
+	flag.Add(
+		cmd,
+		flag.App(),
+		flag.AppConfig(),
+		selectFlag,
+		flag.Duration{
+			Name:        "wait-timeout",
+			Shorthand:   "w",
+			Description: "Duration to wait for individual Machines to be started.",
+			Default:     0 * time.Second,
+		},
+	)
+
+	return cmd
+}
+
+func runMachineResume(ctx context.Context) (err error) {
+	var (
+		io          = iostreams.FromContext(ctx)
+		args        = flag.Args(ctx)
+		waitTimeout = flag.GetDuration(ctx, "wait-timeout")
+	)
+
+	machines, ctx, err := selectManyMachines(ctx, args)
+	if err != nil {
+		return err
+	}
+
+	for _, machine := range machines {
+		if err := checkResumable(machine); err != nil {
+			return err
+		}
+	}
+
+	machines, release, err := mach.AcquireLeases(ctx, machines)
+	defer release()
+	if err != nil {
+		return err
+	}
+
+	for _, machine := range machines {
+		if err = resume(ctx, machine, waitTimeout); err != nil {
+			return
+		}
+		if waitTimeout != 0 {
+			fmt.Fprintf(io.Out, "%s has been resumed\n", machine.ID)
+		} else {
+			fmt.Fprintf(io.Out, "%s is being resumed\n", machine.ID)
+		}
+	}
+	return
+}
+
+// checkResumable fails when the machine isn't suspended, since starting it
+// would boot it from scratch rather than resume it.
+func checkResumable(machine *fly.Machine) error {
+	if machine.State != fly.MachineStateSuspended {
+		return fmt.Errorf("machine %s is %s, not suspended; use 'fly machine start' to start it", machine.ID, machine.State)
+	}
+	return nil
+}
+
+// resume starts the suspended machine, which restores it from its snapshot.
+func resume(ctx context.Context, machine *fly.Machine, waitTimeout time.Duration) error {
+	if err := Start(ctx, machine); err != nil {
+		return err
+	}
+
+	if waitTimeout != 0 {
+		m, err := flapsutil.ClientFromContext(ctx).Get(ctx, machine.ID)
+		if err != nil {
+			return fmt.Errorf("could not get Machine %s to wait for it to resume: %w", machine.ID, err)
+		}
+		if err := mach.WaitForStartOrStop(ctx, m, "start", waitTimeout); err != nil {
+			return fmt.Errorf("Machine %s was not resumed within the wait timeout: %w", machine.ID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/iostreams"
+
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyerr"
+	mach "github.com/superfly/flyctl/internal/machine"
+	"github.com/superfly/flyctl/internal/urls"
+)
+
+func newRollback() *cobra.Command {
+	const (
+		short = "Roll back a machine to its config before the last update"
+		long  = short + `. The previous config is saved locally each time a
+machine is updated with 'fly machine update'; rolling back re-applies it.`
+
+		usage = "rollback [machine_id]"
+	)
+
+	cmd := command.New(usage, short, long, runRollback,
+		command.RequireSession,
+		command.LoadAppNameIfPresent,
+	)
+
+	flag.Add(
+		cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.Yes(),
+		selectFlag,
+		flag.Bool{
+			Name:        "skip-health-checks",
+			Description: "Rolls back the machine without waiting for health checks.",
+			Default:     false,
+		},
+		flag.Int{
+			Name:        "wait-timeout",
+			Description: "Seconds to wait for the machine to transition states and become healthy. (default 300)",
+			Default:     300,
+		},
+	)
+
+	cmd.Args = cobra.RangeArgs(0, 1)
+
+	return cmd
+}
+
+func runRollback(ctx context.Context) error {
+	var (
+		io          = iostreams.FromContext(ctx)
+		autoConfirm = flag.GetBool(ctx, "yes")
+	)
+
+	machineID := flag.FirstArg(ctx)
+	haveMachineID := len(flag.Args(ctx)) > 0
+	machine, ctx, err := selectOneMachine(ctx, "", machineID, haveMachineID)
+	if err != nil {
+		return err
+	}
+	appName := appconfig.NameFromContext(ctx)
+
+	saved, err := lastMachineConfig(ctx, appName, machine.ID)
+	if err != nil {
+		return err
+	}
+	if saved == nil {
+		return flyerr.GenericErr{
+			Err:     fmt.Sprintf("no previous config saved for machine %s", machine.ID),
+			Suggest: "Previous configs are only saved by 'fly machine update' from this computer",
+		}
+	}
+
+	machine, releaseLeaseFunc, err := mach.AcquireLease(ctx, machine)
+	defer releaseLeaseFunc()
+	if err != nil {
+		return err
+	}
+
+	if !autoConfirm {
+		prompt := fmt.Sprintf("Rolling back machine %s to its config from %s\n", machine.ID, saved.SavedAt.Local().Format(time.DateTime))
+		confirmed, err := mach.ConfirmConfigChanges(ctx, machine, *saved.Config, prompt)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintf(io.Out, "No changes to apply\n")
+			return nil
+		}
+	}
+
+	input := &fly.LaunchMachineInput{
+		Name:             machine.Name,
+		Region:           machine.Region,
+		Config:           saved.Config,
+		SkipLaunch:       len(saved.Config.Standbys) > 0,
+		SkipHealthChecks: flag.GetBool(ctx, "skip-health-checks"),
+		Timeout:          flag.GetInt(ctx, "wait-timeout"),
+	}
+	if err := mach.Update(ctx, machine, input); err != nil {
+		var timeoutErr mach.WaitTimeoutErr
+		if errors.As(err, &timeoutErr) {
+			return flyerr.GenericErr{
+				Err:      timeoutErr.Error(),
+				Descript: timeoutErr.Description(),
+				Suggest:  "Try increasing the --wait-timeout",
+			}
+		}
+		return err
+	}
+
+	if err := dropLastMachineConfig(ctx, appName, machine.ID); err != nil {
+		fmt.Fprintf(io.ErrOut, "Warning: failed to update the saved machine config history: %v\n", err)
+	}
+
+	fmt.Fprintf(io.Out, "Machine %s rolled back\n", machine.ID)
+	fmt.Fprintf(io.Out, "\nMonitor machine status here:\n%s\n", urls.Machine(appName, machine.ID))
+
+	return nil
+}
@@ -5,30 +5,37 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"math/rand"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/briandowns/spinner"
+	"github.com/azazeal/pause"
 	"github.com/google/shlex"
 	"github.com/pkg/errors"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
+	"github.com/subosito/gotenv"
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/fly-go/flaps"
 	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/build/imgsrc"
 	"github.com/superfly/flyctl/internal/cmdutil"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/command/ssh"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/flyerr"
 	"github.com/superfly/flyctl/internal/flyutil"
 	mach "github.com/superfly/flyctl/internal/machine"
 	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/internal/spinner"
 	"github.com/superfly/flyctl/internal/watch"
 	"github.com/superfly/flyctl/iostreams"
+	"github.com/superfly/flyctl/logs"
 )
 
 var sharedFlags = flag.Set{
@@ -43,6 +50,14 @@ var sharedFlags = flag.Set{
 	To remove a port mapping use '-' as handler. For example: --port 80/tcp:-`,
 	},
 	flag.Env(),
+	flag.StringArray{
+		Name:        "env-file",
+		Description: "Read environment variables from a dotenv file. Can be specified multiple times; --env takes precedence.",
+	},
+	flag.Bool{
+		Name:        "env-clear",
+		Description: "Drop the Machine's existing environment variables instead of merging --env and --env-file into them",
+	},
 	flag.String{
 		Name:        "entrypoint",
 		Description: "The command to override the Docker ENTRYPOINT.",
@@ -109,7 +124,7 @@ var sharedFlags = flag.Set{
 	},
 	flag.String{
 		Name:        "schedule",
-		Description: `Schedule a Machine run at hourly, daily and monthly intervals`,
+		Description: `Schedule a Machine run at hourly, daily, weekly or monthly intervals`,
 	},
 	flag.Bool{
 		Name:        "skip-dns-registration",
@@ -131,6 +146,14 @@ var sharedFlags = flag.Set{
 		Description: `Set the restart policy for a Machine. Options include 'no', 'always', and 'on-fail'.
 	Default is 'on-fail' for Machines created by 'fly deploy' and Machines with a schedule. Default is 'always' for Machines created by 'fly m run'.`,
 	},
+	flag.String{
+		Name:        "restart-policy",
+		Description: "Set the restart policy for a Machine. Options include 'no', 'on-failure', and 'always'. Same as --restart.",
+	},
+	flag.Int{
+		Name:        "restart-max-retries",
+		Description: "Maximum number of times to restart a Machine that exits with an error. Requires the 'on-failure' restart policy.",
+	},
 	flag.StringSlice{
 		Name:        "standby-for",
 		Description: "For Machines without services, a comma separated list of Machine IDs to act as standby for.",
@@ -147,6 +170,11 @@ var sharedFlags = flag.Set{
 		Name:        "file-secret",
 		Description: "Set of secrets to write to the Machine, in the form of /path/inside/machine=SECRET pairs, where SECRET is the name of the secret. The content of the secret must be base64 encoded. Can be specified multiple times.",
 	},
+	flag.StringSlice{
+		Name:        "volume",
+		Shorthand:   "v",
+		Description: "Volume to mount, in the form of <volume_id_or_name>:/path/inside/machine[:<options>]. Can be specified multiple times.",
+	},
 	flag.VMSizeFlags,
 }
 
@@ -170,10 +198,9 @@ var runOrCreateFlags = flag.Set{
 		Name:        "rm",
 		Description: "Automatically remove the Machine when it exits. Sets the restart-policy to 'never' if not otherwise specified.",
 	},
-	flag.StringSlice{
-		Name:        "volume",
-		Shorthand:   "v",
-		Description: "Volume to mount, in the form of <volume_id_or_name>:/path/inside/machine[:<options>]",
+	flag.String{
+		Name:        "exit-timeout",
+		Description: "With --rm, how long to wait for the Machine to exit, like 1h. By default there's no limit",
 	},
 	flag.Bool{
 		Name:        "lsvd",
@@ -219,8 +246,6 @@ func soManyErrors(args ...interface{}) error {
 	return fmt.Errorf("Multiple errors:\n%s", sb.String())
 }
 
-var s = spinner.New(spinner.CharSets[9], 100*time.Millisecond)
-
 func newRun() *cobra.Command {
 	const (
 		short = "Run a machine"
@@ -316,6 +341,11 @@ func runMachineRun(ctx context.Context) error {
 		interact = true
 	}
 
+	exitTimeout, err := parseExitTimeout(ctx)
+	if err != nil {
+		return err
+	}
+
 	if ctx.Value(createCommandCtxKey) != nil {
 		isCreate = true
 	}
@@ -377,6 +407,9 @@ func runMachineRun(ctx context.Context) error {
 		Region: flag.GetString(ctx, "region"),
 		LSVD:   flag.GetBool(ctx, "lsvd"),
 	}
+	if err := prompt.ValidateRegion(ctx, input.Region); err != nil {
+		return err
+	}
 
 	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{
 		AppCompact: app,
@@ -415,6 +448,10 @@ func runMachineRun(ctx context.Context) error {
 		return nil
 	}
 
+	if err := checkMachineConfig(ctx, machineConf, input.Region, false); err != nil {
+		return err
+	}
+
 	input.SkipLaunch = (len(machineConf.Standbys) > 0 || isCreate)
 	input.Config = machineConf
 
@@ -446,7 +483,7 @@ func runMachineRun(ctx context.Context) error {
 		fmt.Fprintf(io.Out, "\n Attempting to start machine...\n\n")
 	}
 
-	s.Start()
+	s := spinner.Run(io, "")
 	// wait for machine to be started
 	err = mach.WaitForStartOrStop(ctx, machine, "start", time.Minute*5)
 	s.Stop()
@@ -493,6 +530,10 @@ func runMachineRun(ctx context.Context) error {
 		}
 	}
 
+	if destroy && !flag.GetDetach(ctx) {
+		return streamUntilExit(ctx, app, machine, exitTimeout)
+	}
+
 	if !flag.GetDetach(ctx) {
 		fmt.Fprintln(io.Out, colorize.Green("==> "+"Monitoring health checks"))
 
@@ -508,6 +549,88 @@ func runMachineRun(ctx context.Context) error {
 	return nil
 }
 
+// parseExitTimeout returns the value of --exit-timeout, or 0 for no limit.
+func parseExitTimeout(ctx context.Context) (time.Duration, error) {
+	v := flag.GetString(ctx, "exit-timeout")
+	if v == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid value %q for --exit-timeout: it must be a duration like 30m", v)
+	}
+	return d, nil
+}
+
+// streamUntilExit prints the logs of a one-off machine started with --rm
+// until its main process exits, and fails with its exit code if it's not 0.
+// A timeout of 0 waits for as long as it takes.
+func streamUntilExit(ctx context.Context, app *fly.AppCompact, machine *fly.Machine, timeout time.Duration) error {
+	var (
+		io     = iostreams.FromContext(ctx)
+		client = flyutil.ClientFromContext(ctx)
+	)
+
+	fmt.Fprintf(io.Out, "\nStreaming logs until machine %s exits, it will be destroyed afterwards...\n\n", machine.ID)
+
+	logsCtx, cancelLogs := context.WithCancel(ctx)
+	defer cancelLogs()
+
+	logsDone := make(chan struct{})
+	go func() {
+		defer close(logsDone)
+
+		stream := logs.NewPollingStream(client)
+		for entry := range stream.Stream(logsCtx, &logs.LogOptions{AppName: app.Name, VMID: machine.ID}) {
+			fmt.Fprintln(io.Out, entry.Message)
+		}
+	}()
+
+	exitCode, err := mach.WaitForExit(ctx, machine, timeout)
+	if err != nil {
+		return err
+	}
+
+	// give log ingestion a moment to catch up with the exit
+	pause.For(ctx, 5*time.Second)
+	cancelLogs()
+	<-logsDone
+
+	if exitCode != 0 {
+		return machineExitErr{machineID: machine.ID, exitCode: exitCode}
+	}
+
+	fmt.Fprintf(io.Out, "\nMachine %s exited successfully\n", machine.ID)
+	return nil
+}
+
+// machineExitErr is the failure of a one-off machine's process, which flyctl
+// exits with the exit code of.
+type machineExitErr struct {
+	machineID string
+	exitCode  int
+}
+
+func (e machineExitErr) Error() string {
+	return fmt.Sprintf("machine %s exited with code %d", e.machineID, e.exitCode)
+}
+
+func (e machineExitErr) ExitCode() int {
+	return e.exitCode
+}
+
+var validSchedules = []string{"hourly", "daily", "weekly", "monthly"}
+
+func validateSchedule(schedule string) error {
+	if slices.Contains(validSchedules, schedule) {
+		return nil
+	}
+	return flyerr.GenericErr{
+		Err:     fmt.Sprintf("invalid schedule %q", schedule),
+		Suggest: fmt.Sprintf("Valid schedules are: %s", strings.Join(validSchedules, ", ")),
+	}
+}
+
 func getOrCreateEphemeralShellApp(ctx context.Context, client flyutil.Client) (*fly.AppCompact, error) {
 	// no prompt if --org, buried in the context code
 	org, err := prompt.Org(ctx)
@@ -622,13 +745,55 @@ func parseKVFlag(ctx context.Context, flagName string, initialMap map[string]str
 	return parsed, nil
 }
 
-func selectAppName(ctx context.Context) (name string, err error) {
-	const msg = "App Name:"
+// determineEnv returns the machine environment resulting from existing and
+// the --env-clear, --env-file and --env flags, in that order.
+func determineEnv(ctx context.Context, existing map[string]string) (map[string]string, error) {
+	env := make(map[string]string)
+	if !flag.GetBool(ctx, "env-clear") {
+		maps.Copy(env, existing)
+	}
+
+	for _, path := range flag.GetStringArray(ctx, "env-file") {
+		parsed, err := gotenv.Read(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid env file %s: %w", path, err)
+		}
+		for k, v := range parsed {
+			if err := validateEnvName(k); err != nil {
+				return nil, fmt.Errorf("invalid env file %s: %w", path, err)
+			}
+			env[k] = v
+		}
+	}
 
-	if err = prompt.String(ctx, &name, msg, "", false); prompt.IsNonInteractive(err) {
-		err = prompt.NonInteractiveError("name argument or flag must be specified when not running interactively")
+	for _, kv := range flag.GetStringArray(ctx, "env") {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --env %q: must be in the format NAME=VALUE", kv)
+		}
+		if err := validateEnvName(k); err != nil {
+			return nil, fmt.Errorf("invalid --env %q: %w", kv, err)
+		}
+		env[k] = v
 	}
 
+	return env, nil
+}
+
+func validateEnvName(name string) error {
+	switch {
+	case name == "":
+		return errors.New("environment variable name can't be empty")
+	case strings.ContainsAny(name, " \t\n"):
+		return fmt.Errorf("environment variable name %q can't contain whitespace", name)
+	}
+	return nil
+}
+
+func selectAppName(ctx context.Context) (name string, err error) {
+	const msg = "App Name:"
+
+	err = prompt.Require(prompt.String(ctx, &name, msg, "", false), "name argument or flag")
 	return
 }
 
@@ -680,26 +845,26 @@ func determineMachineConfig(
 		machineConf.Guest.KernelArgs = flag.GetStringArray(ctx, "kernel-arg")
 	}
 
-	parsedEnv, err := parseKVFlag(ctx, "env", machineConf.Env)
-	if err != nil {
+	if machineConf.Env, err = determineEnv(ctx, machineConf.Env); err != nil {
 		return machineConf, err
 	}
 
-	if machineConf.Env == nil {
-		machineConf.Env = make(map[string]string)
-	}
-
-	for k, v := range parsedEnv {
-		machineConf.Env[k] = v
-	}
-
-	if flag.GetString(ctx, "schedule") != "" {
-		machineConf.Schedule = flag.GetString(ctx, "schedule")
+	if schedule := flag.GetString(ctx, "schedule"); schedule != "" {
+		if err := validateSchedule(schedule); err != nil {
+			return machineConf, err
+		}
+		machineConf.Schedule = schedule
 	}
 
 	if input.updating {
-		// Called from `update`. Command is specified by flag.
-		if flag.IsSpecified(ctx, "command") {
+		// Called from `update`. Command is specified by flag, or by the
+		// arguments after `--`.
+		if _, commandArgs := splitArgsAtDash(ctx); len(commandArgs) > 0 {
+			if flag.IsSpecified(ctx, "command") {
+				return machineConf, errors.New("--command can't be used together with a command after --")
+			}
+			machineConf.Init.Cmd = commandArgs
+		} else if flag.IsSpecified(ctx, "command") {
 			command := strings.TrimSpace(flag.GetString(ctx, "command"))
 			switch command {
 			case "":
@@ -758,37 +923,8 @@ func determineMachineConfig(
 		machineConf.Init.Entrypoint = splitted
 	}
 
-	// default restart policy to always unless otherwise specified
-	switch flag.GetString(ctx, "restart") {
-	case "no":
-		machineConf.Restart = &fly.MachineRestart{
-			Policy: fly.MachineRestartPolicyNo,
-		}
-	case "on-fail":
-		machineConf.Restart = &fly.MachineRestart{
-			Policy: fly.MachineRestartPolicyOnFailure,
-		}
-	case "always":
-		machineConf.Restart = &fly.MachineRestart{
-			Policy: fly.MachineRestartPolicyAlways,
-		}
-	case "":
-		if flag.IsSpecified(ctx, "restart") {
-			// An empty policy was explicitly requested.
-			machineConf.Restart = nil
-		} else if machineConf.AutoDestroy {
-			// Autodestroy only works when the restart policy is set to no, so unless otherwise specified, we set the restart policy to no.
-			machineConf.Restart = &fly.MachineRestart{Policy: fly.MachineRestartPolicyNo}
-		} else if !input.updating {
-			// This is a new machine; apply the default.
-			if machineConf.Schedule != "" {
-				machineConf.Restart = &fly.MachineRestart{
-					Policy: fly.MachineRestartPolicyOnFailure,
-				}
-			}
-		}
-	default:
-		return machineConf, errors.New("invalid restart provided")
+	if err := determineRestart(ctx, machineConf, input.updating); err != nil {
+		return machineConf, err
 	}
 
 	machineConf.Mounts, err = command.DetermineMounts(ctx, machineConf.Mounts, input.region)
@@ -851,5 +987,111 @@ func determineMachineConfig(
 	}
 	fly.MergeFiles(machineConf, machineFiles)
 
+	if err := checkDefaultCommand(ctx, machineConf, input); err != nil {
+		return machineConf, err
+	}
+
 	return machineConf, nil
 }
+
+// splitArgsAtDash splits the positional arguments into the ones before `--`
+// and the ones after it.
+func splitArgsAtDash(ctx context.Context) (before, after []string) {
+	args := flag.Args(ctx)
+	if n := command.FromContext(ctx).ArgsLenAtDash(); n >= 0 && n <= len(args) {
+		return args[:n], args[n:]
+	}
+	return args, nil
+}
+
+// hasInitCommand reports whether init overrides what the image runs.
+func hasInitCommand(init fly.MachineInit) bool {
+	return len(init.Exec) > 0 || len(init.Entrypoint) > 0 || len(init.Cmd) > 0
+}
+
+// checkDefaultCommand makes sure a machine whose image or command is being
+// changed has something to run: when machineConf doesn't set an entrypoint
+// or command, the image must provide one. The image is only looked up in that
+// case, and one that can't be looked up only gets a warning.
+func checkDefaultCommand(ctx context.Context, machineConf *fly.MachineConfig, input *determineMachineConfigInput) error {
+	if hasInitCommand(machineConf.Init) || machineConf.Image == "" || flag.GetBool(ctx, "build-only") {
+		return nil
+	}
+	changed := input.imageOrPath != "" || flag.IsSpecified(ctx, "entrypoint") ||
+		(input.updating && flag.IsSpecified(ctx, "command"))
+	if !changed {
+		return nil
+	}
+
+	entrypoint, cmd, err := imgsrc.ImageDefaultCommand(ctx, machineConf.Image)
+	if err != nil {
+		io := iostreams.FromContext(ctx)
+		fmt.Fprintf(io.ErrOut, "%s Could not check that the image has a default command: %v\n", io.ColorScheme().WarningIcon(), err)
+		return nil
+	}
+	if len(entrypoint) == 0 && len(cmd) == 0 {
+		return flyerr.GenericErr{
+			Err:     fmt.Sprintf("image %s has no default command and none was given", machineConf.Image),
+			Suggest: "Set one with --entrypoint, or pass the command after --",
+		}
+	}
+	return nil
+}
+
+// determineRestart applies the --restart, --restart-policy and
+// --restart-max-retries flags to machineConf.
+func determineRestart(ctx context.Context, machineConf *fly.MachineConfig, updating bool) error {
+	policyFlag := "restart"
+	if flag.IsSpecified(ctx, "restart-policy") {
+		if flag.IsSpecified(ctx, "restart") {
+			return errors.New("--restart and --restart-policy can't be used together")
+		}
+		policyFlag = "restart-policy"
+	}
+
+	// default restart policy to always unless otherwise specified
+	switch flag.GetString(ctx, policyFlag) {
+	case "no":
+		machineConf.Restart = &fly.MachineRestart{
+			Policy: fly.MachineRestartPolicyNo,
+		}
+	case "on-fail", "on-failure":
+		machineConf.Restart = &fly.MachineRestart{
+			Policy: fly.MachineRestartPolicyOnFailure,
+		}
+	case "always":
+		machineConf.Restart = &fly.MachineRestart{
+			Policy: fly.MachineRestartPolicyAlways,
+		}
+	case "":
+		if flag.IsSpecified(ctx, policyFlag) {
+			// An empty policy was explicitly requested.
+			machineConf.Restart = nil
+		} else if machineConf.AutoDestroy {
+			// Autodestroy only works when the restart policy is set to no, so unless otherwise specified, we set the restart policy to no.
+			machineConf.Restart = &fly.MachineRestart{Policy: fly.MachineRestartPolicyNo}
+		} else if !updating {
+			// This is a new machine; apply the default.
+			if machineConf.Schedule != "" {
+				machineConf.Restart = &fly.MachineRestart{
+					Policy: fly.MachineRestartPolicyOnFailure,
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("invalid restart policy %q, must be one of: no, on-failure, always", flag.GetString(ctx, policyFlag))
+	}
+
+	if flag.IsSpecified(ctx, "restart-max-retries") {
+		maxRetries := flag.GetInt(ctx, "restart-max-retries")
+		switch {
+		case maxRetries < 0:
+			return fmt.Errorf("--restart-max-retries can't be negative, got %d", maxRetries)
+		case machineConf.Restart == nil || machineConf.Restart.Policy != fly.MachineRestartPolicyOnFailure:
+			return errors.New("--restart-max-retries requires the 'on-failure' restart policy")
+		}
+		machineConf.Restart.MaxRetries = maxRetries
+	}
+
+	return nil
+}
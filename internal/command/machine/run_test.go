@@ -0,0 +1,320 @@
+package machine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gcrname "github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/tokens"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyerr"
+	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/internal/mock"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestValidateSchedule(t *testing.T) {
+	for _, schedule := range []string{"hourly", "daily", "weekly", "monthly"} {
+		assert.NoError(t, validateSchedule(schedule), schedule)
+	}
+
+	for _, schedule := range []string{"Daily", "yearly", "*/5 * * * *", " hourly"} {
+		assert.Error(t, validateSchedule(schedule), schedule)
+	}
+}
+
+func TestDetermineRestart(t *testing.T) {
+	cases := []struct {
+		name     string
+		args     []string
+		updating bool
+		initial  *fly.MachineRestart
+		want     *fly.MachineRestart
+		wantErr  string
+	}{
+		{
+			name: "no flags on create",
+			want: nil,
+		},
+		{
+			name:     "no flags on update keeps policy",
+			updating: true,
+			initial:  &fly.MachineRestart{Policy: fly.MachineRestartPolicyAlways},
+			want:     &fly.MachineRestart{Policy: fly.MachineRestartPolicyAlways},
+		},
+		{
+			name: "restart-policy no",
+			args: []string{"--restart-policy", "no"},
+			want: &fly.MachineRestart{Policy: fly.MachineRestartPolicyNo},
+		},
+		{
+			name: "restart-policy always",
+			args: []string{"--restart-policy", "always"},
+			want: &fly.MachineRestart{Policy: fly.MachineRestartPolicyAlways},
+		},
+		{
+			name: "restart-policy on-failure with max retries",
+			args: []string{"--restart-policy", "on-failure", "--restart-max-retries", "3"},
+			want: &fly.MachineRestart{Policy: fly.MachineRestartPolicyOnFailure, MaxRetries: 3},
+		},
+		{
+			name: "legacy restart on-fail",
+			args: []string{"--restart", "on-fail"},
+			want: &fly.MachineRestart{Policy: fly.MachineRestartPolicyOnFailure},
+		},
+		{
+			name:     "max retries on existing on-failure policy",
+			args:     []string{"--restart-max-retries", "5"},
+			updating: true,
+			initial:  &fly.MachineRestart{Policy: fly.MachineRestartPolicyOnFailure, MaxRetries: 1},
+			want:     &fly.MachineRestart{Policy: fly.MachineRestartPolicyOnFailure, MaxRetries: 5},
+		},
+		{
+			name:    "invalid policy",
+			args:    []string{"--restart-policy", "sometimes"},
+			wantErr: "invalid restart policy",
+		},
+		{
+			name:    "both policy flags",
+			args:    []string{"--restart", "no", "--restart-policy", "always"},
+			wantErr: "can't be used together",
+		},
+		{
+			name:    "max retries without on-failure",
+			args:    []string{"--restart-policy", "always", "--restart-max-retries", "3"},
+			wantErr: "requires the 'on-failure' restart policy",
+		},
+		{
+			name:    "negative max retries",
+			args:    []string{"--restart-policy", "on-failure", "--restart-max-retries", "-1"},
+			wantErr: "can't be negative",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			flag.Add(cmd, sharedFlags)
+			require.NoError(t, cmd.Flags().Parse(tc.args))
+			ctx := flag.NewContext(context.Background(), cmd.Flags())
+
+			conf := &fly.MachineConfig{Restart: tc.initial}
+			err := determineRestart(ctx, conf, tc.updating)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, conf.Restart)
+		})
+	}
+}
+
+func TestSplitArgsAtDash(t *testing.T) {
+	cases := []struct {
+		args           []string
+		before, after  []string
+		wantArgsErrors bool
+	}{
+		{args: []string{"m1"}, before: []string{"m1"}},
+		{args: []string{"m1", "--", "bin/worker", "--queue", "default"}, before: []string{"m1"}, after: []string{"bin/worker", "--queue", "default"}},
+		{args: []string{"--", "bin/web"}, before: []string{}, after: []string{"bin/web"}},
+		{args: []string{"m1", "m2"}, wantArgsErrors: true},
+	}
+
+	for _, tc := range cases {
+		cmd := newUpdate()
+		require.NoError(t, cmd.Flags().Parse(tc.args))
+		args := cmd.Flags().Args()
+		if tc.wantArgsErrors {
+			assert.Error(t, cmd.Args(cmd, args), tc.args)
+			continue
+		}
+		require.NoError(t, cmd.Args(cmd, args), tc.args)
+
+		ctx := command.NewContext(flag.NewContext(context.Background(), cmd.Flags()), cmd)
+		before, after := splitArgsAtDash(ctx)
+		assert.Equal(t, tc.before, before, tc.args)
+		assert.Equal(t, tc.after, after, tc.args)
+	}
+}
+
+func TestHasInitCommand(t *testing.T) {
+	assert.False(t, hasInitCommand(fly.MachineInit{}))
+	assert.True(t, hasInitCommand(fly.MachineInit{Cmd: []string{"bin/web"}}))
+	assert.True(t, hasInitCommand(fly.MachineInit{Entrypoint: []string{"/entrypoint.sh"}}))
+	assert.True(t, hasInitCommand(fly.MachineInit{Exec: []string{"/bin/sleep", "inf"}}))
+}
+
+func TestDetermineEnv(t *testing.T) {
+	envFile := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(envFile, []byte("# comment\nFROM_FILE=file\nSHARED=file\nQUOTED=\"a b\"\n"), 0o600))
+
+	existing := map[string]string{"EXISTING": "1", "SHARED": "existing"}
+
+	cases := []struct {
+		name    string
+		args    []string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name: "no flags keeps existing",
+			want: map[string]string{"EXISTING": "1", "SHARED": "existing"},
+		},
+		{
+			name: "env merges into existing",
+			args: []string{"--env", "NEW=2", "--env", "SHARED=flag"},
+			want: map[string]string{"EXISTING": "1", "SHARED": "flag", "NEW": "2"},
+		},
+		{
+			name: "env file merges, env takes precedence",
+			args: []string{"--env-file", envFile, "--env", "SHARED=flag"},
+			want: map[string]string{"EXISTING": "1", "SHARED": "flag", "FROM_FILE": "file", "QUOTED": "a b"},
+		},
+		{
+			name: "env-clear drops existing",
+			args: []string{"--env-clear", "--env", "NEW=2"},
+			want: map[string]string{"NEW": "2"},
+		},
+		{
+			name: "env-clear alone",
+			args: []string{"--env-clear"},
+			want: map[string]string{},
+		},
+		{
+			name:    "missing equals",
+			args:    []string{"--env", "NEW"},
+			wantErr: "must be in the format NAME=VALUE",
+		},
+		{
+			name:    "empty name",
+			args:    []string{"--env", "=value"},
+			wantErr: "can't be empty",
+		},
+		{
+			name:    "missing env file",
+			args:    []string{"--env-file", filepath.Join(t.TempDir(), "missing")},
+			wantErr: "invalid env file",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			flag.Add(cmd, sharedFlags)
+			require.NoError(t, cmd.Flags().Parse(tc.args))
+			ctx := flag.NewContext(context.Background(), cmd.Flags())
+
+			env, err := determineEnv(ctx, existing)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, env)
+			assert.Equal(t, "existing", existing["SHARED"], "existing env must not be modified")
+		})
+	}
+}
+
+func TestMachineExitErr(t *testing.T) {
+	err := fmt.Errorf("run failed: %w", machineExitErr{machineID: "m1", exitCode: 3})
+	assert.EqualError(t, err, "run failed: machine m1 exited with code 3")
+
+	code, ok := flyerr.GetErrorExitCode(err)
+	assert.True(t, ok)
+	assert.Equal(t, 3, code)
+
+	_, ok = flyerr.GetErrorExitCode(errors.New("boom"))
+	assert.False(t, ok)
+}
+
+func TestCheckDefaultCommand(t *testing.T) {
+	var requests atomic.Int32
+	reg := registry.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		reg.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	push := func(name string, cmd []string) string {
+		img, err := mutate.Config(empty.Image, v1.Config{Cmd: cmd})
+		require.NoError(t, err)
+		ref, err := gcrname.ParseReference(strings.TrimPrefix(server.URL, "http://") + "/" + name)
+		require.NoError(t, err)
+		require.NoError(t, remote.Write(ref, img))
+		return ref.String()
+	}
+	withCmd := push("with-cmd:latest", []string{"nginx"})
+	withoutCmd := push("without-cmd:latest", nil)
+
+	check := func(image string, init fly.MachineInit) (string, error) {
+		cmd := newRun()
+		require.NoError(t, cmd.Flags().Parse(nil))
+		ios, _, _, errOut := iostreams.Test()
+		ctx := iostreams.NewContext(context.Background(), ios)
+		ctx = config.NewContext(ctx, &config.Config{Tokens: tokens.Parse("")})
+		ctx = flag.NewContext(ctx, cmd.Flags())
+		err := checkDefaultCommand(ctx, &fly.MachineConfig{Image: image, Init: init}, &determineMachineConfigInput{imageOrPath: image})
+		return errOut.String(), err
+	}
+
+	_, err := check(withCmd, fly.MachineInit{})
+	assert.NoError(t, err)
+
+	_, err = check(withoutCmd, fly.MachineInit{})
+	assert.ErrorContains(t, err, "has no default command and none was given")
+
+	requests.Store(0)
+	_, err = check(withoutCmd, fly.MachineInit{Cmd: []string{"bin/web"}})
+	assert.NoError(t, err)
+	assert.Zero(t, requests.Load(), "the image isn't looked up when a command is given")
+
+	warning, err := check(strings.TrimPrefix(server.URL, "http://")+"/missing:latest", fly.MachineInit{})
+	assert.NoError(t, err)
+	assert.Contains(t, warning, "Could not check that the image has a default command")
+}
+
+func TestRunRejectsExitTimeoutBeforeLaunching(t *testing.T) {
+	for _, v := range []string{"30", "-1m", "soon"} {
+		cmd := newRun()
+		require.NoError(t, cmd.Flags().Parse([]string{"--rm", "--exit-timeout", v, "nginx"}))
+		ios, _, _, _ := iostreams.Test()
+		ctx := iostreams.NewContext(context.Background(), ios)
+		// No API calls are stubbed, so any attempt to create the app or
+		// the machine would panic.
+		ctx = flyutil.NewContextWithClient(ctx, &mock.Client{})
+		ctx = flag.NewContext(ctx, cmd.Flags())
+
+		err := runMachineRun(ctx)
+		assert.ErrorContains(t, err, "--exit-timeout", v)
+	}
+
+	cmd := newRun()
+	require.NoError(t, cmd.Flags().Parse([]string{"--exit-timeout", "90m"}))
+	timeout, err := parseExitTimeout(flag.NewContext(context.Background(), cmd.Flags()))
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, timeout)
+}
@@ -13,8 +13,8 @@ import (
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/flapsutil"
 	"github.com/superfly/flyctl/internal/flyutil"
+	mach "github.com/superfly/flyctl/internal/machine"
 	"github.com/superfly/flyctl/internal/prompt"
-	"github.com/superfly/flyctl/iostreams"
 )
 
 // We now prompt for a machine automatically when no machine IDs are
@@ -123,7 +123,7 @@ func buildContextFromAppName(ctx context.Context, appName string) (context.Conte
 		return nil, fmt.Errorf("could not create flaps client: %w", err)
 	}
 	ctx = flapsutil.NewContextWithClient(ctx, flapsClient)
-	return ctx, nil
+	return mach.WithLeaseTTLFlag(ctx)
 }
 
 func buildContextFromAppNameOrMachineID(ctx context.Context, machineIDs ...string) (context.Context, error) {
@@ -159,7 +159,7 @@ func buildContextFromAppNameOrMachineID(ctx context.Context, machineIDs ...strin
 	}
 
 	ctx = flapsutil.NewContextWithClient(ctx, flapsClient)
-	return ctx, nil
+	return mach.WithLeaseTTLFlag(ctx)
 }
 
 func promptForOneMachine(ctx context.Context) (*fly.Machine, error) {
@@ -171,11 +171,14 @@ func promptForOneMachine(ctx context.Context) (*fly.Machine, error) {
 	}
 
 	options := sortAndBuildOptions(machines)
-	var selection int
-	if err := prompt.Select(ctx, &selection, "Select a machine:", "", options...); err != nil {
+	machine, err := prompt.SelectMachine(ctx, "Select a machine:", machines, options)
+	switch {
+	case prompt.IsNonInteractive(err):
+		return nil, err
+	case err != nil:
 		return nil, fmt.Errorf("could not prompt for machine: %w", err)
 	}
-	return machines[selection], nil
+	return machine, nil
 }
 
 func promptForManyMachines(ctx context.Context) ([]*fly.Machine, error) {
@@ -187,15 +190,13 @@ func promptForManyMachines(ctx context.Context) ([]*fly.Machine, error) {
 	}
 
 	options := sortAndBuildOptions(machines)
-	var selections []int
-	if err := prompt.MultiSelect(ctx, &selections, "Select machines:", nil, options...); err != nil {
+	selectedMachines, err := prompt.MultiSelectMachines(ctx, "Select machines:", machines, options)
+	switch {
+	case prompt.IsNonInteractive(err):
+		return nil, err
+	case err != nil:
 		return nil, fmt.Errorf("could not prompt for machines: %w", err)
 	}
-
-	var selectedMachines []*fly.Machine
-	for _, selection := range selections {
-		selectedMachines = append(selectedMachines, machines[selection])
-	}
 	if len(selectedMachines) == 0 {
 		return nil, errors.New("no machines selected")
 	}
@@ -257,8 +258,6 @@ func checkSelectConditions(ctx context.Context, haveMachineIDs bool) error {
 		return errors.New("an app name must be specified to use --select")
 	case !haveMachineIDs && appName == "":
 		return errors.New("a machine ID or an app name is required")
-	case shouldPrompt(ctx, haveMachineIDs) && !iostreams.FromContext(ctx).IsInteractive():
-		return errors.New("a machine ID must be specified when not running interactively")
 	default:
 		return nil
 	}
@@ -267,3 +266,13 @@ func checkSelectConditions(ctx context.Context, haveMachineIDs bool) error {
 func shouldPrompt(ctx context.Context, haveMachineIDs bool) bool {
 	return flag.GetBool(ctx, "select") || !haveMachineIDs
 }
+
+// parallelismFlag returns the flag bounding how many machines bulk commands
+// operate on at once.
+func parallelismFlag(def int) flag.Int {
+	return flag.Int{
+		Name:        "parallelism",
+		Description: "Maximum number of machines to operate on concurrently",
+		Default:     def,
+	}
+}
@@ -0,0 +1,91 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// destructiveSignals are the signals that, handled or not, are expected to
+// end the machine's main process.
+var destructiveSignals = []string{"SIGABRT", "SIGINT", "SIGKILL", "SIGQUIT", "SIGTERM"}
+
+func newSignal() *cobra.Command {
+	const (
+		short = "Send a signal to a Fly machine"
+		long  = `Send a signal to the main process of a Fly machine, e.g. SIGHUP to
+reload its configuration. Signals that terminate the process ask for
+confirmation unless --yes is given.`
+
+		usage = "signal [id] <signal>"
+	)
+
+	cmd := command.New(usage, short, long, runMachineSignal,
+		command.RequireSession,
+		command.LoadAppNameIfPresent,
+	)
+
+	cmd.Args = cobra.RangeArgs(1, 2)
+
+	flag.Add(
+		cmd,
+		flag.App(),
+		flag.AppConfig(),
+		selectFlag,
+		flag.Yes(),
+	)
+
+	return cmd
+}
+
+func runMachineSignal(ctx context.Context) (err error) {
+	io := iostreams.FromContext(ctx)
+
+	args := flag.Args(ctx)
+	machineID, haveMachineID := "", len(args) == 2
+	if haveMachineID {
+		machineID = args[0]
+	}
+
+	signal, err := flapsutil.ParseSignal(args[len(args)-1])
+	if err != nil {
+		return err
+	}
+
+	current, ctx, err := selectOneMachine(ctx, "", machineID, haveMachineID)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(destructiveSignals, signal) && !flag.GetYes(ctx) {
+		confirmed, err := prompt.Confirmf(ctx, "%s will likely stop the main process of machine %s, continue?", signal, current.ID)
+		switch {
+		case prompt.IsNonInteractive(err):
+			return prompt.NonInteractiveError("yes flag must be specified when not running interactively")
+		case err != nil:
+			return err
+		case !confirmed:
+			return nil
+		}
+	}
+
+	appName := appconfig.NameFromContext(ctx)
+	err = flapsutil.Signal(ctx, flapsutil.ClientFromContext(ctx), appName, current.ID, signal)
+	if err != nil {
+		if rerr := rewriteMachineNotFoundErrors(ctx, err, current.ID); rerr != nil {
+			return rerr
+		}
+		return err
+	}
+
+	fmt.Fprintf(io.Out, "%s has been sent to machine %s\n", signal, current.ID)
+	return nil
+}
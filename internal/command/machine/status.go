@@ -8,7 +8,9 @@ import (
 
 	"github.com/alecthomas/chroma/quick"
 	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
 	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/format"
 	"github.com/superfly/flyctl/internal/render"
@@ -35,6 +37,7 @@ func newStatus() *cobra.Command {
 		flag.App(),
 		flag.AppConfig(),
 		selectFlag,
+		flag.JSONOutput(),
 		flag.Bool{
 			Name:        "display-config",
 			Description: "Display the machine config as JSON",
@@ -64,6 +67,12 @@ func runMachineStatus(ctx context.Context) (err error) {
 		return err
 	}
 
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(io.Out, machine)
+	}
+
+	mConfig := machine.GetConfig()
+
 	checksRows := [][]string{}
 	checksTotal := 0
 	checksPassing := 0
@@ -79,10 +88,16 @@ func runMachineStatus(ctx context.Context) (err error) {
 			roleOutput = c.Output
 		}
 
+		updatedAt := ""
+		if c.UpdatedAt != nil {
+			updatedAt = format.RelativeTime(*c.UpdatedAt)
+		}
+
 		fields := []string{
 			c.Name,
+			checkType(mConfig, c.Name),
 			string(c.Status),
-			format.RelativeTime(*c.UpdatedAt),
+			updatedAt,
 			c.Output,
 		}
 		checksRows = append(checksRows, fields)
@@ -93,8 +108,6 @@ func runMachineStatus(ctx context.Context) (err error) {
 		checksSummary = fmt.Sprintf("%d/%d", checksPassing, checksTotal)
 	}
 
-	mConfig := machine.GetConfig()
-
 	fmt.Fprintf(io.Out, "Machine ID: %s\n", machine.ID)
 	fmt.Fprintf(io.Out, "Instance ID: %s\n", machine.InstanceID)
 	fmt.Fprintf(io.Out, "State: %s\n", machine.State)
@@ -143,7 +156,7 @@ func runMachineStatus(ctx context.Context) (err error) {
 
 	checksTableTitle := fmt.Sprintf("Checks [%s]", checksSummary)
 	if len(checksRows) > 0 {
-		_ = render.Table(io.Out, checksTableTitle, checksRows, "Name", "Status", "Last Updated", "Output")
+		_ = render.Table(io.Out, checksTableTitle, checksRows, "Name", "Type", "Status", "Last Updated", "Output")
 	}
 
 	eventLogs := [][]string{}
@@ -188,3 +201,12 @@ func runMachineStatus(ctx context.Context) (err error) {
 
 	return
 }
+
+// checkType returns the type (tcp, http) of a machine-level check, or an
+// empty string for checks defined on services.
+func checkType(mConfig *fly.MachineConfig, name string) string {
+	if check, ok := mConfig.Checks[name]; ok && check.Type != nil {
+		return *check.Type
+	}
+	return ""
+}
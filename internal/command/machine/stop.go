@@ -3,7 +3,6 @@ package machine
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -38,11 +37,11 @@ func newStop() *cobra.Command {
 		flag.String{
 			Name:        "signal",
 			Shorthand:   "s",
-			Description: "Signal to stop the machine with (default: SIGINT)",
+			Description: "Signal to stop the machine with, like SIGTERM or term. Defaults to the machine's kill_signal, or SIGINT",
 		},
 		flag.Int{
 			Name:        "timeout",
-			Description: "Seconds to wait before sending SIGKILL to the machine",
+			Description: "Seconds to wait after the stop signal before sending SIGKILL to the machine. Defaults to the machine's kill_timeout",
 		},
 		flag.Duration{
 			Name:        "wait-timeout",
@@ -50,6 +49,7 @@ func newStop() *cobra.Command {
 			Description: "Time duration to wait for individual machines to transition states and become stopped.",
 			Default:     0 * time.Second,
 		},
+		parallelismFlag(4),
 	)
 
 	return cmd
@@ -63,6 +63,17 @@ func runMachineStop(ctx context.Context) (err error) {
 		timeout = flag.GetInt(ctx, "timeout")
 	)
 
+	// Validate before leasing the machines, so that a typo doesn't take the
+	// leases for nothing.
+	if signal != "" {
+		if signal, err = flapsutil.ParseSignal(signal); err != nil {
+			return err
+		}
+	}
+	if timeout < 0 {
+		return fmt.Errorf("--timeout must be a number of seconds, got %d", timeout)
+	}
+
 	machines, ctx, err := selectManyMachines(ctx, args)
 	if err != nil {
 		return err
@@ -74,21 +85,29 @@ func runMachineStop(ctx context.Context) (err error) {
 		return err
 	}
 
-	for _, machine := range machines {
+	return mach.RunConcurrently(ctx, machines, flag.GetInt(ctx, "parallelism"), func(ctx context.Context, machine *fly.Machine) error {
 		fmt.Fprintf(io.Out, "Sending kill signal to machine %s...\n", machine.ID)
 
-		if err = Stop(ctx, machine, signal, timeout); err != nil {
-			return
+		if err := Stop(ctx, machine, signal, timeout); err != nil {
+			return err
 		}
 		fmt.Fprintf(io.Out, "%s has been successfully stopped\n", machine.ID)
-	}
-	return
+		return nil
+	})
 }
 
+// Stop stops the machine, sending its main process signal and waiting timeout
+// seconds for it to exit before killing it. An empty signal or a zero timeout
+// leave the machine's own settings in effect.
 func Stop(ctx context.Context, machine *fly.Machine, signal string, timeout int) (err error) {
+	if signal != "" {
+		if signal, err = flapsutil.ParseSignal(signal); err != nil {
+			return err
+		}
+	}
 	machineStopInput := fly.StopMachineInput{
 		ID:     machine.ID,
-		Signal: strings.ToUpper(signal),
+		Signal: signal,
 	}
 
 	if timeout > 0 {
@@ -0,0 +1,41 @@
+package machine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/mock"
+)
+
+func TestStop(t *testing.T) {
+	var got []fly.StopMachineInput
+	client := &mock.FlapsClient{
+		StopFunc: func(ctx context.Context, in fly.StopMachineInput, nonce string) error {
+			assert.Equal(t, "nonce", nonce)
+			got = append(got, in)
+			return nil
+		},
+	}
+
+	cmd := newStop()
+	require.NoError(t, cmd.Flags().Parse(nil))
+	ctx := flag.NewContext(context.Background(), cmd.Flags())
+	ctx = flapsutil.NewContextWithClient(ctx, client)
+	machine := &fly.Machine{ID: "m1", LeaseNonce: "nonce"}
+
+	require.NoError(t, Stop(ctx, machine, "term", 30))
+	require.NoError(t, Stop(ctx, machine, "", 0))
+	assert.Equal(t, []fly.StopMachineInput{
+		{ID: "m1", Signal: "SIGTERM", Timeout: fly.Duration{Duration: 30 * time.Second}},
+		{ID: "m1"},
+	}, got)
+
+	assert.Error(t, Stop(ctx, machine, "SIGNOPE", 0))
+	assert.Len(t, got, 2, "an invalid signal isn't sent")
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/flyerr"
 	mach "github.com/superfly/flyctl/internal/machine"
 	"github.com/superfly/flyctl/iostreams"
 )
@@ -17,7 +18,10 @@ import (
 func newSuspend() *cobra.Command {
 	const (
 		short = "Suspend one or more Fly machines"
-		long  = short + "\n"
+		long  = short + `. A suspended machine keeps its memory and resumes
+where it left off with 'fly machine resume'. Machines with GPUs or swap
+can't be suspended; stop them instead.
+`
 
 		usage = "suspend [<id>...]"
 	)
@@ -76,7 +80,31 @@ func runMachineSuspend(ctx context.Context) (err error) {
 	return
 }
 
+// checkSuspendable fails when the machine's guest can't be suspended.
+func checkSuspendable(machine *fly.Machine) error {
+	var reason string
+	switch conf := machine.Config; {
+	case conf == nil:
+		return nil
+	case conf.Guest != nil && (conf.Guest.GPUs > 0 || conf.Guest.GPUKind != ""):
+		reason = "it has a GPU"
+	case conf.Init.SwapSizeMB != nil && *conf.Init.SwapSizeMB > 0:
+		reason = "it has swap enabled"
+	default:
+		return nil
+	}
+
+	return flyerr.GenericErr{
+		Err:     fmt.Sprintf("machine %s can't be suspended because %s", machine.ID, reason),
+		Suggest: fmt.Sprintf("Stop it instead with 'fly machine stop %s'", machine.ID),
+	}
+}
+
 func suspend(ctx context.Context, machine *fly.Machine, waitTimeout time.Duration) error {
+	if err := checkSuspendable(machine); err != nil {
+		return err
+	}
+
 	client := flapsutil.ClientFromContext(ctx)
 	if err := client.Suspend(ctx, machine.ID, machine.LeaseNonce); err != nil {
 		if err := rewriteMachineNotFoundErrors(ctx, err, machine.ID); err != nil {
@@ -86,12 +114,11 @@ func suspend(ctx context.Context, machine *fly.Machine, waitTimeout time.Duratio
 	}
 
 	if waitTimeout != 0 {
-		machine, err := client.Get(ctx, machine.ID)
+		m, err := client.Get(ctx, machine.ID)
 		if err != nil {
 			return fmt.Errorf("could not get Machine %s to wait for suspension: %w", machine.ID, err)
 		}
-		err = client.Wait(ctx, machine, "suspended", waitTimeout)
-		if err != nil {
+		if err := mach.WaitForStartOrStop(ctx, m, "suspend", waitTimeout); err != nil {
 			return fmt.Errorf("Machine %s was not suspended within the wait timeout: %w", machine.ID, err)
 		}
 	}
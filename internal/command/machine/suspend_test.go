@@ -0,0 +1,33 @@
+package machine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	fly "github.com/superfly/fly-go"
+)
+
+func TestCheckSuspendable(t *testing.T) {
+	swap := 512
+
+	assert.NoError(t, checkSuspendable(&fly.Machine{ID: "m1", Config: &fly.MachineConfig{
+		Guest: &fly.MachineGuest{CPUKind: "shared", CPUs: 1, MemoryMB: 256},
+	}}))
+	assert.NoError(t, checkSuspendable(&fly.Machine{ID: "m1"}))
+
+	err := checkSuspendable(&fly.Machine{ID: "m1", Config: &fly.MachineConfig{
+		Guest: &fly.MachineGuest{GPUKind: "a10", GPUs: 1},
+	}})
+	assert.EqualError(t, err, "machine m1 can't be suspended because it has a GPU")
+
+	err = checkSuspendable(&fly.Machine{ID: "m1", Config: &fly.MachineConfig{
+		Init: fly.MachineInit{SwapSizeMB: &swap},
+	}})
+	assert.EqualError(t, err, "machine m1 can't be suspended because it has swap enabled")
+}
+
+func TestCheckResumable(t *testing.T) {
+	assert.NoError(t, checkResumable(&fly.Machine{ID: "m1", State: fly.MachineStateSuspended}))
+	assert.EqualError(t, checkResumable(&fly.Machine{ID: "m1", State: fly.MachineStateStopped}),
+		"machine m1 is stopped, not suspended; use 'fly machine start' to start it")
+}
@@ -3,6 +3,8 @@ package machine
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -15,15 +17,17 @@ import (
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/flyerr"
 	mach "github.com/superfly/flyctl/internal/machine"
+	"github.com/superfly/flyctl/internal/urls"
 	"github.com/superfly/flyctl/internal/watch"
 )
 
 func newUpdate() *cobra.Command {
 	const (
 		short = "Update a machine"
-		long  = short + "\n"
+		long  = short + `. Arguments after -- replace the machine's command,
+e.g. 'fly machine update <id> -- bin/worker --queue default'.`
 
-		usage = "update [machine_id]"
+		usage = "update [machine_id] [-- <command> [args...]]"
 	)
 
 	cmd := command.New(usage, short, long, runUpdate,
@@ -50,7 +54,7 @@ func newUpdate() *cobra.Command {
 		flag.String{
 			Name:        "command",
 			Shorthand:   "C",
-			Description: "Command to run",
+			Description: "Command to run. Alternatively, pass the command and its arguments after --",
 		},
 		flag.String{
 			Name:        "mount-point",
@@ -63,7 +67,13 @@ func newUpdate() *cobra.Command {
 		},
 	)
 
-	cmd.Args = cobra.RangeArgs(0, 1)
+	cmd.Args = func(cmd *cobra.Command, args []string) error {
+		// Arguments after `--` are the machine's command.
+		if n := cmd.ArgsLenAtDash(); n >= 0 {
+			args = args[:n]
+		}
+		return cobra.RangeArgs(0, 1)(cmd, args)
+	}
 
 	return cmd
 }
@@ -80,8 +90,12 @@ func runUpdate(ctx context.Context) (err error) {
 		dockerfile       = flag.GetString(ctx, flag.Dockerfile().Name)
 	)
 
-	machineID := flag.FirstArg(ctx)
-	haveMachineID := len(flag.Args(ctx)) > 0
+	machineArgs, _ := splitArgsAtDash(ctx)
+	machineID := ""
+	if len(machineArgs) > 0 {
+		machineID = machineArgs[0]
+	}
+	haveMachineID := len(machineArgs) > 0
 	machine, ctx, err := selectOneMachine(ctx, "", machineID, haveMachineID)
 	if err != nil {
 		return err
@@ -99,6 +113,12 @@ func runUpdate(ctx context.Context) (err error) {
 		return err
 	}
 
+	previous := savedMachineConfig{
+		SavedAt:    time.Now(),
+		InstanceID: machine.InstanceID,
+		Config:     mach.CloneConfig(machine.Config),
+	}
+
 	var imageOrPath string
 	if image != "" {
 		imageOrPath = image
@@ -125,6 +145,14 @@ func runUpdate(ctx context.Context) (err error) {
 		machineConf.Mounts[0].Path = mp
 	}
 
+	if err := checkMachineConfig(ctx, machineConf, machine.Region, true); err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(machine.Config.Mounts, machineConf.Mounts) {
+		fmt.Fprintf(io.ErrOut, "%s Changing the mounts of machine %s requires restarting it\n", colorize.WarningIcon(), machine.ID)
+	}
+
 	// Prompt user to confirm changes
 	if !autoConfirm {
 		confirmed, err := mach.ConfirmConfigChanges(ctx, machine, *machineConf, "")
@@ -159,6 +187,12 @@ func runUpdate(ctx context.Context) (err error) {
 		return err
 	}
 
+	if err := pushMachineConfig(ctx, appName, machine.ID, previous); err != nil {
+		fmt.Fprintf(io.ErrOut, "Warning: failed to save the previous machine config: %v\n", err)
+	} else {
+		fmt.Fprintf(io.Out, "Previous config saved, run `fly machine rollback %s` to restore it\n", machine.ID)
+	}
+
 	if !(input.SkipLaunch || flag.GetDetach(ctx)) {
 		fmt.Fprintln(io.Out, colorize.Green("==> "+"Monitoring health checks"))
 
@@ -168,7 +202,7 @@ func runUpdate(ctx context.Context) (err error) {
 		fmt.Fprintln(io.Out)
 	}
 
-	fmt.Fprintf(io.Out, "\nMonitor machine status here:\nhttps://fly.io/apps/%s/machines/%s\n", appName, machine.ID)
+	fmt.Fprintf(io.Out, "\nMonitor machine status here:\n%s\n", urls.Machine(appName, machine.ID))
 
 	return nil
 }
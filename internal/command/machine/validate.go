@@ -0,0 +1,111 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/flyerr"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+var validRestartPolicies = []fly.MachineRestartPolicy{
+	fly.MachineRestartPolicyNo,
+	fly.MachineRestartPolicyOnFailure,
+	fly.MachineRestartPolicyAlways,
+	fly.MachineRestartPolicySpotPrice,
+}
+
+// checkMachineConfig validates conf for a machine in region, fetching the
+// app's volumes when conf mounts any. When updating a machine, max retries set
+// with a policy other than on-failure is only warned about, since existing
+// machines may have been created with it.
+func checkMachineConfig(ctx context.Context, conf *fly.MachineConfig, region string, update bool) error {
+	var volumes []fly.Volume
+	if len(conf.Mounts) > 0 {
+		var err error
+		if volumes, err = flapsutil.ClientFromContext(ctx).GetVolumes(ctx); err != nil {
+			return fmt.Errorf("could not list volumes to check the machine's mounts: %w", err)
+		}
+	}
+	if err := validateMachineConfig(conf, region, volumes); err != nil {
+		return err
+	}
+
+	err := validateRestartRetries(conf.Restart)
+	if err == nil || !update {
+		return err
+	}
+	io := iostreams.FromContext(ctx)
+	fmt.Fprintf(io.ErrOut, "%s %s. %s\n", io.ColorScheme().WarningIcon(), err, flyerr.GetErrorSuggestion(err))
+	return nil
+}
+
+// validateMachineConfig catches the mistakes in conf the Machines API would
+// otherwise reject with a less helpful message: services without an internal
+// port, unknown restart policies and mounts of volumes that don't exist or
+// live in another region. volumes are the app's volumes.
+func validateMachineConfig(conf *fly.MachineConfig, region string, volumes []fly.Volume) error {
+	for i, service := range conf.Services {
+		if service.InternalPort < 1 || service.InternalPort > 65535 {
+			return flyerr.GenericErr{
+				Err:     fmt.Sprintf("service %d (%s) has no valid internal port", i+1, service.Protocol),
+				Suggest: "Set the port the app listens on with --port, e.g. --port 443:8080/tcp:tls:http",
+			}
+		}
+	}
+
+	if restart := conf.Restart; restart != nil {
+		if restart.Policy != "" && !slices.Contains(validRestartPolicies, restart.Policy) {
+			return flyerr.GenericErr{
+				Err:     fmt.Sprintf("invalid restart policy %q", restart.Policy),
+				Suggest: "Use one of: no, always, on-failure, spot-price",
+			}
+		}
+		if restart.MaxRetries < 0 {
+			return flyerr.GenericErr{
+				Err:     fmt.Sprintf("restart max retries can't be negative, got %d", restart.MaxRetries),
+				Suggest: "Set max retries to 0 or more",
+			}
+		}
+	}
+
+	for _, mount := range conf.Mounts {
+		if mount.Volume == "" {
+			return flyerr.GenericErr{
+				Err:     fmt.Sprintf("mount at %s doesn't reference a volume", mount.Path),
+				Suggest: "Attach an existing volume ID; list them with 'fly volumes list'",
+			}
+		}
+
+		i := slices.IndexFunc(volumes, func(v fly.Volume) bool { return v.ID == mount.Volume })
+		if i < 0 {
+			return flyerr.GenericErr{
+				Err:     fmt.Sprintf("volume %s mounted at %s doesn't exist in this app", mount.Volume, mount.Path),
+				Suggest: "Check the volume ID with 'fly volumes list', or create one with 'fly volumes create'",
+			}
+		}
+		if volume := volumes[i]; region != "" && volume.Region != region {
+			return flyerr.GenericErr{
+				Err:     fmt.Sprintf("volume %s is in region %s but the machine is in %s", volume.ID, volume.Region, region),
+				Suggest: "Volumes can only be mounted by machines in the same region; fork it to the machine's region with 'fly volumes fork'",
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateRestartRetries catches max retries set with a restart policy that
+// doesn't retry on failure, which the Machines API ignores.
+func validateRestartRetries(restart *fly.MachineRestart) error {
+	if restart == nil || restart.MaxRetries <= 0 || restart.Policy == "" || restart.Policy == fly.MachineRestartPolicyOnFailure {
+		return nil
+	}
+	return flyerr.GenericErr{
+		Err:     fmt.Sprintf("restart max retries only applies to the on-failure policy, not %q", restart.Policy),
+		Suggest: "Use the on-failure restart policy, or drop max retries",
+	}
+}
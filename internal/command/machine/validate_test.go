@@ -0,0 +1,98 @@
+package machine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestValidateMachineConfig(t *testing.T) {
+	volumes := []fly.Volume{
+		{ID: "vol_ord", Region: "ord"},
+		{ID: "vol_ams", Region: "ams"},
+	}
+
+	cases := []struct {
+		name string
+		conf fly.MachineConfig
+		err  string
+	}{
+		{
+			name: "valid",
+			conf: fly.MachineConfig{
+				Services: []fly.MachineService{{Protocol: "tcp", InternalPort: 8080}},
+				Restart:  &fly.MachineRestart{Policy: fly.MachineRestartPolicyOnFailure, MaxRetries: 3},
+				Mounts:   []fly.MachineMount{{Volume: "vol_ord", Path: "/data"}},
+			},
+		},
+		{
+			name: "service without internal port",
+			conf: fly.MachineConfig{Services: []fly.MachineService{{Protocol: "tcp"}}},
+			err:  "service 1 (tcp) has no valid internal port",
+		},
+		{
+			name: "service port out of range",
+			conf: fly.MachineConfig{Services: []fly.MachineService{{Protocol: "udp", InternalPort: 70000}}},
+			err:  "service 1 (udp) has no valid internal port",
+		},
+		{
+			name: "unknown restart policy",
+			conf: fly.MachineConfig{Restart: &fly.MachineRestart{Policy: "sometimes"}},
+			err:  `invalid restart policy "sometimes"`,
+		},
+		{
+			name: "negative max retries",
+			conf: fly.MachineConfig{Restart: &fly.MachineRestart{Policy: fly.MachineRestartPolicyOnFailure, MaxRetries: -1}},
+			err:  "restart max retries can't be negative, got -1",
+		},
+		{
+			name: "mount without volume",
+			conf: fly.MachineConfig{Mounts: []fly.MachineMount{{Path: "/data"}}},
+			err:  "mount at /data doesn't reference a volume",
+		},
+		{
+			name: "mount of missing volume",
+			conf: fly.MachineConfig{Mounts: []fly.MachineMount{{Volume: "vol_gone", Path: "/data"}}},
+			err:  "volume vol_gone mounted at /data doesn't exist in this app",
+		},
+		{
+			name: "mount of volume in another region",
+			conf: fly.MachineConfig{Mounts: []fly.MachineMount{{Volume: "vol_ams", Path: "/data"}}},
+			err:  "volume vol_ams is in region ams but the machine is in ord",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateMachineConfig(&tc.conf, "ord", volumes)
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestCheckMachineConfigRestartRetries(t *testing.T) {
+	conf := &fly.MachineConfig{Restart: &fly.MachineRestart{Policy: fly.MachineRestartPolicyAlways, MaxRetries: 2}}
+
+	ios, _, _, errOut := iostreams.Test()
+	ctx := iostreams.NewContext(context.Background(), ios)
+
+	// Creating a machine with it fails.
+	err := checkMachineConfig(ctx, conf, "ord", false)
+	assert.EqualError(t, err, `restart max retries only applies to the on-failure policy, not "always"`)
+	assert.Empty(t, errOut.String())
+
+	// Updating one, which may have been created with it, only warns.
+	require.NoError(t, checkMachineConfig(ctx, conf, "ord", true))
+	assert.Contains(t, errOut.String(), `restart max retries only applies to the on-failure policy, not "always". Use the on-failure restart policy, or drop max retries`)
+
+	conf.Restart.Policy = fly.MachineRestartPolicyOnFailure
+	assert.NoError(t, checkMachineConfig(ctx, conf, "ord", false))
+}
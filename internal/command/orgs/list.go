@@ -1,11 +1,11 @@
 package orgs
 
 import (
-	"bytes"
 	"context"
 
 	"github.com/spf13/cobra"
 
+	"github.com/superfly/flyctl/gql"
 	"github.com/superfly/flyctl/iostreams"
 
 	"github.com/superfly/flyctl/internal/command"
@@ -17,7 +17,8 @@ import (
 
 func newList() *cobra.Command {
 	const (
-		long = `Lists organizations available to current user.
+		long = `Lists organizations available to current user, along with your
+role in each. The organization used by default is marked.
 `
 		short = "Lists organizations for current user"
 	)
@@ -26,44 +27,98 @@ func newList() *cobra.Command {
 		command.RequireSession,
 	)
 
-	flag.Add(cmd, flag.JSONOutput())
+	flag.Add(cmd, flag.JSONOutput(), flag.ListOutput())
 	cmd.Aliases = []string{"ls"}
 	return cmd
 }
 
+type listedOrg = gql.ListOrganizationsOrganizationsOrganizationConnectionNodesOrganization
+
+// listColumns are the columns of the list, for --columns and --sort.
+var listColumns = []string{"Name", "Slug", "Type", "Role"}
+
 func runList(ctx context.Context) error {
-	client := flyutil.ClientFromContext(ctx)
+	client := flyutil.ClientFromContext(ctx).GenqClient()
 
-	orgs, err := client.GetOrganizations(ctx)
+	listOpts, err := render.ListOptionsFromContext(ctx, listColumns...)
 	if err != nil {
 		return err
 	}
 
-	out := iostreams.FromContext(ctx).Out
-
-	if config.FromContext(ctx).JSONOutput {
-		bySlug := map[string]string{}
-
-		for _, other := range orgs {
-			bySlug[other.Slug] = other.Name
+	_ = `# @genqlient
+	query ListOrganizations {
+		organizations {
+			nodes {
+				slug
+				name
+				type
+				viewerRole
+			}
 		}
+	}
+	`
 
-		_ = render.JSON(out, bySlug)
-
-		return nil
+	resp, err := gql.ListOrganizations(ctx, client)
+	if err != nil {
+		return err
 	}
+	orgs := resp.Organizations.Nodes
 
 	var (
-		b     bytes.Buffer
-		first = true
+		out         = iostreams.FromContext(ctx).Out
+		defaultSlug = defaultOrgSlug(orgs, config.FromContext(ctx).Organization)
 	)
 
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(out, jsonOrgs(orgs, defaultSlug))
+	}
+
+	rows := formatOrgs(orgs, defaultSlug)
+	return render.List(out, listOpts, "", rows, listColumns...)
+}
+
+type jsonOrg struct {
+	Name    string `json:"name"`
+	Slug    string `json:"slug"`
+	Type    string `json:"type"`
+	Role    string `json:"role"`
+	Default bool   `json:"default"`
+}
+
+func jsonOrgs(orgs []listedOrg, defaultSlug string) []jsonOrg {
+	ret := make([]jsonOrg, 0, len(orgs))
 	for _, org := range orgs {
-		printOrg(&b, &org, first)
-		first = false
+		ret = append(ret, jsonOrg{
+			Name:    org.Name,
+			Slug:    org.Slug,
+			Type:    string(org.Type),
+			Role:    org.ViewerRole,
+			Default: org.Slug == defaultSlug,
+		})
 	}
+	return ret
+}
 
-	b.WriteTo(out)
+// defaultOrgSlug returns the slug of the organization commands will use
+// without asking: the configured one, or the only one there is.
+func defaultOrgSlug(orgs []listedOrg, configured string) string {
+	if configured != "" {
+		return configured
+	}
+	if len(orgs) == 1 {
+		return orgs[0].Slug
+	}
+	return ""
+}
 
-	return nil
+func formatOrgs(orgs []listedOrg, defaultSlug string) [][]string {
+	rows := make([][]string, 0, len(orgs))
+	for _, org := range orgs {
+		name := org.Name
+		if org.Slug == defaultSlug {
+			name += " (default)"
+		}
+		rows = append(rows, []string{name, org.Slug, string(org.Type), org.ViewerRole})
+	}
+	return rows
 }
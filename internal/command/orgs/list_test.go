@@ -0,0 +1,39 @@
+package orgs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/superfly/flyctl/gql"
+)
+
+func TestFormatOrgs(t *testing.T) {
+	personal := listedOrg{Slug: "personal", Name: "Jane Doe", Type: gql.OrganizationTypePersonal, ViewerRole: "admin"}
+	acme := listedOrg{Slug: "acme", Name: "Acme", Type: gql.OrganizationTypeShared, ViewerRole: "member"}
+
+	// The only organization is the default.
+	assert.Equal(t, [][]string{
+		{"Jane Doe (default)", "personal", "PERSONAL", "admin"},
+	}, formatOrgs([]listedOrg{personal}, defaultOrgSlug([]listedOrg{personal}, "")))
+
+	// With several, only the configured one is.
+	orgs := []listedOrg{personal, acme}
+	assert.Empty(t, defaultOrgSlug(orgs, ""))
+	assert.Equal(t, [][]string{
+		{"Jane Doe", "personal", "PERSONAL", "admin"},
+		{"Acme (default)", "acme", "SHARED", "member"},
+	}, formatOrgs(orgs, defaultOrgSlug(orgs, "acme")))
+}
+
+func TestJSONOrgs(t *testing.T) {
+	orgs := []listedOrg{
+		{Slug: "personal", Name: "Jane Doe", Type: gql.OrganizationTypePersonal, ViewerRole: "admin"},
+		{Slug: "acme", Name: "Acme", Type: gql.OrganizationTypeShared, ViewerRole: "member"},
+	}
+
+	assert.Equal(t, []jsonOrg{
+		{Name: "Jane Doe", Slug: "personal", Type: "PERSONAL", Role: "admin"},
+		{Name: "Acme", Slug: "acme", Type: "SHARED", Role: "member", Default: true},
+	}, jsonOrgs(orgs, "acme"))
+}
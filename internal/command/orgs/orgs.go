@@ -3,7 +3,6 @@ package orgs
 import (
 	"context"
 	"fmt"
-	"io"
 
 	"github.com/spf13/cobra"
 
@@ -109,6 +108,10 @@ func OrgFromEnvVarOrFirstArgOrSelect(ctx context.Context, filters ...fly.Organiz
 }
 
 func OrgFromFlagOrSelect(ctx context.Context, filters ...fly.OrganizationFilter) (*fly.Organization, error) {
+	if org := prompt.CachedOrg(ctx, flag.GetOrg(ctx)); org != nil {
+		return org, nil
+	}
+
 	slug, err := slugFromArgOrSelect(ctx, flag.GetOrg(ctx), filters...)
 	if err != nil {
 		return nil, err
@@ -118,21 +121,26 @@ func OrgFromFlagOrSelect(ctx context.Context, filters ...fly.OrganizationFilter)
 }
 
 func OrgFromSlug(ctx context.Context, slug string) (*fly.Organization, error) {
+	if org := prompt.CachedOrg(ctx, slug); org != nil {
+		return org, nil
+	}
+
 	client := flyutil.ClientFromContext(ctx)
 
 	org, err := client.GetOrganizationBySlug(ctx, slug)
 	if err != nil {
-		return nil, fmt.Errorf("failed retrieving organization with slug %s: %w", slug, err)
+		// fall back to matching on the display name, which users often
+		// remember better than the slug
+		byName, nameErr := prompt.FindOrg(ctx, slug)
+		if nameErr != nil {
+			if prompt.IsNonInteractive(nameErr) {
+				return nil, nameErr
+			}
+			return nil, fmt.Errorf("failed retrieving organization with slug %s: %w", slug, err)
+		}
+		org = byName
 	}
 
+	prompt.CacheOrg(ctx, org)
 	return org, nil
 }
-
-func printOrg(w io.Writer, org *fly.Organization, headers bool) {
-	if headers {
-		fmt.Fprintf(w, "%-20s %-20s %-10s\n", "Name", "Slug", "Type")
-		fmt.Fprintf(w, "%-20s %-20s %-10s\n", "----", "----", "----")
-	}
-
-	fmt.Fprintf(w, "%-20s %-20s %-10s\n", org.Name, org.Slug, org.Type)
-}
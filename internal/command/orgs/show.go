@@ -8,6 +8,8 @@ import (
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
@@ -48,9 +50,26 @@ func runShow(ctx context.Context) (err error) {
 		return err
 	}
 
+	_ = `# @genqlient
+	query GetOrganizationBillingStatus($slug: String!) {
+		organization(slug: $slug) {
+			billingStatus
+		}
+	}
+	`
+
+	billing, err := gql.GetOrganizationBillingStatus(ctx, client.GenqClient(), org.Slug)
+	if err != nil {
+		return err
+	}
+	billingStatus := string(billing.Organization.BillingStatus)
+
 	io := iostreams.FromContext(ctx)
 	if config.FromContext(ctx).JSONOutput {
-		_ = render.JSON(io.Out, org)
+		_ = render.JSON(io.Out, struct {
+			*fly.OrganizationDetails
+			BillingStatus string
+		}{org, billingStatus})
 
 		return nil
 	}
@@ -62,6 +81,7 @@ func runShow(ctx context.Context) (err error) {
 	fmt.Fprintf(&buf, "%-10s: %-20s\n", "Name", org.Name)
 	fmt.Fprintf(&buf, "%-10s: %-20s\n", "Slug", org.Slug)
 	fmt.Fprintf(&buf, "%-10s: %-20s\n", "Type", org.Type)
+	fmt.Fprintf(&buf, "%-10s: %-20s\n", "Billing", billingStatus)
 	fmt.Fprintln(&buf)
 
 	fmt.Fprintln(&buf, colorize.Bold("Summary"))
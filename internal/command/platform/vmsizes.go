@@ -10,6 +10,7 @@ import (
 
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/iostreams"
@@ -32,6 +33,15 @@ func newVMSizes() (cmd *cobra.Command) {
 	return
 }
 
+type vmSize struct {
+	Name     string `json:"name"`
+	CPUKind  string `json:"cpu_kind"`
+	CPUs     int    `json:"cpus"`
+	MemoryMB int    `json:"memory_mb"`
+	GPUKind  string `json:"gpu_kind,omitempty"`
+	GPUs     int    `json:"gpus,omitempty"`
+}
+
 func runMachineVMSizes(ctx context.Context) error {
 	out := iostreams.FromContext(ctx).Out
 
@@ -63,6 +73,20 @@ func runMachineVMSizes(ctx context.Context) error {
 		}
 	})
 
+	if config.FromContext(ctx).JSONOutput {
+		sizes := lo.Map(sortedPresets, func(p preset, _ int) vmSize {
+			return vmSize{
+				Name:     p.strings[0],
+				CPUKind:  p.guest.CPUKind,
+				CPUs:     p.guest.CPUs,
+				MemoryMB: p.guest.MemoryMB,
+				GPUKind:  p.guest.GPUKind,
+				GPUs:     p.guest.GPUs,
+			}
+		})
+		return render.JSON(out, sizes)
+	}
+
 	// Filter and display shared cpu sizes.
 	shared := lo.FilterMap(sortedPresets, func(p preset, _ int) ([]string, bool) {
 		return p.strings, p.guest.CPUKind == "shared" && p.guest.GPUKind == ""
@@ -37,6 +37,7 @@ func newConfigUpdate() (cmd *cobra.Command) {
 	flag.Add(cmd,
 		flag.App(),
 		flag.AppConfig(),
+		mach.LeaseTTLFlag(),
 		flag.Detach(),
 		flag.String{
 			Name:        "max-connections",
@@ -94,6 +95,11 @@ func runConfigUpdate(ctx context.Context) error {
 		appName = appconfig.NameFromContext(ctx)
 	)
 
+	ctx, err := mach.WithLeaseTTLFlag(ctx)
+	if err != nil {
+		return err
+	}
+
 	app, err := client.GetAppCompact(ctx, appName)
 	if err != nil {
 		return fmt.Errorf("failed retrieving app %s: %w", appName, err)
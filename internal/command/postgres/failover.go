@@ -42,6 +42,7 @@ func newFailover() *cobra.Command {
 		cmd,
 		flag.App(),
 		flag.AppConfig(),
+		mach.LeaseTTLFlag(),
 		flag.Bool{
 			Name:        "force",
 			Description: "Force a failover even if we can't connect to the active leader",
@@ -68,6 +69,11 @@ func runFailover(ctx context.Context) (err error) {
 		appName = appconfig.NameFromContext(ctx)
 	)
 
+	ctx, err = mach.WithLeaseTTLFlag(ctx)
+	if err != nil {
+		return err
+	}
+
 	app, err := client.GetAppCompact(ctx, appName)
 	if err != nil {
 		return fmt.Errorf("get app: %w", err)
@@ -25,11 +25,14 @@ func newList() *cobra.Command {
 
 	cmd := command.New(usage, short, long, runList)
 
-	flag.Add(cmd, flag.JSONOutput())
+	flag.Add(cmd, flag.JSONOutput(), flag.ListOutput())
 	cmd.Aliases = []string{"ls"}
 	return cmd
 }
 
+// listColumns are the columns of the list, for --columns and --sort.
+var listColumns = []string{"Name", "Owner", "Status", "Latest Deploy"}
+
 func runList(ctx context.Context) (err error) {
 	var (
 		client = flyutil.ClientFromContext(ctx)
@@ -37,6 +40,11 @@ func runList(ctx context.Context) (err error) {
 		cfg    = config.FromContext(ctx)
 	)
 
+	listOpts, err := render.ListOptionsFromContext(ctx, listColumns...)
+	if err != nil {
+		return err
+	}
+
 	apps, err := client.GetApps(ctx, fly.StringPointer("postgres_cluster"))
 	if err != nil {
 		return fmt.Errorf("failed to list postgres clusters: %w", err)
@@ -67,7 +75,5 @@ func runList(ctx context.Context) (err error) {
 		})
 	}
 
-	_ = render.Table(io.Out, "", rows, "Name", "Owner", "Status", "Latest Deploy")
-
-	return
+	return render.List(io.Out, listOpts, "", rows, listColumns...)
 }
@@ -32,6 +32,7 @@ func newRenewSSHCerts() *cobra.Command {
 	flag.Add(cmd,
 		flag.App(),
 		flag.AppConfig(),
+		mach.LeaseTTLFlag(),
 		flag.Int{
 			Name:        "valid-days",
 			Description: "The number of days the certificate should be valid for.",
@@ -48,6 +49,11 @@ func runRefreshSSHCerts(ctx context.Context) error {
 		client  = flyutil.ClientFromContext(ctx)
 	)
 
+	ctx, err := mach.WithLeaseTTLFlag(ctx)
+	if err != nil {
+		return err
+	}
+
 	app, err := client.GetAppCompact(ctx, appName)
 	if err != nil {
 		return err
@@ -32,6 +32,7 @@ func newRestart() *cobra.Command {
 	flag.Add(cmd,
 		flag.App(),
 		flag.AppConfig(),
+		mach.LeaseTTLFlag(),
 		flag.Bool{
 			Name:        "force",
 			Description: "Force a restart even we don't have an active leader",
@@ -53,6 +54,11 @@ func runRestart(ctx context.Context) error {
 		client  = flyutil.ClientFromContext(ctx)
 	)
 
+	ctx, err := mach.WithLeaseTTLFlag(ctx)
+	if err != nil {
+		return err
+	}
+
 	app, err := client.GetAppCompact(ctx, appName)
 	if err != nil {
 		return err
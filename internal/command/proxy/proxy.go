@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -17,19 +19,25 @@ import (
 	"github.com/superfly/flyctl/internal/prompt"
 	"github.com/superfly/flyctl/iostreams"
 	"github.com/superfly/flyctl/proxy"
+	"golang.org/x/sync/errgroup"
 )
 
 func New() *cobra.Command {
 	var (
 		long = strings.Trim(`Proxies connections to a Fly Machine through a WireGuard tunnel. By default,
-connects to the first Machine address returned by an internal DNS query on the app.`, "\n")
+connects to the first Machine address returned by an internal DNS query on the app.
+
+Each mapping is one of local_port, local_port:remote_port or
+local_port:remote_host:remote_port. Several mappings can be proxied at once:
+
+  fly proxy 5432:my-db.internal:5432 8080:my-app.internal:80`, "\n")
 		short = `Proxies connections to a Fly Machine.`
 	)
 
-	cmd := command.New("proxy <local:remote> [remote_host]", short, long, run,
+	cmd := command.New("proxy <local:[remote_host:]remote>... [remote_host]", short, long, run,
 		command.RequireSession, command.LoadAppNameIfPresent)
 
-	cmd.Args = cobra.RangeArgs(1, 2)
+	cmd.Args = cobra.MinimumNArgs(1)
 
 	flag.Add(cmd,
 		flag.App(),
@@ -120,29 +128,116 @@ func run(ctx context.Context) (err error) {
 		return err
 	}
 
-	ports := strings.Split(args[0], ":")
+	defaultHost := fmt.Sprintf("%s.internal", appName)
+	if host, ok := remoteHostArg(args); ok {
+		defaultHost = host
+		args = args[:1]
+	}
 
-	params := &proxy.ConnectParams{
-		BindAddr:         flag.GetBindAddr(ctx),
-		Ports:            ports,
-		AppName:          appName,
-		OrganizationSlug: orgSlug,
-		Dialer:           dialer,
-		PromptInstance:   promptInstance,
-		Network:          *network,
+	mappings := make([]portMapping, 0, len(args))
+	for _, arg := range args {
+		m, err := parsePortMapping(arg)
+		if err != nil {
+			return err
+		}
+		mappings = append(mappings, m)
 	}
 
-	if len(args) > 1 {
-		params.RemoteHost = args[1]
-	} else {
-		params.RemoteHost = fmt.Sprintf("%s.internal", appName)
+	if promptInstance && len(mappings) > 1 {
+		return errors.New("--select can only be used with a single port mapping")
 	}
 
 	if flag.GetBool(ctx, "watch-stdin") {
 		ctx = watchStdinAndAbortOnClose(ctx)
 	}
 
-	return proxy.Connect(ctx, params)
+	servers := make([]*proxy.Server, 0, len(mappings))
+	for _, m := range mappings {
+		params := &proxy.ConnectParams{
+			BindAddr:         flag.GetBindAddr(ctx),
+			Ports:            m.ports,
+			AppName:          appName,
+			OrganizationSlug: orgSlug,
+			Dialer:           dialer,
+			PromptInstance:   promptInstance,
+			Network:          *network,
+			RemoteHost:       m.host,
+		}
+		if params.RemoteHost == "" {
+			params.RemoteHost = defaultHost
+		}
+
+		server, err := proxy.NewServer(ctx, params)
+		if err != nil {
+			for _, s := range servers {
+				s.Listener.Close()
+			}
+			return err
+		}
+		servers = append(servers, server)
+	}
+
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, server := range servers {
+		eg.Go(func() error {
+			return server.ProxyServer(ctx)
+		})
+	}
+	return eg.Wait()
+}
+
+// remoteHostArg returns the remote host of the original form, which takes a
+// single mapping and the remote host as a separate argument. The host may be
+// an IPv6 address, bracketed or not, so it's told apart from a mapping by
+// parsing it as an IP first.
+func remoteHostArg(args []string) (string, bool) {
+	if len(args) != 2 {
+		return "", false
+	}
+
+	host := args[1]
+	if ip := strings.TrimSuffix(strings.TrimPrefix(host, "["), "]"); net.ParseIP(ip) != nil {
+		return ip, true
+	}
+	if strings.Contains(host, ":") || isPort(host) {
+		return "", false
+	}
+	return host, true
+}
+
+type portMapping struct {
+	ports []string
+	host  string
+}
+
+// parsePortMapping parses local, local:remote or local:host:remote. The host
+// may be a bracketed IPv6 address.
+func parsePortMapping(arg string) (portMapping, error) {
+	first := strings.Index(arg, ":")
+	last := strings.LastIndex(arg, ":")
+
+	switch {
+	case first < 0:
+		return portMapping{ports: []string{arg}}, nil
+	case first == last:
+		return portMapping{ports: []string{arg[:first], arg[last+1:]}}, nil
+	}
+
+	local, host, remote := arg[:first], arg[first+1:last], arg[last+1:]
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	if local == "" || host == "" || remote == "" {
+		return portMapping{}, fmt.Errorf("invalid port mapping %q, expected local_port:remote_host:remote_port", arg)
+	}
+	if !isPort(remote) {
+		return portMapping{}, fmt.Errorf("invalid remote port in %q", arg)
+	}
+
+	return portMapping{ports: []string{local, remote}, host: host}, nil
+}
+
+func isPort(s string) bool {
+	_, err := strconv.ParseUint(s, 10, 16)
+	return err == nil
 }
 
 // Asynchronously watches stdin and abort when it closes.
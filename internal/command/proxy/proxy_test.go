@@ -0,0 +1,56 @@
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePortMapping(t *testing.T) {
+	cases := []struct {
+		arg   string
+		ports []string
+		host  string
+	}{
+		{"5432", []string{"5432"}, ""},
+		{"15432:5432", []string{"15432", "5432"}, ""},
+		{"15432:my-db.internal:5432", []string{"15432", "5432"}, "my-db.internal"},
+		{"8080:[fdaa:0:1::3]:80", []string{"8080", "80"}, "fdaa:0:1::3"},
+	}
+
+	for _, c := range cases {
+		m, err := parsePortMapping(c.arg)
+		require.NoError(t, err, c.arg)
+		assert.Equal(t, c.ports, m.ports, c.arg)
+		assert.Equal(t, c.host, m.host, c.arg)
+	}
+
+	for _, arg := range []string{"5432::5432", ":db:5432", "5432:db:", "5432:db:http"} {
+		_, err := parsePortMapping(arg)
+		assert.Error(t, err, arg)
+	}
+}
+
+func TestRemoteHostArg(t *testing.T) {
+	cases := []struct {
+		args []string
+		host string
+		ok   bool
+	}{
+		{[]string{"5432", "my-db.internal"}, "my-db.internal", true},
+		{[]string{"5432", "fdaa:0:1::2"}, "fdaa:0:1::2", true},
+		{[]string{"5432:5433", "[fdaa:0:1::2]"}, "fdaa:0:1::2", true},
+		{[]string{"5432", "10.0.0.2"}, "10.0.0.2", true},
+		{[]string{"5432", "8080"}, "", false},
+		{[]string{"5432", "8080:80"}, "", false},
+		{[]string{"5432", "8080:my-app.internal:80"}, "", false},
+		{[]string{"5432"}, "", false},
+	}
+
+	for _, c := range cases {
+		host, ok := remoteHostArg(c.args)
+		assert.Equal(t, c.ok, ok, c.args)
+		assert.Equal(t, c.host, host, c.args)
+	}
+}
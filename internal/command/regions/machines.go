@@ -3,8 +3,11 @@ package regions
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/samber/lo"
+
 	"github.com/superfly/fly-go/flaps"
 	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/config"
@@ -41,6 +44,7 @@ func v2RunRegionsList(ctx context.Context) error {
 		for region := range regions {
 			machineRegions[group] = append(machineRegions[group], region)
 		}
+		slices.Sort(machineRegions[group])
 	}
 
 	printApssV2Regions(ctx, machineRegions)
@@ -56,12 +60,15 @@ func printApssV2Regions(ctx context.Context, machineRegions map[string][]string)
 	io := iostreams.FromContext(ctx)
 	colorize := io.ColorScheme()
 
+	groups := lo.Keys(machineRegions)
+	slices.Sort(groups)
+
 	if config.FromContext(ctx).JSONOutput {
 		jsonPg := []printableProcessGroup{}
-		for group, regionlist := range machineRegions {
+		for _, group := range groups {
 			jsonPg = append(jsonPg, printableProcessGroup{
 				Name:    group,
-				Regions: regionlist,
+				Regions: machineRegions[group],
 			})
 		}
 
@@ -75,7 +82,7 @@ func printApssV2Regions(ctx context.Context, machineRegions map[string][]string)
 		return
 	}
 
-	for group, regionlist := range machineRegions {
-		fmt.Fprintf(io.Out, "Regions [%s]: %s\n", colorize.Bold(group), strings.Join(regionlist, ", "))
+	for _, group := range groups {
+		fmt.Fprintf(io.Out, "Regions [%s]: %s\n", colorize.Bold(group), strings.Join(machineRegions[group], ", "))
 	}
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/superfly/flyctl/internal/command/agent"
 	"github.com/superfly/flyctl/internal/command/apps"
 	"github.com/superfly/flyctl/internal/command/auth"
+	"github.com/superfly/flyctl/internal/command/builds"
 	"github.com/superfly/flyctl/internal/command/certificates"
 	"github.com/superfly/flyctl/internal/command/checks"
 	"github.com/superfly/flyctl/internal/command/config"
@@ -98,8 +99,14 @@ func New() *cobra.Command {
 
 	fs := root.PersistentFlags()
 	_ = fs.StringP(flagnames.AccessToken, "t", "", "Fly API Access Token")
-	_ = fs.BoolP(flagnames.Verbose, "", false, "Verbose output")
+	_ = fs.BoolP(flagnames.Verbose, "", false, "Verbose output, including API requests and responses")
+	_ = fs.BoolP(flagnames.Quiet, "", false, "Suppress spinners, progress output and colors")
 	_ = fs.BoolP(flagnames.Debug, "", false, "Print additional logs and traces")
+	_ = fs.Bool(flagnames.NoAutoAgent, false, "Fail instead of starting the flyctl agent when a command needs it and it isn't running. Can also be set with FLY_NO_AUTO_AGENT")
+	_ = fs.Bool(flagnames.NoPrompt, false, "Fail instead of prompting when input is missing, e.g. in scripts. Can also be set with FLY_NO_PROMPT")
+	_ = fs.Bool(flagnames.StrictApp, false, "Refuse --app, and a FLY_APP other than the config file's app, when the app config file names the app. Can also be set with FLY_STRICT_APP or strict_app in the flyctl config file")
+	_ = fs.String(flagnames.Org, "", "Default organization slug for commands that need one. Can also be set with FLY_ORG")
+	_ = fs.String(flagnames.AppConfigEnv, "", "Merge the config overlay for this environment (e.g. fly.staging.toml for staging) over the app config. Can also be set with FLY_CONFIG_ENV")
 
 	flyctl.InitConfig()
 
@@ -109,6 +116,7 @@ func New() *cobra.Command {
 		version.New(),
 		group(orgs.New(), "acl"),
 		group(auth.New(), "acl"),
+		group(auth.NewWhoAmI(), "acl"),
 		group(platform.New(), "more_help"),
 		group(docs.New(), "more_help"),
 		group(releases.New(), "upkeep"),
@@ -123,6 +131,7 @@ func New() *cobra.Command {
 		agent.New(),
 		group(image.New(), "configuring"),
 		group(incidents.New(), "upkeep"),
+		group(builds.New(), "upkeep"),
 		group(mysql.New(), "dbs_and_extensions"),
 		group(ping.New(), "upkeep"),
 		group(proxy.New(), "upkeep"),
@@ -9,7 +9,13 @@ import (
 func newScaleShow() *cobra.Command {
 	const (
 		short = "Show current resources"
-		long  = `Show current VM size and counts`
+		long  = `Show the current VM sizes and machine counts of each process group,
+and the app's total CPUs and memory. Machines of a process group that were
+scaled to different sizes are listed separately.
+
+With --suggest, the p95 CPU and memory utilization of the machines over the
+last day is used to suggest a right-sized guest for each group, flagging
+over- and under-provisioned groups.`
 	)
 	cmd := command.New("show", short, long, runMachinesScaleShow,
 		command.RequireSession,
@@ -20,6 +26,10 @@ func newScaleShow() *cobra.Command {
 		flag.App(),
 		flag.AppConfig(),
 		flag.JSONOutput(),
+		flag.Bool{
+			Name:        "suggest",
+			Description: "Suggest a right-sized guest for each group from recent resource utilization",
+		},
 	)
 	return cmd
 }
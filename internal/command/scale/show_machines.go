@@ -1,6 +1,7 @@
 package scale
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/flyutil"
 	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/iostreams"
 )
@@ -34,83 +36,159 @@ func runMachinesScaleShow(ctx context.Context) error {
 		return err
 	}
 
-	machines = lo.Filter(machines, func(m *fly.Machine, _ int) bool {
-		return m.Config != nil
-	})
-
-	machineGroups := lo.GroupBy(machines, func(m *fly.Machine) string {
-		return m.ProcessGroup()
-	})
+	groups := groupMachinesBySize(machines)
 
-	// Deterministic output sorted by group name
-	groupNames := lo.Keys(machineGroups)
-	slices.Sort(groupNames)
-
-	// TODO: Each machine can technically have a different Guest configuration.
-	// It's impractical to show the guest for each machine, but arbitrarily
-	// picking the first one is not ideal either.
-	representativeGuests := lo.MapValues(machineGroups, func(machines []*fly.Machine, _ string) *fly.MachineGuest {
-		if len(machines) == 0 {
-			return nil
-		}
-		return machines[0].Config.Guest
-	})
+	var suggestErr error
+	if flag.GetBool(ctx, "suggest") {
+		suggestErr = addSuggestions(ctx, appName, groups)
+	}
 
 	if flag.GetBool(ctx, "json") {
-		type groupData struct {
-			Process string
-			Count   int
-			CPUKind string
-			CPUs    int
-			Memory  int
-			Regions map[string]int
+		if suggestErr != nil {
+			return fmt.Errorf("metrics are unavailable, can't suggest sizes: %w", suggestErr)
 		}
-		groups := lo.FilterMap(groupNames, func(name string, _ int) (res groupData, ok bool) {
-			machines := machineGroups[name]
-			guest := representativeGuests[name]
-			if guest == nil {
-				return res, false
-			}
-			return groupData{
-				Process: name,
-				Count:   len(machines),
-				CPUKind: guest.CPUKind,
-				CPUs:    guest.CPUs,
-				Memory:  guest.MemoryMB,
-				Regions: lo.CountValues(lo.Map(machines, func(m *fly.Machine, _ int) string {
-					return m.Region
-				})),
-			}, true
-		})
-
 		prettyJSON, _ := json.MarshalIndent(groups, "", "    ")
 		fmt.Fprintln(io.Out, string(prettyJSON))
 		return nil
 	}
 
-	rows := make([][]string, 0, len(machineGroups))
-	for _, groupName := range groupNames {
-		machines := machineGroups[groupName]
-		guest := representativeGuests[groupName]
-		if guest == nil {
-			continue
-		}
+	var (
+		rows        = make([][]string, 0, len(groups))
+		totalCount  int
+		totalCPUs   int
+		totalMemory int
+	)
+	for _, g := range groups {
 		rows = append(rows, []string{
-			groupName,
-			fmt.Sprintf("%d", len(machines)),
-			guest.CPUKind,
-			fmt.Sprintf("%d", guest.CPUs),
-			fmt.Sprintf("%d MB", guest.MemoryMB),
-			formatRegions(machines),
+			g.Process,
+			fmt.Sprintf("%d", g.Count),
+			g.CPUKind,
+			fmt.Sprintf("%d", g.CPUs),
+			fmt.Sprintf("%d MB", g.Memory),
+			formatRegions(g.machines),
 		})
+		totalCount += g.Count
+		totalCPUs += g.TotalCPUs
+		totalMemory += g.TotalMemory
 	}
 
 	fmt.Fprintf(io.Out, "VM Resources for app: %s\n\n", appName)
 	render.Table(io.Out, "Groups", rows, "Name", "Count", "Kind", "CPUs", "Memory", "Regions")
+	fmt.Fprintf(io.Out, "Total: %d machines, %d CPUs, %d MB memory\n", totalCount, totalCPUs, totalMemory)
+
+	switch {
+	case suggestErr != nil:
+		fmt.Fprintf(io.Out, "\nNo sizes are suggested, metrics are unavailable: %v\n", suggestErr)
+	case flag.GetBool(ctx, "suggest"):
+		rows = rows[:0]
+		for _, g := range groups {
+			s := g.Suggestion
+			suggested := "-"
+			if s.Verdict != verdictNoMetrics {
+				suggested = fmt.Sprintf("%s, %d MB", s.Size, s.Memory)
+			}
+			rows = append(rows, []string{
+				g.Process,
+				fmt.Sprintf("%s, %d MB", (&fly.MachineGuest{CPUKind: g.CPUKind, CPUs: g.CPUs}).ToSize(), g.Memory),
+				suggested,
+				s.Verdict,
+				strings.Join(s.Rationale, "; "),
+			})
+		}
+		fmt.Fprintln(io.Out)
+		render.Table(io.Out, fmt.Sprintf("Suggested sizes (p95 over the last %s)", suggestWindow), rows, "Name", "Current", "Suggested", "Verdict", "Rationale")
+	}
 
 	return nil
 }
 
+// addSuggestions sets the suggested size of each group from the recent
+// utilization of its machines.
+func addSuggestions(ctx context.Context, appName string, groups []scaleGroup) error {
+	app, err := flyutil.ClientFromContext(ctx).GetAppCompact(ctx, appName)
+	if err != nil {
+		return err
+	}
+	usage, err := queryUtilization(ctx, app.Organization.Slug, appName)
+	if err != nil {
+		return err
+	}
+	for i := range groups {
+		s := suggestSize(groups[i], usage)
+		groups[i].Suggestion = &s
+	}
+	return nil
+}
+
+// scaleGroup is a set of machines of one process group sharing a guest size.
+type scaleGroup struct {
+	Process     string
+	Count       int
+	CPUKind     string
+	CPUs        int
+	Memory      int
+	Regions     map[string]int
+	TotalCPUs   int
+	TotalMemory int
+	Suggestion  *suggestion `json:",omitempty"`
+
+	machines []*fly.Machine
+}
+
+// groupMachinesBySize groups machines by process group and guest size, so a
+// process group whose machines were scaled to different sizes gets a group per
+// size. Groups are sorted by process group name, then by size.
+func groupMachinesBySize(machines []*fly.Machine) []scaleGroup {
+	type key struct {
+		process  string
+		cpuKind  string
+		cpus     int
+		memoryMB int
+	}
+
+	var (
+		keys   []key
+		groups = map[key][]*fly.Machine{}
+	)
+	for _, m := range machines {
+		if m.Config == nil || m.Config.Guest == nil {
+			continue
+		}
+		guest := m.Config.Guest
+		k := key{m.ProcessGroup(), guest.CPUKind, guest.CPUs, guest.MemoryMB}
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], m)
+	}
+
+	slices.SortFunc(keys, func(a, b key) int {
+		return cmp.Or(
+			cmp.Compare(a.process, b.process),
+			cmp.Compare(a.cpuKind, b.cpuKind),
+			cmp.Compare(a.cpus, b.cpus),
+			cmp.Compare(a.memoryMB, b.memoryMB),
+		)
+	})
+
+	return lo.Map(keys, func(k key, _ int) scaleGroup {
+		machines := groups[k]
+		return scaleGroup{
+			Process: k.process,
+			Count:   len(machines),
+			CPUKind: k.cpuKind,
+			CPUs:    k.cpus,
+			Memory:  k.memoryMB,
+			Regions: lo.CountValues(lo.Map(machines, func(m *fly.Machine, _ int) string {
+				return m.Region
+			})),
+			TotalCPUs:   k.cpus * len(machines),
+			TotalMemory: k.memoryMB * len(machines),
+			machines:    machines,
+		}
+	})
+}
+
 func formatRegions(machines []*fly.Machine) string {
 	regions := lo.Map(
 		lo.Entries(lo.CountValues(lo.Map(machines, func(m *fly.Machine, _ int) string {
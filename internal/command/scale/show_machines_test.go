@@ -20,3 +20,33 @@ func Test_formatRegions(t *testing.T) {
 		"fra(3),mia,scl(2)",
 	)
 }
+
+func Test_groupMachinesBySize(t *testing.T) {
+	machine := func(process, region string, cpus, memory int) *fly.Machine {
+		return &fly.Machine{
+			Region: region,
+			Config: &fly.MachineConfig{
+				Metadata: map[string]string{fly.MachineConfigMetadataKeyFlyProcessGroup: process},
+				Guest:    &fly.MachineGuest{CPUKind: "shared", CPUs: cpus, MemoryMB: memory},
+			},
+		}
+	}
+
+	groups := groupMachinesBySize([]*fly.Machine{
+		machine("web", "fra", 1, 256),
+		machine("worker", "fra", 2, 1024),
+		machine("web", "scl", 1, 256),
+		machine("web", "fra", 2, 512),
+		{Region: "mia"},
+	})
+
+	assert.Len(t, groups, 3)
+	for i, want := range []scaleGroup{
+		{Process: "web", Count: 2, CPUKind: "shared", CPUs: 1, Memory: 256, Regions: map[string]int{"fra": 1, "scl": 1}, TotalCPUs: 2, TotalMemory: 512},
+		{Process: "web", Count: 1, CPUKind: "shared", CPUs: 2, Memory: 512, Regions: map[string]int{"fra": 1}, TotalCPUs: 2, TotalMemory: 512},
+		{Process: "worker", Count: 1, CPUKind: "shared", CPUs: 2, Memory: 1024, Regions: map[string]int{"fra": 1}, TotalCPUs: 2, TotalMemory: 1024},
+	} {
+		groups[i].machines = nil
+		assert.Equal(t, want, groups[i])
+	}
+}
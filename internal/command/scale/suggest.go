@@ -0,0 +1,224 @@
+package scale
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/buildinfo"
+	"github.com/superfly/flyctl/internal/config"
+)
+
+const (
+	// suggestWindow is how far back utilization is looked at.
+	suggestWindow = "24h"
+
+	// suggestHeadroom is the share of a guest's CPUs and memory the p95
+	// utilization may take before it's considered under-provisioned.
+	suggestHeadroom = 0.8
+
+	// suggestShrinkBelow is the share of a guest's memory under which the p95
+	// usage must be before a smaller guest is suggested, so that guests close
+	// to right-sized aren't resized back and forth.
+	suggestShrinkBelow = 0.4
+)
+
+var metricsHTTPClient = &http.Client{
+	Timeout: 30 * time.Second,
+}
+
+// utilization is the p95 resource utilization of a machine.
+type utilization struct {
+	// CPUs is the number of CPUs kept busy.
+	CPUs     float64
+	MemoryMB float64
+}
+
+// queryUtilization returns the p95 CPU and memory utilization of the app's
+// machines over the suggestion window, keyed by machine ID. Machines without
+// metrics are left out.
+func queryUtilization(ctx context.Context, orgSlug, appName string) (map[string]utilization, error) {
+	// fly_instance_cpu counts centiseconds of CPU time.
+	cpu, err := queryPrometheus(ctx, orgSlug, fmt.Sprintf(
+		`quantile_over_time(0.95, sum by (instance) (rate(fly_instance_cpu{app=%q, mode!="idle"}[5m]))[%s:5m]) / 100`,
+		appName, suggestWindow,
+	))
+	if err != nil {
+		return nil, err
+	}
+	memory, err := queryPrometheus(ctx, orgSlug, fmt.Sprintf(
+		`quantile_over_time(0.95, (fly_instance_memory_mem_total{app=%[1]q} - fly_instance_memory_mem_available{app=%[1]q})[%[2]s:5m])`,
+		appName, suggestWindow,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make(map[string]utilization, len(memory))
+	for id, bytes := range memory {
+		if _, ok := cpu[id]; !ok {
+			continue
+		}
+		usage[id] = utilization{CPUs: cpu[id], MemoryMB: bytes / (1 << 20)}
+	}
+	return usage, nil
+}
+
+// queryPrometheus runs an instant query against the organization's metrics
+// and returns the value of each series by its instance label.
+func queryPrometheus(ctx context.Context, orgSlug, query string) (map[string]float64, error) {
+	cfg := config.FromContext(ctx)
+
+	endpoint := fmt.Sprintf("%s/prometheus/%s/api/v1/query?%s", cfg.APIBaseURL, url.PathEscape(orgSlug), url.Values{"query": {query}}.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", buildinfo.UserAgent())
+	req.Header.Set("Authorization", fly.AuthorizationHeader(cfg.Tokens.GraphQL()))
+
+	res, err := metricsHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed querying metrics: %w", err)
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+		Data   struct {
+			Result []struct {
+				Metric map[string]string `json:"metric"`
+				Value  [2]any            `json:"value"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed querying metrics: unexpected response (status %d): %w", res.StatusCode, err)
+	}
+	if res.StatusCode != http.StatusOK || body.Status != "success" {
+		return nil, fmt.Errorf("failed querying metrics (status %d): %s", res.StatusCode, body.Error)
+	}
+
+	values := make(map[string]float64, len(body.Data.Result))
+	for _, r := range body.Data.Result {
+		s, _ := r.Value[1].(string)
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil || math.IsNaN(v) || r.Metric["instance"] == "" {
+			continue
+		}
+		values[r.Metric["instance"]] = v
+	}
+	return values, nil
+}
+
+// suggestion is the right-sized guest for a group of machines.
+type suggestion struct {
+	Size      string
+	CPUKind   string
+	CPUs      int
+	Memory    int
+	Verdict   string
+	Rationale []string
+}
+
+const (
+	verdictRightSized = "right-sized"
+	verdictOver       = "over-provisioned"
+	verdictUnder      = "under-provisioned"
+	verdictNoMetrics  = "no metrics"
+)
+
+// suggestSize suggests a guest for a group of machines from the utilization
+// of its machines. The busiest machine of the group decides.
+func suggestSize(g scaleGroup, usage map[string]utilization) suggestion {
+	var (
+		peak  utilization
+		found bool
+	)
+	for _, m := range g.machines {
+		u, ok := usage[m.ID]
+		if !ok {
+			continue
+		}
+		found = true
+		peak.CPUs = max(peak.CPUs, u.CPUs)
+		peak.MemoryMB = max(peak.MemoryMB, u.MemoryMB)
+	}
+
+	s := suggestion{CPUKind: g.CPUKind, CPUs: g.CPUs, Memory: g.Memory}
+	if !found || g.CPUs == 0 || g.Memory == 0 {
+		s.Verdict = verdictNoMetrics
+		s.Rationale = []string{fmt.Sprintf("no utilization metrics over the last %s", suggestWindow)}
+		return s
+	}
+
+	minPerCPU, maxPerCPU, maxCPUs := fly.MIN_MEMORY_MB_PER_SHARED_CPU, fly.MAX_MEMORY_MB_PER_SHARED_CPU, 8
+	if g.CPUKind == "performance" {
+		minPerCPU, maxPerCPU, maxCPUs = fly.MIN_MEMORY_MB_PER_CPU, fly.MAX_MEMORY_MB_PER_CPU, 16
+	}
+
+	var (
+		under, over bool
+		// memory is the memory to size for, kept as is unless the usage is
+		// out of bounds.
+		memory = g.Memory
+	)
+
+	switch limit := suggestHeadroom * float64(g.Memory); {
+	case peak.MemoryMB > limit:
+		under = true
+		memory = roundUp(int(math.Ceil(peak.MemoryMB/suggestHeadroom)), 256)
+		s.Rationale = append(s.Rationale, fmt.Sprintf("p95 memory %.0fMB exceeds %.0f%% of %dMB", peak.MemoryMB, suggestHeadroom*100, g.Memory))
+	case peak.MemoryMB < suggestShrinkBelow*float64(g.Memory):
+		over = true
+		memory = roundUp(int(math.Ceil(peak.MemoryMB/suggestHeadroom)), 256)
+		s.Rationale = append(s.Rationale, fmt.Sprintf("p95 memory %.0fMB is under %.0f%% of %dMB", peak.MemoryMB, suggestShrinkBelow*100, g.Memory))
+	}
+	switch limit := suggestHeadroom * float64(g.CPUs); {
+	case peak.CPUs > limit:
+		under = true
+		s.Rationale = append(s.Rationale, fmt.Sprintf("p95 CPU use %.2f exceeds %.0f%% of %d CPUs", peak.CPUs, suggestHeadroom*100, g.CPUs))
+	case g.CPUs > 1 && peak.CPUs <= suggestHeadroom*float64(g.CPUs/2):
+		over = true
+		s.Rationale = append(s.Rationale, fmt.Sprintf("p95 CPU use %.2f fits in %d CPUs", peak.CPUs, g.CPUs/2))
+	}
+
+	if under || over {
+		// Pick the fewest CPUs that keep the CPU headroom and allow that
+		// much memory.
+		s.CPUs = 1
+		for s.CPUs < maxCPUs && (float64(s.CPUs)*suggestHeadroom < peak.CPUs || s.CPUs*maxPerCPU < memory) {
+			s.CPUs *= 2
+		}
+		s.Memory = min(max(memory, s.CPUs*minPerCPU), s.CPUs*maxPerCPU)
+	}
+
+	switch {
+	case under:
+		s.Verdict = verdictUnder
+	case over && (s.CPUs < g.CPUs || s.Memory < g.Memory):
+		s.Verdict = verdictOver
+	default:
+		s.Verdict = verdictRightSized
+		s.CPUs, s.Memory = g.CPUs, g.Memory
+		if over {
+			s.Rationale = append(s.Rationale, "no smaller size fits")
+		} else {
+			s.Rationale = []string{fmt.Sprintf("p95 memory %.0fMB and CPU use %.2f fit with headroom", peak.MemoryMB, peak.CPUs)}
+		}
+	}
+
+	s.Size = (&fly.MachineGuest{CPUKind: s.CPUKind, CPUs: s.CPUs}).ToSize()
+	return s
+}
+
+func roundUp(n, multiple int) int {
+	return (n + multiple - 1) / multiple * multiple
+}
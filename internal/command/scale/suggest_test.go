@@ -0,0 +1,147 @@
+package scale
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/tokens"
+	"github.com/superfly/flyctl/internal/config"
+)
+
+func TestSuggestSize(t *testing.T) {
+	group := func(cpuKind string, cpus, memory int) scaleGroup {
+		return scaleGroup{
+			Process:  "app",
+			CPUKind:  cpuKind,
+			CPUs:     cpus,
+			Memory:   memory,
+			machines: []*fly.Machine{{ID: "m1"}, {ID: "m2"}},
+		}
+	}
+
+	cases := []struct {
+		name      string
+		group     scaleGroup
+		usage     map[string]utilization
+		size      string
+		memory    int
+		verdict   string
+		rationale string
+	}{
+		{
+			name:      "no metrics",
+			group:     group("shared", 1, 512),
+			usage:     map[string]utilization{"other": {CPUs: 0.1, MemoryMB: 100}},
+			memory:    512,
+			verdict:   verdictNoMetrics,
+			rationale: "no utilization metrics",
+		},
+		{
+			name:      "memory pressure",
+			group:     group("shared", 1, 512),
+			usage:     map[string]utilization{"m1": {CPUs: 0.1, MemoryMB: 300}, "m2": {CPUs: 0.2, MemoryMB: 820}},
+			size:      "shared-cpu-1x",
+			memory:    1280,
+			verdict:   verdictUnder,
+			rationale: "p95 memory 820MB exceeds 80% of 512MB",
+		},
+		{
+			name:      "cpu pressure",
+			group:     group("shared", 1, 512),
+			usage:     map[string]utilization{"m1": {CPUs: 1.5, MemoryMB: 300}},
+			size:      "shared-cpu-2x",
+			memory:    512,
+			verdict:   verdictUnder,
+			rationale: "p95 CPU use 1.50 exceeds 80% of 1 CPUs",
+		},
+		{
+			name:      "oversized",
+			group:     group("performance", 4, 8192),
+			usage:     map[string]utilization{"m1": {CPUs: 0.3, MemoryMB: 900}},
+			size:      "performance-1x",
+			memory:    2048,
+			verdict:   verdictOver,
+			rationale: "p95 memory 900MB is under 40% of 8192MB",
+		},
+		{
+			name:      "smallest size",
+			group:     group("shared", 1, 256),
+			usage:     map[string]utilization{"m1": {CPUs: 0.01, MemoryMB: 40}},
+			size:      "shared-cpu-1x",
+			memory:    256,
+			verdict:   verdictRightSized,
+			rationale: "no smaller size fits",
+		},
+		{
+			name:      "right-sized",
+			group:     group("shared", 2, 1024),
+			usage:     map[string]utilization{"m1": {CPUs: 1.2, MemoryMB: 600}},
+			size:      "shared-cpu-2x",
+			memory:    1024,
+			verdict:   verdictRightSized,
+			rationale: "fit with headroom",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s := suggestSize(c.group, c.usage)
+			assert.Equal(t, c.size, s.Size)
+			assert.Equal(t, c.memory, s.Memory)
+			assert.Equal(t, c.verdict, s.Verdict)
+			assert.Contains(t, s.Rationale[0]+"; "+s.Rationale[len(s.Rationale)-1], c.rationale)
+		})
+	}
+}
+
+func TestQueryUtilization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/prometheus/my-org/api/v1/query", r.URL.Path)
+		assert.Equal(t, "FlyV1 fm2_token", r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.URL.Query().Get("query"), "fly_instance_cpu") {
+			w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[
+				{"metric":{"instance":"m1"},"value":[1700000000,"0.25"]},
+				{"metric":{"instance":"m2"},"value":[1700000000,"NaN"]}
+			]}}`))
+			return
+		}
+		w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[
+			{"metric":{"instance":"m1"},"value":[1700000000,"524288000"]},
+			{"metric":{"instance":"m2"},"value":[1700000000,"104857600"]}
+		]}}`))
+	}))
+	defer server.Close()
+
+	ctx := config.NewContext(context.Background(), &config.Config{
+		APIBaseURL: server.URL,
+		Tokens:     tokens.Parse("FlyV1 fm2_token"),
+	})
+
+	usage, err := queryUtilization(ctx, "my-org", "my-app")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]utilization{"m1": {CPUs: 0.25, MemoryMB: 500}}, usage)
+}
+
+func TestQueryPrometheusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"parse error"}`))
+	}))
+	defer server.Close()
+
+	ctx := config.NewContext(context.Background(), &config.Config{
+		APIBaseURL: server.URL,
+		Tokens:     tokens.Parse("token"),
+	})
+
+	_, err := queryPrometheus(ctx, "my-org", "up")
+	assert.EqualError(t, err, "failed querying metrics (status 400): parse error")
+}
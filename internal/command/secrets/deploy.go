@@ -12,12 +12,15 @@ import (
 	"github.com/superfly/flyctl/internal/flapsutil"
 	"github.com/superfly/flyctl/internal/flyerr"
 	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/iostreams"
 )
 
 func newDeploy() (cmd *cobra.Command) {
 	const (
-		long  = `Deploy staged secrets for an application`
-		short = long
+		long = `Deploy staged secrets for an application. Machines are restarted with
+the current image to pick up secrets that were set with --stage.`
+		short = `Deploy staged secrets for an application`
 		usage = "deploy [flags]"
 	)
 
@@ -27,6 +30,7 @@ func newDeploy() (cmd *cobra.Command) {
 		flag.App(),
 		flag.AppConfig(),
 		flag.Detach(),
+		flag.Yes(),
 	)
 
 	return cmd
@@ -63,5 +67,28 @@ func runDeploy(ctx context.Context) (err error) {
 		}
 	}
 
+	secrets, err := client.GetAppSecrets(ctx, appName)
+	if err != nil {
+		return err
+	}
+
+	// The API doesn't tell staged secrets apart from deployed ones, so the
+	// machines are updated with all of the current secrets. This also applies
+	// secrets unset with --stage.
+	io := iostreams.FromContext(ctx)
+	fmt.Fprintf(io.Out, "The current secrets of %s (%d) will be deployed to %d machine(s)\n", appName, len(secrets), len(machines))
+
+	if !flag.GetYes(ctx) {
+		switch confirmed, err := prompt.Confirm(ctx, "Deploy secrets?"); {
+		case err == nil:
+			if !confirmed {
+				return nil
+			}
+		case prompt.IsNonInteractive(err):
+		default:
+			return err
+		}
+	}
+
 	return DeploySecrets(ctx, app, false, flag.GetBool(ctx, "detach"))
 }
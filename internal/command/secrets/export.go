@@ -0,0 +1,86 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newExport() (cmd *cobra.Command) {
+	const (
+		long = `Export the names, digests and creation times of an application's secrets,
+for example to record them for disaster recovery or to migrate them to another
+app. Secret values are never exported. By default the output is a template with
+a commented-out NAME= line for each secret. Uncomment and fill in the lines of
+the secrets to set and pass the file to 'fly secrets import'; commented lines
+are ignored, so unfilled secrets are left untouched.`
+		short = `Export secret names and digests, without values`
+		usage = "export [flags]"
+	)
+
+	cmd = command.New(usage, short, long, runExport, command.RequireSession, command.RequireAppName)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.JSONOutput(),
+	)
+
+	return cmd
+}
+
+type exportedSecret struct {
+	Name      string    `json:"name"`
+	Digest    string    `json:"digest"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func runExport(ctx context.Context) error {
+	var (
+		io      = iostreams.FromContext(ctx)
+		client  = flyutil.ClientFromContext(ctx)
+		appName = appconfig.NameFromContext(ctx)
+	)
+
+	secrets, err := client.GetAppSecrets(ctx, appName)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
+
+	exported := make([]exportedSecret, 0, len(secrets))
+	for _, secret := range secrets {
+		exported = append(exported, exportedSecret{
+			Name:      secret.Name,
+			Digest:    secret.Digest,
+			CreatedAt: secret.CreatedAt,
+		})
+	}
+
+	fmt.Fprintln(io.ErrOut, "Secret values are not exported and must be supplied again when importing.")
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(io.Out, exported)
+	}
+
+	for _, secret := range exported {
+		fmt.Fprintf(io.Out, "# digest: %s, created at: %s\n", secret.Digest, secret.CreatedAt.Format(time.RFC3339))
+		// Commented out so importing the template as-is doesn't set the
+		// secret to an empty value.
+		fmt.Fprintf(io.Out, "# %s=\n", secret.Name)
+	}
+	return nil
+}
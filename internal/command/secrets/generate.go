@@ -0,0 +1,111 @@
+package secrets
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+const maxGeneratedSecretLength = 1024
+
+func newGenerate() (cmd *cobra.Command) {
+	const (
+		long = `Generate a cryptographically random value and set it as a secret of the
+application. The value isn't printed unless --show is given, so it only
+ever exists in the app's environment.`
+		short = "Generate a random value and set it as a secret"
+		usage = "generate [flags] NAME"
+	)
+
+	cmd = command.New(usage, short, long, runGenerate, command.RequireSession, command.RequireAppName)
+
+	flag.Add(cmd,
+		sharedFlags,
+		flag.Int{
+			Name:        "length",
+			Description: "Number of random bytes to generate",
+			Default:     32,
+		},
+		flag.String{
+			Name:        "format",
+			Description: "Encoding of the generated value, hex or base64",
+			Default:     "hex",
+		},
+		flag.Bool{
+			Name:        "show",
+			Description: "Print the generated value",
+		},
+	)
+
+	cmd.Args = cobra.ExactArgs(1)
+
+	return cmd
+}
+
+func runGenerate(ctx context.Context) error {
+	var (
+		io      = iostreams.FromContext(ctx)
+		client  = flyutil.ClientFromContext(ctx)
+		appName = appconfig.NameFromContext(ctx)
+		name    = flag.FirstArg(ctx)
+	)
+
+	if name == "" || strings.ContainsAny(name, "= ") {
+		return fmt.Errorf("invalid secret name %q", name)
+	}
+
+	value, err := generateSecretValue(flag.GetInt(ctx, "length"), flag.GetString(ctx, "format"))
+	if err != nil {
+		return err
+	}
+
+	app, err := client.GetAppCompact(ctx, appName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.SetSecrets(ctx, app.Name, map[string]string{name: value}); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(io.Out, "Generated and set secret %s\n", name)
+	if flag.GetBool(ctx, "show") {
+		fmt.Fprintln(io.Out, value)
+	}
+
+	return DeploySecrets(ctx, app, flag.GetBool(ctx, "stage"), flag.GetBool(ctx, "detach"))
+}
+
+// generateSecretValue returns length cryptographically random bytes encoded
+// as hex or base64.
+func generateSecretValue(length int, format string) (string, error) {
+	if length < 1 || length > maxGeneratedSecretLength {
+		return "", fmt.Errorf("--length must be between 1 and %d", maxGeneratedSecretLength)
+	}
+
+	var encode func([]byte) string
+	switch strings.ToLower(format) {
+	case "hex":
+		encode = hex.EncodeToString
+	case "base64":
+		encode = base64.StdEncoding.EncodeToString
+	default:
+		return "", fmt.Errorf("unsupported --format %q, expected hex or base64", format)
+	}
+
+	buf := make([]byte, length)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed generating random value: %w", err)
+	}
+	return encode(buf), nil
+}
@@ -2,8 +2,10 @@ package secrets
 
 import (
 	"context"
+	"sort"
 
 	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
 	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/config"
@@ -31,15 +33,28 @@ actual value of the secret is only available to the application.`
 		flag.App(),
 		flag.AppConfig(),
 		flag.JSONOutput(),
+		flag.ListOutput(),
 	)
 
 	return cmd
 }
 
+type secretListing struct {
+	fly.Secret
+	Status string `json:"status"`
+}
+
+// listColumns are the columns of the list, for --columns and --sort.
+var listColumns = []string{"Name", "Digest", "Status", "Created At"}
+
 func runList(ctx context.Context) (err error) {
 	client := flyutil.ClientFromContext(ctx)
 	appName := appconfig.NameFromContext(ctx)
 	out := iostreams.FromContext(ctx).Out
+	listOpts, err := render.ListOptionsFromContext(ctx, listColumns...)
+	if err != nil {
+		return err
+	}
 	secrets, err := client.GetAppSecrets(ctx, appName)
 	cfg := config.FromContext(ctx)
 
@@ -47,24 +62,28 @@ func runList(ctx context.Context) (err error) {
 		return err
 	}
 
-	var rows [][]string
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
+
+	var (
+		rows     [][]string
+		listings []secretListing
+	)
 
 	for _, secret := range secrets {
+		listings = append(listings, secretListing{Secret: secret, Status: statusUnknown})
 		rows = append(rows, []string{
 			secret.Name,
 			secret.Digest,
+			statusUnknown,
 			format.RelativeTime(secret.CreatedAt),
 		})
 	}
 
-	headers := []string{
-		"Name",
-		"Digest",
-		"Created At",
-	}
 	if cfg.JSONOutput {
-		return render.JSON(out, secrets)
+		return render.JSON(out, listings)
 	} else {
-		return render.Table(out, "", rows, headers...)
+		return render.List(out, listOpts, "", rows, listColumns...)
 	}
 }
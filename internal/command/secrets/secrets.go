@@ -41,8 +41,10 @@ func New() *cobra.Command {
 	secrets.AddCommand(
 		newList(),
 		newSet(),
+		newGenerate(),
 		newUnset(),
 		newImport(),
+		newExport(),
 		newDeploy(),
 		newKeys(),
 	)
@@ -50,6 +52,12 @@ func New() *cobra.Command {
 	return secrets
 }
 
+// statusUnknown is the status secrets list reports for every secret. The API
+// doesn't say whether a secret has been deployed to the app's machines or is
+// still staged, and guessing from the machines' update times is wrong after
+// any restart or update of a machine.
+const statusUnknown = "unknown"
+
 func DeploySecrets(ctx context.Context, app *fly.AppCompact, stage bool, detach bool) error {
 	out := iostreams.FromContext(ctx).Out
 
@@ -0,0 +1,132 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/internal/mock"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newCommandContext(client *mock.Client, jsonOutput bool) (context.Context, *bytes.Buffer) {
+	ios, _, out, _ := iostreams.Test()
+	ctx := iostreams.NewContext(context.Background(), ios)
+	ctx = flyutil.NewContextWithClient(ctx, client)
+	ctx = appconfig.WithName(ctx, "my-app")
+	ctx = config.NewContext(ctx, &config.Config{JSONOutput: jsonOutput})
+	ctx = flag.NewContext(ctx, pflag.NewFlagSet("secrets", pflag.ContinueOnError))
+	return ctx, out
+}
+
+func TestListWithUnknownStatus(t *testing.T) {
+	client := &mock.Client{
+		GetAppSecretsFunc: func(ctx context.Context, appName string) ([]fly.Secret, error) {
+			return []fly.Secret{{Name: "SECRET_KEY_BASE", Digest: "abc"}, {Name: "API_KEY", Digest: "def"}}, nil
+		},
+	}
+	ctx, out := newCommandContext(client, true)
+
+	// The app's machines aren't listed, so there's no flaps client to set up.
+	require.NoError(t, runList(ctx))
+
+	var listings []map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &listings))
+	require.Len(t, listings, 2)
+	assert.Equal(t, "API_KEY", listings[0]["Name"])
+	assert.Equal(t, "unknown", listings[0]["status"])
+	assert.Equal(t, "SECRET_KEY_BASE", listings[1]["Name"])
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	client := &mock.Client{
+		GetAppSecretsFunc: func(ctx context.Context, appName string) ([]fly.Secret, error) {
+			return []fly.Secret{{Name: "DATABASE_URL", Digest: "abc"}, {Name: "API_KEY", Digest: "def"}}, nil
+		},
+	}
+	ctx, out := newCommandContext(client, false)
+	require.NoError(t, runExport(ctx))
+
+	// Importing the template as-is must not set the secrets to empty values.
+	secrets, err := parseSecrets(bytes.NewReader(out.Bytes()))
+	require.NoError(t, err)
+	assert.Empty(t, secrets)
+
+	// Uncommenting a line and filling it in sets just that secret.
+	template := strings.Replace(out.String(), "# API_KEY=", "API_KEY=xyz", 1)
+	secrets, err = parseSecrets(strings.NewReader(template))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"API_KEY": "xyz"}, secrets)
+}
+
+func TestUnsetSecretsReportsNotFound(t *testing.T) {
+	var unset []string
+	client := &mock.Client{
+		GetAppSecretsFunc: func(ctx context.Context, appName string) ([]fly.Secret, error) {
+			return []fly.Secret{{Name: "DATABASE_URL"}, {Name: "API_KEY"}}, nil
+		},
+		UnsetSecretsFunc: func(ctx context.Context, appName string, keys []string) (*fly.Release, error) {
+			unset = keys
+			return &fly.Release{}, nil
+		},
+	}
+	ctx := flyutil.NewContextWithClient(context.Background(), client)
+
+	result, err := unsetSecrets(ctx, "my-app", []string{"API_KEY", "MISSING"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"API_KEY"}, result.Removed)
+	assert.Equal(t, []string{"MISSING"}, result.NotFound)
+	assert.Equal(t, []string{"API_KEY"}, unset)
+}
+
+func TestGenerateSecretValue(t *testing.T) {
+	value, err := generateSecretValue(32, "hex")
+	require.NoError(t, err)
+	raw, err := hex.DecodeString(value)
+	require.NoError(t, err)
+	assert.Len(t, raw, 32)
+
+	other, err := generateSecretValue(32, "hex")
+	require.NoError(t, err)
+	assert.NotEqual(t, value, other)
+
+	value, err = generateSecretValue(16, "BASE64")
+	require.NoError(t, err)
+	raw, err = base64.StdEncoding.DecodeString(value)
+	require.NoError(t, err)
+	assert.Len(t, raw, 16)
+
+	_, err = generateSecretValue(0, "hex")
+	assert.ErrorContains(t, err, "--length must be between 1 and 1024")
+
+	_, err = generateSecretValue(32, "ascii85")
+	assert.ErrorContains(t, err, `unsupported --format "ascii85"`)
+}
+
+func TestDropExistingSecrets(t *testing.T) {
+	client := &mock.Client{
+		GetAppSecretsFunc: func(ctx context.Context, appName string) ([]fly.Secret, error) {
+			assert.Equal(t, "my-app", appName)
+			return []fly.Secret{{Name: "SECRET_KEY_BASE"}, {Name: "DATABASE_URL"}, {Name: "UNRELATED"}}, nil
+		},
+	}
+	ctx := flyutil.NewContextWithClient(context.Background(), client)
+
+	secrets := map[string]string{"DATABASE_URL": "postgres://", "SECRET_KEY_BASE": "abc", "API_KEY": "xyz"}
+	skipped, err := dropExistingSecrets(ctx, "my-app", secrets)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"DATABASE_URL", "SECRET_KEY_BASE"}, skipped)
+	assert.Equal(t, map[string]string{"API_KEY": "xyz"}, secrets)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 	fly "github.com/superfly/fly-go"
@@ -13,6 +15,7 @@ import (
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/iostreams"
 )
 
 func newSet() (cmd *cobra.Command) {
@@ -26,6 +29,10 @@ func newSet() (cmd *cobra.Command) {
 
 	flag.Add(cmd,
 		sharedFlags,
+		flag.Bool{
+			Name:        "if-not-exists",
+			Description: "Only set secrets that aren't already set on the app, leaving existing values untouched",
+		},
 	)
 
 	cmd.Args = cobra.MinimumNArgs(1)
@@ -63,6 +70,23 @@ func runSet(ctx context.Context) (err error) {
 		return errors.New("requires at least one SECRET=VALUE pair")
 	}
 
+	if flag.GetBool(ctx, "if-not-exists") {
+		skipped, err := dropExistingSecrets(ctx, app.Name, secrets)
+		if err != nil {
+			return err
+		}
+
+		out := iostreams.FromContext(ctx).Out
+		if len(skipped) > 0 {
+			fmt.Fprintf(out, "Skipped secrets that are already set: %s\n", strings.Join(skipped, ", "))
+		}
+		if len(secrets) == 0 {
+			fmt.Fprintln(out, "No secrets to set")
+			return nil
+		}
+		fmt.Fprintf(out, "Setting secrets: %s\n", strings.Join(sortedKeys(secrets), ", "))
+	}
+
 	return SetSecretsAndDeploy(ctx, app, secrets, flag.GetBool(ctx, "stage"), flag.GetBool(ctx, "detach"))
 }
 
@@ -74,3 +98,32 @@ func SetSecretsAndDeploy(ctx context.Context, app *fly.AppCompact, secrets map[s
 
 	return DeploySecrets(ctx, app, stage, detach)
 }
+
+// dropExistingSecrets removes the secrets the app already has from secrets
+// and returns their names, sorted. Secret values can't be read back, so only
+// names are compared.
+func dropExistingSecrets(ctx context.Context, appName string, secrets map[string]string) ([]string, error) {
+	existing, err := flyutil.ClientFromContext(ctx).GetAppSecrets(ctx, appName)
+	if err != nil {
+		return nil, fmt.Errorf("could not list existing secrets: %w", err)
+	}
+
+	var skipped []string
+	for _, secret := range existing {
+		if _, ok := secrets[secret.Name]; ok {
+			delete(secrets, secret.Name)
+			skipped = append(skipped, secret.Name)
+		}
+	}
+	sort.Strings(skipped)
+	return skipped, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
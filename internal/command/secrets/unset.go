@@ -2,13 +2,17 @@ package secrets
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/iostreams"
 )
 
 func newUnset() (cmd *cobra.Command) {
@@ -41,10 +45,54 @@ func runUnset(ctx context.Context) (err error) {
 }
 
 func UnsetSecretsAndDeploy(ctx context.Context, app *fly.AppCompact, secrets []string, stage bool, detach bool) error {
-	client := flyutil.ClientFromContext(ctx)
-	if _, err := client.UnsetSecrets(ctx, app.Name, secrets); err != nil {
+	out := iostreams.FromContext(ctx).Out
+
+	result, err := unsetSecrets(ctx, app.Name, secrets)
+	if err != nil {
 		return err
 	}
 
+	if len(result.NotFound) > 0 {
+		fmt.Fprintf(out, "Secrets not found on %s: %s\n", app.Name, strings.Join(result.NotFound, ", "))
+	}
+	if len(result.Removed) == 0 {
+		fmt.Fprintln(out, "No secrets were removed")
+		return nil
+	}
+	fmt.Fprintf(out, "Removed secrets from %s: %s\n", app.Name, strings.Join(result.Removed, ", "))
+
 	return DeploySecrets(ctx, app, stage, detach)
 }
+
+// unsetResult describes which of the secrets requested to be unset were
+// removed and which didn't exist in the first place.
+type unsetResult struct {
+	Removed  []string
+	NotFound []string
+}
+
+func unsetSecrets(ctx context.Context, appName string, names []string) (*unsetResult, error) {
+	client := flyutil.ClientFromContext(ctx)
+
+	existing, err := client.GetAppSecrets(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &unsetResult{}
+	for _, name := range names {
+		if lo.ContainsBy(existing, func(secret fly.Secret) bool { return secret.Name == name }) {
+			result.Removed = append(result.Removed, name)
+		} else {
+			result.NotFound = append(result.NotFound, name)
+		}
+	}
+
+	if len(result.Removed) > 0 {
+		if _, err := client.UnsetSecrets(ctx, appName, result.Removed); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,52 @@
+package command
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/internal/mock"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestRequireSessionNoPrompt(t *testing.T) {
+	// Not in CI, where there's no offer to sign in either.
+	for _, name := range []string{"CI", "GITHUB_ACTIONS", "CONTINUOUS_INTEGRATION", "BUILD_NUMBER", "RUN_ID"} {
+		if v, ok := os.LookupEnv(name); ok {
+			os.Unsetenv(name)
+			t.Cleanup(func() { os.Setenv(name, v) })
+		}
+	}
+
+	// A terminal where prompts are disabled with --no-prompt doesn't offer to
+	// sign in.
+	ios, _, _, _ := iostreams.Test()
+	ios.SetStdinTTY(true)
+	ios.SetStdoutTTY(true)
+	ios.SetNeverPrompt(true)
+
+	ctx := iostreams.NewContext(context.Background(), ios)
+	ctx = flag.NewContext(ctx, new(cobra.Command).Flags())
+	ctx = flyutil.NewContextWithClient(ctx, &mock.Client{AuthenticatedFunc: func() bool { return false }})
+
+	_, err := RequireSession(ctx)
+	assert.ErrorIs(t, err, fly.ErrNoAuthToken)
+}
+
+func TestRequireSessionNoLogin(t *testing.T) {
+	// Even a terminal that can prompt gets no offer to sign in.
+	ios, _, _, _ := iostreams.Test()
+	ios.SetStdinTTY(true)
+	ios.SetStdoutTTY(true)
+
+	ctx := iostreams.NewContext(context.Background(), ios)
+	ctx = flyutil.NewContextWithClient(ctx, &mock.Client{AuthenticatedFunc: func() bool { return false }})
+
+	_, err := RequireSessionNoLogin(ctx)
+	assert.ErrorIs(t, err, fly.ErrNoAuthToken)
+}
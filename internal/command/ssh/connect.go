@@ -5,14 +5,11 @@ import (
 	"crypto/ed25519"
 	"fmt"
 	"net"
-	"os"
-	"time"
+	"sync"
 
-	"github.com/briandowns/spinner"
 	"github.com/pkg/errors"
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/flyctl/agent"
-	"github.com/superfly/flyctl/helpers"
 	"github.com/superfly/flyctl/internal/flyutil"
 	"github.com/superfly/flyctl/iostreams"
 	"github.com/superfly/flyctl/ssh"
@@ -84,7 +81,7 @@ func Connect(p *ConnectParams, addr string) (*ssh.Client, error) {
 
 	var endSpin context.CancelFunc
 	if !p.DisableSpinner {
-		endSpin = spin(fmt.Sprintf("Connecting to %s...", addr),
+		endSpin = spin(iostreams.FromContext(p.Ctx), fmt.Sprintf("Connecting to %s...", addr),
 			fmt.Sprintf("Connecting to %s... complete\n", addr))
 		defer endSpin()
 	}
@@ -119,24 +116,18 @@ func singleUseSSHCertificate(ctx context.Context, org fly.OrganizationImpl, appN
 	return icert, priv, nil
 }
 
-func spin(in, out string) context.CancelFunc {
-	ctx, cancel := context.WithCancel(context.Background())
-
-	if !helpers.IsTerminal() {
-		fmt.Fprintln(os.Stderr, in)
-		return cancel
+// spin shows in next to a spinner until the returned function is called,
+// which replaces it with out. Without a spinner, in is logged unless quiet
+// mode is on.
+func spin(io *iostreams.IOStreams, in, out string) context.CancelFunc {
+	io.StartProgressIndicatorOrLog(in)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			if io.ProgressIndicatorEnabled() {
+				io.StopProgressIndicatorMsg(out)
+			}
+		})
 	}
-
-	go func() {
-		s := spinner.New(spinner.CharSets[11], 100*time.Millisecond)
-		s.Writer = os.Stderr
-		s.Prefix = in
-		s.FinalMSG = out
-		s.Start()
-		defer s.Stop()
-
-		<-ctx.Done()
-	}()
-
-	return cancel
 }
@@ -305,13 +305,13 @@ func addrForMachines(ctx context.Context, app *fly.AppCompact, console bool) (ad
 			return "", errors.New("--machine can't be used with -s/--select")
 		}
 
-		selected := 0
-
-		if prompt.Select(ctx, &selected, "Select VM:", "", namesWithRegion...); err != nil {
+		selectedMachine, err = prompt.SelectMachine(ctx, "Select VM:", machines, namesWithRegion)
+		switch {
+		case prompt.IsNonInteractive(err):
+			return "", err
+		case err != nil:
 			return "", fmt.Errorf("selecting VM: %w", err)
 		}
-
-		selectedMachine = machines[selected]
 	}
 
 	if selectedMachine != nil {
@@ -14,6 +14,7 @@ import (
 	"github.com/pkg/errors"
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/flyctl/agent"
+	"github.com/superfly/flyctl/iostreams"
 	"github.com/superfly/flyctl/ssh"
 	"github.com/superfly/flyctl/terminal"
 )
@@ -91,7 +92,7 @@ func SSHConnect(p *SSHParams, addr string) error {
 
 	var endSpin context.CancelFunc
 	if !p.DisableSpinner {
-		endSpin = spin(fmt.Sprintf("Connecting to %s...", addr),
+		endSpin = spin(iostreams.FromContext(p.Ctx), fmt.Sprintf("Connecting to %s...", addr),
 			fmt.Sprintf("Connecting to %s... complete\n", addr))
 		defer endSpin()
 	}
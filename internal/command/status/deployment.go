@@ -0,0 +1,159 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/flagnames"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/internal/format"
+	"github.com/superfly/flyctl/internal/machine"
+	"github.com/superfly/flyctl/internal/render"
+)
+
+// deploymentStatus is the state of the rollout of one release.
+type deploymentStatus struct {
+	Version     int
+	Status      string
+	Strategy    string
+	Description string
+	Reason      string
+	Stable      bool
+	ImageRef    string
+	User        string
+	CreatedAt   time.Time
+
+	// Machines are the active machines running the release.
+	Machines []*fly.Machine
+	// OtherMachines is the number of active machines running another release.
+	OtherMachines int
+}
+
+// renderDeploymentStatus shows the rollout of the given release version of
+// the app: the release, and the machines still running it.
+func renderDeploymentStatus(ctx context.Context, app *fly.AppCompact, version int, out io.Writer) error {
+	client := flyutil.ClientFromContext(ctx).GenqClient()
+
+	_ = `# @genqlient
+	query GetAppRelease($appName: String!, $version: Int!) {
+		app(name: $appName) {
+			# @genqlient(pointer: true)
+			release(version: $version) {
+				version
+				status
+				deploymentStrategy
+				description
+				reason
+				stable
+				imageRef
+				createdAt
+				user {
+					email
+				}
+			}
+		}
+	}
+	`
+
+	resp, err := gql.GetAppRelease(ctx, client, app.Name, version)
+	if err != nil {
+		return fmt.Errorf("failed to get release v%d of %s: %w", version, app.Name, err)
+	}
+	release := resp.App.Release
+	if release == nil {
+		return fmt.Errorf("app %s has no release v%d, run 'fly releases' to list its releases", app.Name, version)
+	}
+
+	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{
+		AppCompact: app,
+		AppName:    app.Name,
+	})
+	if err != nil {
+		return err
+	}
+	machines, err := flapsClient.ListActive(ctx)
+	if err != nil {
+		return err
+	}
+	machines, err = machine.FilterByProcessGroup(machines, flag.GetString(ctx, flagnames.ProcessGroup))
+	if err != nil {
+		return err
+	}
+
+	status := newDeploymentStatus(release, machines)
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(out, status)
+	}
+	return status.render(out)
+}
+
+// newDeploymentStatus splits the active machines of the app between those
+// running release and the others.
+func newDeploymentStatus(release *gql.GetAppReleaseAppRelease, machines []*fly.Machine) deploymentStatus {
+	status := deploymentStatus{
+		Version:     release.Version,
+		Status:      release.Status,
+		Strategy:    string(release.DeploymentStrategy),
+		Description: release.Description,
+		Reason:      release.Reason,
+		Stable:      release.Stable,
+		ImageRef:    release.ImageRef,
+		User:        release.User.Email,
+		CreatedAt:   release.CreatedAt,
+		Machines:    []*fly.Machine{},
+	}
+	for _, m := range machines {
+		if getReleaseVersion(m) == strconv.Itoa(release.Version) {
+			status.Machines = append(status.Machines, m)
+		} else {
+			status.OtherMachines++
+		}
+	}
+	sort.Slice(status.Machines, func(i, j int) bool {
+		return status.Machines[i].ID > status.Machines[j].ID
+	})
+	return status
+}
+
+// render writes status as a table of the release and one of its machines.
+func (status deploymentStatus) render(out io.Writer) error {
+	obj := [][]string{{
+		fmt.Sprintf("v%d", status.Version),
+		status.Status,
+		status.Strategy,
+		status.Description,
+		status.ImageRef,
+		status.User,
+		format.RelativeTime(status.CreatedAt),
+	}}
+	if err := render.VerticalTable(out, "Deployment", obj, "Version", "Status", "Strategy", "Description", "Image", "User", "Created"); err != nil {
+		return err
+	}
+
+	if len(status.Machines) > 0 {
+		rows := make([][]string, 0, len(status.Machines))
+		for _, m := range status.Machines {
+			rows = append(rows, machineStatusRow(m))
+		}
+		sort.Slice(rows, func(i, j int) bool {
+			return slices.Compare(rows[i], rows[j]) < 0
+		})
+		if err := render.Table(out, "Machines", rows, "Process", "ID", "Version", "Region", "State", "Role", "Checks", "Last Updated"); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(out, "%d of %d active machines are running v%d\n", len(status.Machines), len(status.Machines)+status.OtherMachines, status.Version)
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package status
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+)
+
+func TestDeploymentStatus(t *testing.T) {
+	machine := func(id, version string) *fly.Machine {
+		return &fly.Machine{
+			ID:     id,
+			Region: "ord",
+			State:  "started",
+			Config: &fly.MachineConfig{Metadata: map[string]string{
+				fly.MachineConfigMetadataKeyFlyProcessGroup:   "app",
+				fly.MachineConfigMetadataKeyFlyReleaseVersion: version,
+			}},
+		}
+	}
+	release := &gql.GetAppReleaseAppRelease{
+		Version:            7,
+		Status:             "failed",
+		DeploymentStrategy: gql.DeploymentStrategyRolling,
+		Description:        "Deploy image",
+		ImageRef:           "registry.fly.io/my-app:deployment-7",
+		CreatedAt:          time.Now().Add(-time.Hour),
+	}
+
+	status := newDeploymentStatus(release, []*fly.Machine{
+		machine("m1", "7"),
+		machine("m2", "8"),
+		machine("m3", "7"),
+		machine("m4", "17"),
+	})
+	require.Len(t, status.Machines, 2)
+	assert.Equal(t, "m3", status.Machines[0].ID)
+	assert.Equal(t, "m1", status.Machines[1].ID)
+	assert.Equal(t, 2, status.OtherMachines)
+
+	var out bytes.Buffer
+	require.NoError(t, status.render(&out))
+	assert.Contains(t, out.String(), "failed")
+	assert.Contains(t, out.String(), "ROLLING")
+	assert.Contains(t, out.String(), "2 of 4 active machines are running v7\n")
+
+	out.Reset()
+	status = newDeploymentStatus(release, []*fly.Machine{machine("m2", "8")})
+	require.NoError(t, status.render(&out))
+	assert.Contains(t, out.String(), "0 of 1 active machines are running v7\n")
+}
@@ -13,8 +13,11 @@ import (
 	"github.com/superfly/fly-go/flaps"
 	"github.com/superfly/flyctl/internal/command/postgres"
 	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/flagnames"
 	"github.com/superfly/flyctl/internal/flapsutil"
 	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/internal/machine"
 	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/iostreams"
 )
@@ -90,6 +93,11 @@ func RenderMachineStatus(ctx context.Context, app *fly.AppCompact, out io.Writer
 		return err
 	}
 
+	machines, err = machine.FilterByProcessGroup(machines, flag.GetString(ctx, flagnames.ProcessGroup))
+	if err != nil {
+		return err
+	}
+
 	sort.Slice(machines, func(i, j int) bool {
 		return machines[i].ID > machines[j].ID
 	})
@@ -181,21 +189,7 @@ func RenderMachineStatus(ctx context.Context, app *fly.AppCompact, out io.Writer
 			if machine.HostStatus != fly.HostStatusOk {
 				hasNotOk = true
 			}
-			var role string
-
-			if v := mConfig.Metadata["role"]; v != "" {
-				role = v
-			}
-			rows = append(rows, []string{
-				getProcessgroup(machine),
-				machine.ID,
-				getReleaseVersion(machine),
-				machine.Region,
-				machine.State,
-				role,
-				render.MachineHealthChecksSummary(machine),
-				machine.UpdatedAt,
-			})
+			rows = append(rows, machineStatusRow(machine))
 		}
 
 		sort.Slice(rows, func(i, j int) bool {
@@ -226,6 +220,20 @@ func RenderMachineStatus(ctx context.Context, app *fly.AppCompact, out io.Writer
 	return nil
 }
 
+// machineStatusRow is the row of the machines table for m.
+func machineStatusRow(m *fly.Machine) []string {
+	return []string{
+		getProcessgroup(m),
+		m.ID,
+		getReleaseVersion(m),
+		m.Region,
+		m.State,
+		m.GetConfig().Metadata["role"],
+		render.MachineHealthChecksSummary(m),
+		m.UpdatedAt,
+	}
+}
+
 func renderMachineJSONStatus(ctx context.Context, app *fly.AppCompact, machines []*fly.Machine) error {
 	var (
 		out    = iostreams.FromContext(ctx).Out
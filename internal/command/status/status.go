@@ -28,6 +28,10 @@ func New() (cmd *cobra.Command) {
 		long = `Show the application's current status including application
 details, tasks, most recent deployment details and in which regions it is
 currently allocated.
+
+With --release, show the rollout of a past release instead, for example to
+investigate a failed deploy: the release's status and strategy, and the
+machines still running it.
 `
 		short = "Show app status"
 	)
@@ -43,6 +47,7 @@ currently allocated.
 		flag.App(),
 		flag.AppConfig(),
 		flag.JSONOutput(),
+		flag.ProcessGroup("Only show machines in the given process group"),
 		flag.Bool{
 			Name:        "all",
 			Description: "Show completed instances",
@@ -51,6 +56,10 @@ currently allocated.
 			Name:        "deployment",
 			Description: "Always show deployment status",
 		},
+		flag.Int{
+			Name:        "release",
+			Description: "Show the rollout of this release version instead: the release and the machines running it",
+		},
 		flag.Bool{
 			Name:        "watch",
 			Description: "Refresh details",
@@ -93,6 +102,14 @@ func once(ctx context.Context, out io.Writer) (err error) {
 		return fmt.Errorf("failed to get app: %w", err)
 	}
 
+	if flag.IsSpecified(ctx, "release") {
+		version := flag.GetInt(ctx, "release")
+		if version < 1 {
+			return fmt.Errorf("--release must be a release version, like 12, got %d", version)
+		}
+		return renderDeploymentStatus(ctx, app, version, out)
+	}
+
 	return RenderMachineStatus(ctx, app, out)
 }
 
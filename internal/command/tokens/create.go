@@ -271,7 +271,6 @@ func makeToken(ctx context.Context, apiClient flyutil.Client, orgID string, expi
 }
 
 func runOrg(ctx context.Context) error {
-	var token string
 	apiClient := flyutil.ClientFromContext(ctx)
 
 	expiry := ""
@@ -289,16 +288,7 @@ func runOrg(ctx context.Context) error {
 		return err
 	}
 
-	token = resp.CreateLimitedAccessToken.LimitedAccessToken.TokenHeader
-
-	io := iostreams.FromContext(ctx)
-	if config.FromContext(ctx).JSONOutput {
-		render.JSON(io.Out, map[string]string{"token": token})
-	} else {
-		fmt.Fprintln(io.Out, token)
-	}
-
-	return nil
+	return printCreatedToken(ctx, resp.CreateLimitedAccessToken.LimitedAccessToken)
 }
 
 func runSSH(ctx context.Context) error {
@@ -471,7 +461,6 @@ func runOrgRead(ctx context.Context) error {
 }
 
 func runDeploy(ctx context.Context) (err error) {
-	var token string
 	apiClient := flyutil.ClientFromContext(ctx)
 
 	expiry := ""
@@ -493,15 +482,28 @@ func runDeploy(ctx context.Context) (err error) {
 		return err
 	}
 
-	token = resp.CreateLimitedAccessToken.LimitedAccessToken.TokenHeader
+	return printCreatedToken(ctx, resp.CreateLimitedAccessToken.LimitedAccessToken)
+}
 
+type createdToken = gql.CreateLimitedAccessTokenCreateLimitedAccessTokenCreateLimitedAccessTokenPayloadLimitedAccessToken
+
+// printCreatedToken prints a newly minted token. The token can't be fetched
+// again, so outside of JSON mode the user is told to save it and how to
+// revoke it.
+func printCreatedToken(ctx context.Context, created createdToken) error {
 	io := iostreams.FromContext(ctx)
 	if config.FromContext(ctx).JSONOutput {
-		render.JSON(io.Out, map[string]string{"token": token})
-	} else {
-		fmt.Fprintln(io.Out, token)
+		return render.JSON(io.Out, map[string]any{
+			"token":      created.TokenHeader,
+			"id":         created.Id,
+			"name":       created.Name,
+			"expires_at": created.ExpiresAt,
+		})
 	}
 
+	fmt.Fprintln(io.Out, created.TokenHeader)
+	fmt.Fprintf(io.ErrOut, "%s This token won't be shown again, so store it somewhere safe. Revoke it with: fly tokens revoke %s\n",
+		io.ColorScheme().Yellow("Warning:"), created.Id)
 	return nil
 }
 
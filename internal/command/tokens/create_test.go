@@ -0,0 +1,47 @@
+package tokens
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestPrintCreatedToken(t *testing.T) {
+	created := createdToken{
+		Id:          "tok_123",
+		Name:        "ci",
+		ExpiresAt:   time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+		TokenHeader: "FlyV1 fm2_secret",
+	}
+
+	newCtx := func(jsonOutput bool) (context.Context, *bytes.Buffer, *bytes.Buffer) {
+		ios, _, out, errOut := iostreams.Test()
+		ctx := iostreams.NewContext(context.Background(), ios)
+		return config.NewContext(ctx, &config.Config{JSONOutput: jsonOutput}), out, errOut
+	}
+
+	ctx, stdout, stderr := newCtx(false)
+	require.NoError(t, printCreatedToken(ctx, created))
+	assert.Equal(t, "FlyV1 fm2_secret\n", stdout.String())
+	assert.Contains(t, stderr.String(), "won't be shown again")
+	assert.Contains(t, stderr.String(), "fly tokens revoke tok_123")
+
+	ctx, stdout, _ = newCtx(true)
+	require.NoError(t, printCreatedToken(ctx, created))
+
+	var out map[string]string
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &out))
+	assert.Equal(t, map[string]string{
+		"token":      "FlyV1 fm2_secret",
+		"id":         "tok_123",
+		"name":       "ci",
+		"expires_at": "2030-01-01T00:00:00Z",
+	}, out)
+}
@@ -0,0 +1,100 @@
+package version
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/superfly/flyctl/internal/buildinfo"
+	"github.com/superfly/flyctl/internal/cache"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyerr"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/internal/update"
+	"github.com/superfly/flyctl/internal/version"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newCheck() *cobra.Command {
+	const (
+		short = "Checks whether a newer version of flyctl is available"
+
+		long = `Checks whether a newer version of flyctl is available, without installing it.
+Exits with a non-zero status when an update is available, so scripts can gate on it.
+Setting FLY_NO_UPDATE_CHECK disables the check.`
+	)
+
+	cmd := command.New("check", short, long, runCheck)
+	cmd.Args = cobra.NoArgs
+
+	flag.Add(cmd, flag.JSONOutput())
+
+	return cmd
+}
+
+type checkResult struct {
+	CurrentVersion  string `json:"current_version"`
+	LatestVersion   string `json:"latest_version,omitempty"`
+	UpdateAvailable bool   `json:"update_available"`
+	ChangelogURL    string `json:"changelog_url,omitempty"`
+	Disabled        bool   `json:"disabled,omitempty"`
+}
+
+func runCheck(ctx context.Context) error {
+	var (
+		io      = iostreams.FromContext(ctx)
+		cfg     = config.FromContext(ctx)
+		current = buildinfo.Version()
+		result  = checkResult{CurrentVersion: current.String()}
+	)
+
+	if update.CheckDisabled() {
+		result.Disabled = true
+		if cfg.JSONOutput {
+			return render.JSON(io.Out, result)
+		}
+		fmt.Fprintln(io.Out, "Update checks are disabled by FLY_NO_UPDATE_CHECK")
+		return nil
+	}
+
+	release, err := update.LatestRelease(ctx, cache.FromContext(ctx).Channel())
+	switch {
+	case err != nil:
+		return fmt.Errorf("failed determining latest release: %w", err)
+	case release == nil:
+		return fmt.Errorf("failed querying latest release information")
+	}
+
+	latest, err := version.Parse(release.Version)
+	if err != nil {
+		return fmt.Errorf("error parsing version: %q, %w", release.Version, err)
+	}
+
+	result.LatestVersion = latest.String()
+	result.UpdateAvailable = latest.Newer(current)
+	if result.UpdateAvailable {
+		result.ChangelogURL = fmt.Sprintf("https://github.com/superfly/flyctl/releases/tag/v%s", latest)
+	}
+
+	if cfg.JSONOutput {
+		if err := render.JSON(io.Out, result); err != nil {
+			return err
+		}
+	} else if result.UpdateAvailable {
+		fmt.Fprintf(io.Out, "Update available v%s -> v%s\n", current, latest)
+		fmt.Fprintf(io.Out, "Changelog: %s\n", result.ChangelogURL)
+	} else {
+		fmt.Fprintf(io.Out, "Already running latest flyctl v%s\n", current)
+	}
+
+	if result.UpdateAvailable {
+		return flyerr.GenericErr{
+			Err:     fmt.Sprintf("flyctl v%s is behind the latest release v%s", current, latest),
+			Suggest: fmt.Sprintf("Run \"%s version upgrade\" to upgrade.", buildinfo.Name()),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestCheckDisabled(t *testing.T) {
+	t.Setenv("FLY_NO_UPDATE_CHECK", "1")
+	t.Setenv("FLY_UPDATE_CHECK", "")
+
+	ios, _, out, _ := iostreams.Test()
+	ctx := iostreams.NewContext(context.Background(), ios)
+	ctx = config.NewContext(ctx, &config.Config{JSONOutput: true})
+
+	require.NoError(t, runCheck(ctx))
+
+	var result checkResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	assert.True(t, result.Disabled)
+	assert.False(t, result.UpdateAvailable)
+	assert.Empty(t, result.LatestVersion)
+}
@@ -22,7 +22,7 @@ func New() *cobra.Command {
 		short = "Show version information for the flyctl command"
 
 		long = `Shows version information for the flyctl command itself, including version
-number and build date.`
+number, commit, build date, Go version, OS and architecture.`
 	)
 
 	version := command.New("version", short, long, run)
@@ -40,6 +40,7 @@ number and build date.`
 	version.AddCommand(
 		newSaveInstall(),
 		newUpgrade(),
+		newCheck(),
 	)
 
 	flag.Add(version, flag.JSONOutput())
@@ -8,7 +8,6 @@ import (
 	"os"
 	"text/template"
 
-	"github.com/AlecAivazis/survey/v2"
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/flyutil"
@@ -16,19 +15,29 @@ import (
 	"github.com/superfly/flyctl/iostreams"
 )
 
-func argOrPrompt(ctx context.Context, nth int, prompt string) (string, error) {
+// argOrPrompt returns the nth argument, prompting for it with msg when it
+// wasn't given. When not running interactively, it fails asking for the
+// argument called name instead.
+func argOrPrompt(ctx context.Context, nth int, name, msg string) (string, error) {
 	args := flag.Args(ctx)
 	if len(args) >= (nth + 1) {
 		return args[nth], nil
 	}
 
 	val := ""
-	err := survey.AskOne(
-		&survey.Input{Message: prompt},
-		&val,
-	)
+	err := prompt.String(ctx, &val, msg, "", false)
 
-	return val, err
+	return val, prompt.Require(err, name+" argument")
+}
+
+// requireArg fails like argOrPrompt would when the nth argument is missing
+// and can't be prompted for, so commands can check before they change
+// anything.
+func requireArg(ctx context.Context, nth int, name string) error {
+	if len(flag.Args(ctx)) > nth || iostreams.FromContext(ctx).CanPrompt() {
+		return nil
+	}
+	return prompt.Require(prompt.ErrNonInteractive, name+" argument")
 }
 
 func orgByArg(ctx context.Context) (*fly.Organization, error) {
@@ -43,17 +52,16 @@ func orgByArg(ctx context.Context) (*fly.Organization, error) {
 		return org, nil
 	}
 
-	apiClient := flyutil.ClientFromContext(ctx)
-	return apiClient.GetOrganizationBySlug(ctx, args[0])
+	return prompt.FindOrg(ctx, args[0])
 }
 
-func resolveOutputWriter(ctx context.Context, idx int, prompt string) (w io.WriteCloser, mustClose bool, err error) {
+func resolveOutputWriter(ctx context.Context, idx int, msg string) (w io.WriteCloser, mustClose bool, err error) {
 	io := iostreams.FromContext(ctx)
 	var f *os.File
 	var filename string
 
 	for {
-		filename, err = argOrPrompt(ctx, idx, prompt)
+		filename, err = argOrPrompt(ctx, idx, "file", msg)
 		if err != nil {
 			return nil, false, err
 		}
@@ -134,13 +142,8 @@ func selectWireGuardPeer(ctx context.Context, client flyutil.Client, slug string
 	}
 
 	selectedPeer := 0
-	prompt := &survey.Select{
-		Message:  "Select peer:",
-		Options:  options,
-		PageSize: 30,
-	}
-	if err := survey.AskOne(prompt, &selectedPeer); err != nil {
-		return "", err
+	if err := prompt.Select(ctx, &selectedPeer, "Select peer:", "", options...); err != nil {
+		return "", prompt.Require(err, "name argument")
 	}
 
 	return peers[selectedPeer].Name, nil
@@ -0,0 +1,68 @@
+package wireguard
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newTestContext(t *testing.T, args ...string) context.Context {
+	t.Helper()
+
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	require.NoError(t, fs.Parse(args))
+
+	// Run with stdin closed, as in CI.
+	ios, _, _, err := iostreams.TestClosedStdin()
+	require.NoError(t, err)
+	t.Cleanup(func() { ios.In.Close() })
+
+	ctx := iostreams.NewContext(context.Background(), ios)
+	return flag.NewContext(ctx, fs)
+}
+
+func TestArgOrPromptNonInteractive(t *testing.T) {
+	ctx := newTestContext(t, "personal", "laptop")
+
+	name, err := argOrPrompt(ctx, 1, "name", "Name: ")
+	require.NoError(t, err)
+	assert.Equal(t, "laptop", name)
+
+	_, err = argOrPrompt(ctx, 2, "region", "Region: ")
+	assert.True(t, prompt.IsNonInteractive(err))
+	assert.EqualError(t, err, "region argument must be specified when not running interactively")
+
+	// A missing file name must fail rather than ask again forever.
+	_, _, err = resolveOutputWriter(ctx, 2, "Filename: ")
+	assert.EqualError(t, err, "file argument must be specified when not running interactively")
+}
+
+func TestTokenStartNonInteractive(t *testing.T) {
+	t.Setenv("FLY_WIREGUARD_TOKEN", "token")
+
+	err := runWireguardTokenStart(newTestContext(t))
+	assert.EqualError(t, err, "name argument must be specified when not running interactively")
+}
+
+func TestRequireArg(t *testing.T) {
+	ctx := newTestContext(t, "personal")
+
+	assert.NoError(t, requireArg(ctx, 0, "org"))
+	assert.EqualError(t, requireArg(ctx, 1, "file"), "file argument must be specified when not running interactively")
+
+	// --no-prompt disables prompts even in a terminal.
+	ios := iostreams.FromContext(ctx)
+	ios.SetStdinTTY(true)
+	ios.SetStdoutTTY(true)
+	assert.NoError(t, requireArg(ctx, 1, "file"))
+
+	ios.SetNeverPrompt(true)
+	assert.True(t, prompt.IsNonInteractive(requireArg(ctx, 1, "file")))
+}
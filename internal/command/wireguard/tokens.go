@@ -56,10 +56,13 @@ func runWireguardTokenCreate(ctx context.Context) error {
 		return err
 	}
 
-	name, err := argOrPrompt(ctx, 1, "Memorable name for WireGuard token: ")
+	name, err := argOrPrompt(ctx, 1, "name", "Memorable name for WireGuard token: ")
 	if err != nil {
 		return err
 	}
+	if err := requireArg(ctx, 2, "file"); err != nil {
+		return err
+	}
 
 	data, err := apiClient.CreateDelegatedWireGuardToken(ctx, org, name)
 	if err != nil {
@@ -109,7 +112,7 @@ func runWireguardTokenDelete(ctx context.Context) error {
 		return err
 	}
 
-	kv, err := argOrPrompt(ctx, 1, "'name:<name>' or token:<token>': ")
+	kv, err := argOrPrompt(ctx, 1, "token", "'name:<name>' or token:<token>': ")
 	if err != nil {
 		return err
 	}
@@ -205,17 +208,17 @@ func runWireguardTokenStart(ctx context.Context) error {
 		return fmt.Errorf("set FLY_WIREGUARD_TOKEN env")
 	}
 
-	name, err := argOrPrompt(ctx, 0, "Name (DNS-compatible) for peer: ")
+	name, err := argOrPrompt(ctx, 0, "name", "Name (DNS-compatible) for peer: ")
 	if err != nil {
 		return err
 	}
 
-	group, err := argOrPrompt(ctx, 1, "Peer group (i.e. 'k8s'): ")
+	group, err := argOrPrompt(ctx, 1, "group", "Peer group (i.e. 'k8s'): ")
 	if err != nil {
 		return err
 	}
 
-	region, err := argOrPrompt(ctx, 2, "Gateway region: ")
+	region, err := argOrPrompt(ctx, 2, "region", "Gateway region: ")
 	if err != nil {
 		return err
 	}
@@ -260,7 +263,7 @@ func runWireguardTokenUpdate(ctx context.Context) error {
 		return fmt.Errorf("set FLY_WIREGUARD_TOKEN env")
 	}
 
-	name, err := argOrPrompt(ctx, 0, "Name (DNS-compatible) for peer: ")
+	name, err := argOrPrompt(ctx, 0, "name", "Name (DNS-compatible) for peer: ")
 	if err != nil {
 		return err
 	}
@@ -143,6 +143,10 @@ func runWireguardCreate(ctx context.Context) error {
 
 	network := flag.GetString(ctx, "network")
 
+	if err := requireArg(ctx, 3, "file"); err != nil {
+		return err
+	}
+
 	state, err := wireguard.Create(apiClient, org, region, name, network, "static")
 	if err != nil {
 		return err
@@ -39,9 +39,14 @@ const (
 	organizationEnvKey         = "FLY_ORGANIZATION"
 	regionEnvKey               = "FLY_REGION"
 	verboseOutputEnvKey        = "FLY_VERBOSE"
+	quietOutputEnvKey          = "FLY_QUIET"
 	jsonOutputEnvKey           = "FLY_JSON"
 	logGQLEnvKey               = "FLY_LOG_GQL_ERRORS"
 	localOnlyEnvKey            = "FLY_LOCAL_ONLY"
+	noAutoAgentEnvKey          = "FLY_NO_AUTO_AGENT"
+	noPromptEnvKey             = "FLY_NO_PROMPT"
+	strictAppEnvKey            = "FLY_STRICT_APP"
+	appConfigEnvKey            = "FLY_CONFIG_ENV"
 
 	defaultAPIBaseURL        = "https://api.fly.io"
 	defaultFlapsBaseURL      = "https://api.machines.dev"
@@ -74,6 +79,10 @@ type Config struct {
 	// VerboseOutput denotes whether the user wants the output to be verbose.
 	VerboseOutput bool
 
+	// QuietOutput denotes whether the user wants spinners and other cosmetic
+	// output suppressed.
+	QuietOutput bool
+
 	// JSONOutput denotes whether the user wants the output to be JSON.
 	JSONOutput bool
 
@@ -98,6 +107,22 @@ type Config struct {
 	// LocalOnly denotes whether the user wants only local operations.
 	LocalOnly bool
 
+	// NoAutoAgent denotes whether the user wants commands to fail instead of
+	// starting a background agent when none is running.
+	NoAutoAgent bool
+
+	// NoPrompt denotes whether the user wants commands to fail instead of
+	// prompting for missing input, even when running in a terminal.
+	NoPrompt bool
+
+	// StrictApp denotes whether the app named by the app config file must be
+	// the app commands act on, refusing --app and a different FLY_APP.
+	StrictApp bool
+
+	// AppConfigEnv denotes the environment whose overlay (e.g.
+	// fly.staging.toml) is merged over the app config file.
+	AppConfigEnv string
+
 	// Tokens is the user's authentication token(s). They are used differently
 	// depending on where they need to be sent.
 	Tokens *tokens.Tokens
@@ -143,13 +168,18 @@ func (cfg *Config) applyEnv() {
 	}
 
 	cfg.VerboseOutput = env.IsTruthy(verboseOutputEnvKey) || cfg.VerboseOutput
+	cfg.QuietOutput = env.IsTruthy(quietOutputEnvKey) || cfg.QuietOutput
 	cfg.JSONOutput = env.IsTruthy(jsonOutputEnvKey) || cfg.JSONOutput
 	cfg.LogGQLErrors = env.IsTruthy(logGQLEnvKey) || cfg.LogGQLErrors
 	cfg.LocalOnly = env.IsTruthy(localOnlyEnvKey) || cfg.LocalOnly
+	cfg.NoAutoAgent = env.IsTruthy(noAutoAgentEnvKey) || cfg.NoAutoAgent
+	cfg.NoPrompt = env.IsTruthy(noPromptEnvKey) || cfg.NoPrompt
+	cfg.StrictApp = env.IsTruthy(strictAppEnvKey) || cfg.StrictApp
 
 	cfg.Organization = env.FirstOrDefault(cfg.Organization,
 		orgEnvKey, organizationEnvKey)
 	cfg.Region = env.FirstOrDefault(cfg.Region, regionEnvKey)
+	cfg.AppConfigEnv = env.FirstOrDefault(cfg.AppConfigEnv, appConfigEnvKey)
 	cfg.RegistryHost = env.FirstOrDefault(cfg.RegistryHost, registryHostEnvKey)
 	cfg.APIBaseURL = env.FirstOrDefault(cfg.APIBaseURL, apiBaseURLEnvKey)
 	cfg.FlapsBaseURL = env.FirstOrDefault(cfg.FlapsBaseURL, flapsBaseURLEnvKey)
@@ -172,6 +202,7 @@ func (cfg *Config) applyFile(path string) (err error) {
 		SendMetrics     bool   `yaml:"send_metrics"`
 		AutoUpdate      bool   `yaml:"auto_update"`
 		SyntheticsAgent bool   `yaml:"synthetics_agent"`
+		StrictApp       bool   `yaml:"strict_app"`
 	}
 	w.SendMetrics = true
 	w.AutoUpdate = true
@@ -183,6 +214,7 @@ func (cfg *Config) applyFile(path string) (err error) {
 		cfg.SendMetrics = w.SendMetrics
 		cfg.AutoUpdate = w.AutoUpdate
 		cfg.SyntheticsAgent = w.SyntheticsAgent
+		cfg.StrictApp = w.StrictApp
 	}
 
 	return
@@ -195,14 +227,19 @@ func (cfg *Config) applyFlags(fs *pflag.FlagSet) {
 	defer cfg.mu.Unlock()
 
 	applyStringFlags(fs, map[string]*string{
-		flagnames.Org:    &cfg.Organization,
-		flagnames.Region: &cfg.Region,
+		flagnames.Org:          &cfg.Organization,
+		flagnames.Region:       &cfg.Region,
+		flagnames.AppConfigEnv: &cfg.AppConfigEnv,
 	})
 
 	applyBoolFlags(fs, map[string]*bool{
-		flagnames.Verbose:    &cfg.VerboseOutput,
-		flagnames.JSONOutput: &cfg.JSONOutput,
-		flagnames.LocalOnly:  &cfg.LocalOnly,
+		flagnames.Verbose:     &cfg.VerboseOutput,
+		flagnames.Quiet:       &cfg.QuietOutput,
+		flagnames.JSONOutput:  &cfg.JSONOutput,
+		flagnames.LocalOnly:   &cfg.LocalOnly,
+		flagnames.NoAutoAgent: &cfg.NoAutoAgent,
+		flagnames.NoPrompt:    &cfg.NoPrompt,
+		flagnames.StrictApp:   &cfg.StrictApp,
 	})
 
 	if fs.Changed(flagnames.AccessToken) {
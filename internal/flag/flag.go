@@ -531,10 +531,28 @@ func NoCache() Bool {
 	}
 }
 
+// CacheFrom returns a string array flag for importing BuildKit cache. The
+// builder needs pull access to registry cache references.
+func CacheFrom() StringArray {
+	return StringArray{
+		Name:        "cache-from",
+		Description: "External cache sources for the build, e.g. a registry image reference or type=registry,ref=<image>. The builder must be able to pull from the registry. Can be specified multiple times.",
+	}
+}
+
+// CacheTo returns a string array flag for exporting BuildKit cache. The
+// builder needs push access to registry cache references.
+func CacheTo() StringArray {
+	return StringArray{
+		Name:        "cache-to",
+		Description: "Cache export destinations for the build, e.g. a registry image reference or type=registry,ref=<image>,mode=max. The builder must be able to push to the registry. Can be specified multiple times.",
+	}
+}
+
 func BuildSecret() StringArray {
 	return StringArray{
 		Name:        "build-secret",
-		Description: "Set of build secrets of NAME=VALUE pairs. Can be specified multiple times. See https://docs.docker.com/engine/reference/commandline/buildx_build/#secret",
+		Description: "Set of build secrets of NAME=VALUE pairs, or NAME=@/path/to/file to read a secret from a file. Use NAME=@@VALUE for a value starting with @. Can be specified multiple times. See https://docs.docker.com/engine/reference/commandline/buildx_build/#secret",
 	}
 }
 
@@ -593,6 +611,51 @@ func JSONOutput() Bool {
 	}
 }
 
+// Format returns a format flag taking a Go template that list commands
+// evaluate once per row.
+func Format() String {
+	return String{
+		Name:        flagnames.Format,
+		Description: `Go template evaluated for each row, with column names as keys, e.g. '{{.Name}} {{.Status}}'`,
+	}
+}
+
+// CSV returns a csv flag for list commands.
+func CSV() Bool {
+	return Bool{
+		Name:        flagnames.CSV,
+		Description: "CSV output, with the column names as the header row",
+	}
+}
+
+// Columns returns a columns flag selecting, and ordering, the columns list
+// commands render.
+func Columns() StringSlice {
+	return StringSlice{
+		Name:        flagnames.Columns,
+		Description: "Comma separated columns to show, in order, e.g. 'Name,Status'",
+	}
+}
+
+// Sort returns a sort flag naming the columns list commands order rows by.
+func Sort() StringSlice {
+	return StringSlice{
+		Name:        flagnames.Sort,
+		Description: "Comma separated columns to sort by, each optionally suffixed with :desc, e.g. 'Status,Name:desc'",
+	}
+}
+
+// ListOutput returns the flags controlling how list commands render their
+// rows; see render.ListOptionsFromContext.
+func ListOutput() Set {
+	return Set{
+		Format(),
+		CSV(),
+		Columns(),
+		Sort(),
+	}
+}
+
 func ProcessGroup(desc string) String {
 	if desc == "" {
 		desc = "The target process group"
@@ -620,6 +683,16 @@ This option may set DOCKER_HOST environment variable for the build container if
 	}
 }
 
+// BuildpacksBuilder the CNB builder image used when building with buildpacks
+const BuildpacksBuilder = "buildpacks-builder"
+
+func BpBuilder() String {
+	return String{
+		Name:        BuildpacksBuilder,
+		Description: "Buildpacks builder image to use, overriding the builder set in the [build] section of fly.toml",
+	}
+}
+
 func RecreateBuilder() Bool {
 	return Bool{
 		Name:        "recreate-builder",
@@ -7,6 +7,9 @@ const (
 	// Verbose denotes the name of the verbose flag.
 	Verbose = "verbose"
 
+	// Quiet denotes the name of the quiet flag.
+	Quiet = "quiet"
+
 	// JSONOutput denotes the name of the json output flag.
 	JSONOutput = "json"
 
@@ -51,4 +54,29 @@ const (
 
 	// ProcessGroup denotes the name of the process group flag.
 	ProcessGroup = "process-group"
+
+	// NoAutoAgent denotes the name of the flag that disables starting the agent automatically.
+	NoAutoAgent = "no-auto-agent"
+
+	// AppConfigEnv denotes the name of the app config overlay flag.
+	AppConfigEnv = "config-env"
+
+	// NoPrompt denotes the name of the flag that disables interactive prompts.
+	NoPrompt = "no-prompt"
+
+	// StrictApp denotes the name of the flag that pins commands to the app
+	// named by the app config file.
+	StrictApp = "strict-app"
+
+	// Format denotes the name of the list output template flag.
+	Format = "format"
+
+	// CSV denotes the name of the CSV output flag.
+	CSV = "csv"
+
+	// Columns denotes the name of the list column selection flag.
+	Columns = "columns"
+
+	// Sort denotes the name of the list sort flag.
+	Sort = "sort"
 )
@@ -6,10 +6,12 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/agnivade/levenshtein"
 	"github.com/docker/go-units"
 	"github.com/samber/lo"
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/flyctl/helpers"
+	"github.com/superfly/flyctl/internal/flyerr"
 )
 
 var (
@@ -33,7 +35,11 @@ func GetMachineGuest(ctx context.Context, guest *fly.MachineGuest) (*fly.Machine
 	}
 
 	if IsSpecified(ctx, "vm-size") {
-		if err := guest.SetSize(GetString(ctx, "vm-size")); err != nil {
+		size := GetString(ctx, "vm-size")
+		if err := validateVMSize(size); err != nil {
+			return nil, err
+		}
+		if err := guest.SetSize(size); err != nil {
 			return nil, err
 		}
 	}
@@ -101,6 +107,32 @@ func GetMachineGuest(ctx context.Context, guest *fly.MachineGuest) (*fly.Machine
 	return guest, nil
 }
 
+// validateVMSize checks a --vm-size against the presets listed by
+// "fly platform vm-sizes", suggesting the closest one for a near miss.
+func validateVMSize(size string) error {
+	if _, ok := fly.MachinePresets[size]; ok {
+		return nil
+	}
+
+	err := flyerr.GenericErr{
+		Err:     fmt.Sprintf("invalid --vm-size %q", size),
+		Suggest: `Run "fly platform vm-sizes" to list the valid sizes`,
+	}
+
+	closest, distance := "", 0
+	for _, preset := range lo.Keys(fly.MachinePresets) {
+		d := levenshtein.ComputeDistance(strings.ToLower(size), preset)
+		if closest == "" || d < distance || (d == distance && preset < closest) {
+			closest, distance = preset, d
+		}
+	}
+	// Anything further away is likely not a typo of the preset.
+	if distance <= 3 {
+		err.Suggest = fmt.Sprintf("Did you mean %s? %s", closest, err.Suggest)
+	}
+	return err
+}
+
 var VMSizeFlags = Set{
 	String{
 		Name:        "vm-size",
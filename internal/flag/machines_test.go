@@ -0,0 +1,40 @@
+package flag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/flyerr"
+)
+
+func TestGetMachineGuestVMSize(t *testing.T) {
+	newCtx := func(args ...string) context.Context {
+		cmd := &cobra.Command{}
+		Add(cmd, VMSizeFlags)
+		require.NoError(t, cmd.ParseFlags(args))
+		return NewContext(context.Background(), cmd.Flags())
+	}
+
+	guest, err := GetMachineGuest(newCtx("--vm-size", "performance-2x"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "performance", guest.CPUKind)
+	assert.Equal(t, 2, guest.CPUs)
+
+	_, err = GetMachineGuest(newCtx("--vm-size", "performance-2"), nil)
+	assert.EqualError(t, err, `invalid --vm-size "performance-2"`)
+	assert.Equal(t, `Did you mean performance-2x? Run "fly platform vm-sizes" to list the valid sizes`, flyerr.GetErrorSuggestion(err))
+
+	_, err = GetMachineGuest(newCtx("--vm-size", "huge"), nil)
+	assert.EqualError(t, err, `invalid --vm-size "huge"`)
+	assert.Equal(t, `Run "fly platform vm-sizes" to list the valid sizes`, flyerr.GetErrorSuggestion(err))
+}
+
+func TestValidateVMSizeAcceptsEveryPreset(t *testing.T) {
+	for size := range fly.MachinePresets {
+		assert.NoError(t, validateVMSize(size), size)
+	}
+}
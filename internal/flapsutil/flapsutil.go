@@ -40,6 +40,7 @@ func NewClientWithOptions(ctx context.Context, opts flaps.NewClientOpts) (*flaps
 			return nil, fmt.Errorf("flaps: can't build tunnel for %s: %w", opts.OrgSlug, err)
 		}
 		opts.DialContext = dialer.DialContext
+		setPeerTransport(opts.AppName, dialer.DialContext)
 
 		flapsBaseUrlString := fmt.Sprintf("http://[%s]:4280", resolvePeerIP(dialer.State().Peer.Peerip))
 		if opts.BaseURL, err = url.Parse(flapsBaseUrlString); err != nil {
@@ -59,6 +60,12 @@ func NewClientWithOptions(ctx context.Context, opts flaps.NewClientOpts) (*flaps
 		opts.Logger = v
 	}
 
+	// Over wireguard the flaps client builds its own transport around
+	// DialContext, which already keeps connections alive for that client.
+	if opts.DialContext == nil && opts.Transport == nil {
+		opts.Transport = appTransport(opts.AppName)
+	}
+
 	return flaps.NewWithOptions(ctx, opts)
 }
 
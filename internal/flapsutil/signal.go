@@ -0,0 +1,115 @@
+package flapsutil
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/internal/buildinfo"
+)
+
+// signals maps the signal names accepted by the Machines API to their Linux
+// numbers, which is what the signal endpoint expects.
+var signals = map[string]int{
+	"SIGABRT": 6,
+	"SIGALRM": 14,
+	"SIGFPE":  8,
+	"SIGHUP":  1,
+	"SIGILL":  4,
+	"SIGINT":  2,
+	"SIGKILL": 9,
+	"SIGPIPE": 13,
+	"SIGQUIT": 3,
+	"SIGSEGV": 11,
+	"SIGTERM": 15,
+	"SIGTRAP": 5,
+	"SIGUSR1": 10,
+	"SIGUSR2": 12,
+}
+
+// ParseSignal normalizes a signal name such as "hup", "SIGHUP" or "sighup" to
+// its canonical form, and fails for signals the Machines API doesn't accept.
+func ParseSignal(name string) (string, error) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if !strings.HasPrefix(name, "SIG") {
+		name = "SIG" + name
+	}
+	if _, ok := signals[name]; !ok {
+		return "", fmt.Errorf("unsupported signal %q, valid signals are: %s", name, strings.Join(SignalNames(), ", "))
+	}
+	return name, nil
+}
+
+// SignalNames returns the signals the Machines API accepts, sorted by name.
+func SignalNames() []string {
+	names := make([]string, 0, len(signals))
+	for name := range signals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Signal sends signal to the main process of a machine. The flaps client has
+// no method for arbitrary signals (only Kill), so the request is built with
+// the client's NewRequest, for its base URL and authorization, and sent over
+// the same transport as the client's own requests, wireguard included.
+func Signal(ctx context.Context, client FlapsClient, appName, machineID, signal string) error {
+	signal, err := ParseSignal(signal)
+	if err != nil {
+		return err
+	}
+
+	in := map[string]int{"signal": signals[signal]}
+	req, err := client.NewRequest(ctx, http.MethodPost, fmt.Sprintf("/apps/%s/machines/%s/signal", appName, machineID), in, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", buildinfo.UserAgent())
+
+	httpClient, err := appHTTPClient(ctx, appName)
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send %s to machine %s: %w", signal, machineID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode > 299 {
+		body, _ := io.ReadAll(resp.Body)
+		return &flaps.FlapsError{
+			OriginalError:      fmt.Errorf("failed to send %s to machine %s: %w", signal, machineID, apiError(resp.StatusCode, body)),
+			ResponseStatusCode: resp.StatusCode,
+			ResponseBody:       body,
+			FlyRequestId:       resp.Header.Get("Fly-Request-Id"),
+		}
+	}
+	return nil
+}
+
+// apiError returns the error message of a Machines API error response, like
+// the flaps client does for its own requests.
+func apiError(statusCode int, body []byte) error {
+	var apiErr struct {
+		Error   string `json:"error"`
+		Message string `json:"message,omitempty"`
+	}
+	switch {
+	case json.Unmarshal(body, &apiErr) != nil:
+		return fmt.Errorf("request returned non-2xx status: %d: %s", statusCode, strings.TrimSpace(string(body)))
+	case apiErr.Message != "":
+		return errors.New(apiErr.Message)
+	case apiErr.Error != "":
+		return errors.New(apiErr.Error)
+	default:
+		return fmt.Errorf("request returned non-2xx status: %d", statusCode)
+	}
+}
@@ -0,0 +1,94 @@
+package flapsutil
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/fly-go/tokens"
+)
+
+func TestParseSignal(t *testing.T) {
+	for _, name := range []string{"SIGHUP", "sighup", "hup", " HUP "} {
+		signal, err := ParseSignal(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, "SIGHUP", signal)
+	}
+
+	_, err := ParseSignal("SIGWINCH")
+	assert.ErrorContains(t, err, `unsupported signal "SIGWINCH"`)
+
+	_, err = ParseSignal("9")
+	assert.Error(t, err)
+}
+
+func TestSignal(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = append(got, r.URL.Path+" "+string(body))
+		if strings.Contains(r.URL.Path, "/missing/") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"machine not found"}`))
+			return
+		}
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	t.Setenv("FLY_FLAPS_BASE_URL", server.URL)
+	client, err := NewClientWithOptions(context.Background(), flaps.NewClientOpts{
+		AppName: "signal-app",
+		Tokens:  tokens.Parse("test-token"),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, Signal(context.Background(), client, "signal-app", "m1", "hup"))
+
+	err = Signal(context.Background(), client, "signal-app", "missing", "SIGUSR1")
+	var flapsErr *flaps.FlapsError
+	require.ErrorAs(t, err, &flapsErr)
+	assert.Equal(t, http.StatusNotFound, flapsErr.ResponseStatusCode)
+	assert.ErrorContains(t, err, "failed to send SIGUSR1 to machine missing: machine not found")
+
+	assert.Equal(t, []string{
+		`/v1/apps/signal-app/machines/m1/signal {"signal":1}`,
+		`/v1/apps/signal-app/machines/missing/signal {"signal":10}`,
+	}, got)
+}
+
+func TestSignalUsesPeerTransport(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+
+	// The flaps client points at an address only the peer dialer can reach.
+	t.Setenv("FLY_FLAPS_BASE_URL", "http://peer.invalid:4280")
+	client, err := NewClientWithOptions(context.Background(), flaps.NewClientOpts{
+		AppName: "peer-app",
+		Tokens:  tokens.Parse("test-token"),
+	})
+	require.NoError(t, err)
+
+	setPeerTransport("peer-app", func(ctx context.Context, network, address string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, network, server.Listener.Addr().String())
+	})
+	defer func() {
+		transportsMu.Lock()
+		delete(peerTransports, "peer-app")
+		transportsMu.Unlock()
+	}()
+
+	require.NoError(t, Signal(context.Background(), client, "peer-app", "m1", "SIGTERM"))
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,78 @@
+package flapsutil
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/logger"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// maxIdleConnsPerApp bounds the idle connections kept per app. The default
+// transport keeps only two, which forces bulk machine operations running in
+// parallel to redial most of their requests.
+const maxIdleConnsPerApp = 32
+
+var (
+	transportsMu   sync.Mutex
+	transports     = map[string]*http.Transport{}
+	peerTransports = map[string]*http.Transport{}
+)
+
+// appTransport returns the keep-alive transport shared by every flaps client
+// created for appName, so connections to the Machines API are reused across
+// calls. Requests still carry their own context, which cancels them
+// individually without affecting the pool.
+func appTransport(appName string) *http.Transport {
+	transportsMu.Lock()
+	defer transportsMu.Unlock()
+
+	if t, ok := transports[appName]; ok {
+		return t
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConns = maxIdleConnsPerApp
+	t.MaxIdleConnsPerHost = maxIdleConnsPerApp
+	t.IdleConnTimeout = 90 * time.Second
+	transports[appName] = t
+	return t
+}
+
+// setPeerTransport records the transport dialing the Machines API over
+// wireguard for appName, which the flaps client builds internally when
+// FLY_FLAPS_BASE_URL is "peer".
+func setPeerTransport(appName string, dialContext func(ctx context.Context, network, address string) (net.Conn, error)) {
+	transportsMu.Lock()
+	defer transportsMu.Unlock()
+
+	peerTransports[appName] = &http.Transport{DialContext: dialContext}
+}
+
+// clientTransport returns the transport the flaps client for appName sends
+// its requests over: the wireguard one if there is one, or the shared one.
+func clientTransport(appName string) http.RoundTripper {
+	transportsMu.Lock()
+	t, ok := peerTransports[appName]
+	transportsMu.Unlock()
+
+	if ok {
+		return t
+	}
+	return appTransport(appName)
+}
+
+// appHTTPClient returns an HTTP client for requests built with the flaps
+// client's NewRequest that it has no method for. It goes over the same
+// transport as the flaps client, with the same retries and logging.
+func appHTTPClient(ctx context.Context, appName string) (*http.Client, error) {
+	var l fly.Logger
+	if v := logger.MaybeFromContext(ctx); v != nil {
+		l = v
+	}
+	return fly.NewHTTPClient(l, otelhttp.NewTransport(clientTransport(appName)))
+}
@@ -0,0 +1,66 @@
+package flapsutil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/fly-go/tokens"
+)
+
+func TestClientsReuseConnectionsPerApp(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("slow") {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	t.Setenv("FLY_FLAPS_BASE_URL", server.URL)
+
+	newClient := func() *flaps.Client {
+		client, err := NewClientWithOptions(context.Background(), flaps.NewClientOpts{
+			AppName: "pooled-app",
+			Tokens:  tokens.Parse("test-token"),
+		})
+		require.NoError(t, err)
+		return client
+	}
+
+	var reused []bool
+	ctx := httptrace.WithClientTrace(context.Background(), &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) { reused = append(reused, info.Reused) },
+	})
+
+	// Separate clients for the same app share one pool.
+	for i := 0; i < 3; i++ {
+		_, err := newClient().List(ctx, "")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []bool{false, true, true}, reused)
+	assert.Same(t, appTransport("pooled-app"), appTransport("pooled-app"))
+	assert.NotSame(t, appTransport("pooled-app"), appTransport("other-app"))
+
+	// Cancelling one call doesn't affect the others.
+	client := newClient()
+	cancelled, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.List(cancelled, "slow")
+	require.Error(t, err)
+
+	_, err = client.List(context.Background(), "")
+	assert.NoError(t, err)
+}
@@ -74,6 +74,21 @@ func GetErrorSuggestion(err error) string {
 	return ""
 }
 
+// ErrorExitCode is an error for a failure the CLI should report with a
+// specific exit code, like the one of a remote process
+type ErrorExitCode interface {
+	error
+	ExitCode() int
+}
+
+func GetErrorExitCode(err error) (int, bool) {
+	var ferr ErrorExitCode
+	if errors.As(err, &ferr) {
+		return ferr.ExitCode(), true
+	}
+	return 0, false
+}
+
 func PrintCLIOutput(err error) {
 	if err == nil {
 		return
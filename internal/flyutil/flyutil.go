@@ -18,5 +18,8 @@ func NewClientFromOptions(ctx context.Context, opts fly.ClientOptions) *fly.Clie
 	if v := logger.MaybeFromContext(ctx); v != nil && opts.Logger == nil {
 		opts.Logger = v
 	}
+	if opts.Logger != nil {
+		opts.Logger = &redactingLogger{inner: opts.Logger}
+	}
 	return fly.NewClientFromOptions(opts)
 }
@@ -0,0 +1,95 @@
+package flyutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	fly "github.com/superfly/fly-go"
+)
+
+const redacted = "[redacted]"
+
+var redactTokenRx = regexp.MustCompile(`(fo1_|fm1[ar]_|fm2_)[a-zA-Z0-9/+_-]+=*`)
+
+// redactingLogger scrubs tokens and secret values from everything the API
+// client logs, so request and response bodies can be shown with --verbose.
+type redactingLogger struct {
+	inner fly.Logger
+}
+
+func (l *redactingLogger) Debug(v ...interface{}) {
+	if len(v) == 1 {
+		if s, ok := v[0].(string); ok {
+			l.inner.Debug(redact(s))
+			return
+		}
+	}
+	l.inner.Debug(redact(fmt.Sprint(v...)))
+}
+
+func (l *redactingLogger) Debugf(format string, v ...interface{}) {
+	l.inner.Debug(redact(fmt.Sprintf(format, v...)))
+}
+
+// redact replaces sensitive values in s. In JSON documents, scalar values of
+// credential-like keys are replaced, as are the values of secrets nested
+// under such keys (e.g. the setSecrets input). API tokens are masked
+// everywhere.
+func redact(s string) string {
+	var doc any
+	if err := json.Unmarshal([]byte(s), &doc); err == nil {
+		if data, err := json.Marshal(redactValue(doc, false)); err == nil {
+			s = string(data)
+		}
+	}
+	return redactTokenRx.ReplaceAllString(s, "$1"+redacted)
+}
+
+func redactValue(v any, inSecrets bool) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, val := range v {
+			switch {
+			case inSecrets && strings.EqualFold(key, "value"):
+				v[key] = redacted
+			case isSensitiveKey(key):
+				if isScalar(val) {
+					v[key] = redacted
+				} else {
+					v[key] = redactValue(val, true)
+				}
+			default:
+				v[key] = redactValue(val, inSecrets)
+			}
+		}
+		return v
+	case []any:
+		for i, val := range v {
+			v[i] = redactValue(val, inSecrets)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func isScalar(v any) bool {
+	switch v.(type) {
+	case map[string]any, []any, nil:
+		return false
+	default:
+		return true
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range []string{"secret", "token", "password", "private", "macaroon"} {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
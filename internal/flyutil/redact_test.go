@@ -0,0 +1,28 @@
+package flyutil
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactSecretInputs(t *testing.T) {
+	body := `{"query":"mutation($input: SetSecretsInput!) { setSecrets(input: $input) { release { id } } }","variables":{"input":{"appId":"my-app","secrets":[{"key":"DATABASE_URL","value":"postgres://user:hunter2@db"}]}}}`
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal([]byte(redact(body)), &got))
+
+	secrets := got["variables"].(map[string]any)["input"].(map[string]any)["secrets"].([]any)
+	secret := secrets[0].(map[string]any)
+	assert.Equal(t, "DATABASE_URL", secret["key"])
+	assert.Equal(t, redacted, secret["value"])
+	assert.Equal(t, "my-app", got["variables"].(map[string]any)["input"].(map[string]any)["appId"])
+}
+
+func TestRedactTokens(t *testing.T) {
+	assert.Equal(t, `{"accessToken":"[redacted]","name":"deploy"}`, redact(`{"accessToken":"abc123","name":"deploy"}`))
+	assert.Equal(t, "Authorization: FlyV1 fm2_[redacted]", redact("Authorization: FlyV1 fm2_lJPECAAAAAAAAAc="))
+	assert.Equal(t, "plain text", redact("plain text"))
+}
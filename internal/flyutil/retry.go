@@ -0,0 +1,113 @@
+package flyutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/PuerkitoBio/rehttp"
+)
+
+const (
+	// MaxRetriesEnv overrides the number of times a failed API request is
+	// retried.
+	MaxRetriesEnv = "FLY_API_MAX_RETRIES"
+
+	defaultMaxRetries = 3
+)
+
+// MaxRetries returns the number of retries for API requests, as configured
+// via FLY_API_MAX_RETRIES.
+func MaxRetries() int {
+	if v, err := strconv.Atoi(os.Getenv(MaxRetriesEnv)); err == nil && v >= 0 {
+		return v
+	}
+	return defaultMaxRetries
+}
+
+// NewRetryTransport wraps rt so that idempotent requests, GETs, HEADs and
+// GraphQL queries, are retried with jittered exponential backoff when they
+// fail with a 504 status or because the connection was refused. Mutations
+// are never retried, as they might have been applied. Retries stop as soon
+// as the request's context is canceled.
+//
+// The fly-go clients already retry 502 and 503 responses and temporary
+// network errors on top of their transport, so those are left to them
+// rather than retried at both levels.
+func NewRetryTransport(rt http.RoundTripper, maxRetries int) http.RoundTripper {
+	return &retryTransport{
+		rt: rt,
+		retrying: rehttp.NewTransport(
+			rt,
+			rehttp.RetryAll(
+				rehttp.RetryMaxRetries(maxRetries),
+				rehttp.RetryAny(
+					rehttp.RetryIsErr(isConnectionRefused),
+					rehttp.RetryStatuses(http.StatusGatewayTimeout),
+				),
+			),
+			rehttp.ExpJitterDelay(100*time.Millisecond, 2*time.Second),
+		),
+	}
+}
+
+type retryTransport struct {
+	rt       http.RoundTripper
+	retrying http.RoundTripper
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	idempotent, err := isIdempotent(req)
+	if err != nil {
+		return nil, err
+	}
+	if idempotent {
+		return t.retrying.RoundTrip(req)
+	}
+	return t.rt.RoundTrip(req)
+}
+
+// isIdempotent reports whether req can safely be sent again: a GET, a HEAD,
+// or a GraphQL query. The body of a POST is read to tell queries from
+// mutations, and replaced with a copy.
+func isIdempotent(req *http.Request) (bool, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return true, nil
+	case http.MethodPost:
+	default:
+		return false, nil
+	}
+	if req.Body == nil || req.Body == http.NoBody {
+		return false, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return false, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	var gql struct {
+		Query string `json:"query"`
+	}
+	if json.Unmarshal(body, &gql) != nil {
+		return false, nil
+	}
+	// Operations other than queries start with their type; queries may
+	// also use the shorthand form, { ... }.
+	query := strings.TrimSpace(gql.Query)
+	return strings.HasPrefix(query, "query") || strings.HasPrefix(query, "{"), nil
+}
+
+func isConnectionRefused(err error) bool {
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
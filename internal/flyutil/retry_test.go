@@ -0,0 +1,159 @@
+package flyutil
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *fly.Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return NewClientFromOptions(context.Background(), fly.ClientOptions{
+		BaseURL: server.URL,
+		Transport: &fly.Transport{
+			UnderlyingTransport: NewRetryTransport(http.DefaultTransport, 3),
+		},
+	})
+}
+
+func TestRetryTransportRetriesQueries(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"app":{"name":"my-app"}}}`))
+	})
+
+	resp, err := client.RunWithContext(context.Background(), client.NewRequest(`query { app { name } }`))
+	require.NoError(t, err)
+	assert.Equal(t, "my-app", resp.App.Name)
+	assert.EqualValues(t, 3, calls.Load())
+}
+
+func TestRetryTransportDoesNotRetryMutations(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusGatewayTimeout)
+	})
+
+	_, err := client.RunWithContext(context.Background(), client.NewRequest(`mutation { deleteApp(appId: "my-app") { organization { id } } }`))
+	assert.Error(t, err)
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func TestRetryTransportLeavesClientRetriesAlone(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	// fly-go retries 502s 3 times itself; they must not be retried again
+	// underneath.
+	_, err := client.RunWithContext(context.Background(), client.NewRequest(`query { app { name } }`))
+	assert.Error(t, err)
+	assert.EqualValues(t, 4, calls.Load())
+}
+
+// countingTransport counts the requests it sends.
+type countingTransport struct {
+	calls atomic.Int32
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.calls.Add(1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestRetryTransportRetriesRefusedConnections(t *testing.T) {
+	// A closed listener's address refuses connections.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	url := "http://" + listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	counter := &countingTransport{}
+	rt := NewRetryTransport(counter, 2)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	_, err = rt.RoundTrip(req)
+	assert.ErrorIs(t, err, syscall.ECONNREFUSED)
+	assert.EqualValues(t, 3, counter.calls.Load())
+
+	counter.calls.Store(0)
+	req, err = http.NewRequest(http.MethodPost, url, strings.NewReader(`{"query":"mutation { createApp { app { id } } }"}`))
+	require.NoError(t, err)
+	_, err = rt.RoundTrip(req)
+	assert.ErrorIs(t, err, syscall.ECONNREFUSED)
+	assert.EqualValues(t, 1, counter.calls.Load())
+}
+
+func TestIsIdempotent(t *testing.T) {
+	for body, want := range map[string]bool{
+		`{"query":"query GetApp { app { name } }"}`:     true,
+		`{"query":"  { app { name } }"}`:                true,
+		`{"query":"mutation { deleteApp { id } }"}`:     false,
+		`{"query":"subscription { logs { message } }"}`: false,
+		`not json`: false,
+	} {
+		req, err := http.NewRequest(http.MethodPost, "http://example.com", strings.NewReader(body))
+		require.NoError(t, err)
+		got, err := isIdempotent(req)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, body)
+
+		b, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, body, string(b), "the body is left for the request")
+	}
+
+	for method, want := range map[string]bool{http.MethodGet: true, http.MethodHead: true, http.MethodDelete: false, http.MethodPut: false} {
+		req, err := http.NewRequest(method, "http://example.com", nil)
+		require.NoError(t, err)
+		got, err := isIdempotent(req)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, method)
+	}
+}
+
+func TestRetryTransportDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	_, err := client.RunWithContext(context.Background(), client.NewRequest(`query { app { name } }`))
+	assert.Error(t, err)
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func TestMaxRetries(t *testing.T) {
+	t.Setenv(MaxRetriesEnv, "")
+	assert.Equal(t, defaultMaxRetries, MaxRetries())
+
+	t.Setenv(MaxRetriesEnv, "7")
+	assert.Equal(t, 7, MaxRetries())
+
+	t.Setenv(MaxRetriesEnv, "nope")
+	assert.Equal(t, defaultMaxRetries, MaxRetries())
+}
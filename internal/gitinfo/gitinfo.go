@@ -0,0 +1,61 @@
+// Package gitinfo implements detection of the git commit a directory is
+// checked out at, to record where a deploy came from.
+package gitinfo
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+)
+
+// Info describes the git checkout a deploy was made from.
+type Info struct {
+	// Commit is the full SHA of the checked out commit.
+	Commit string `json:"commit"`
+
+	// Branch is the checked out branch, empty when HEAD is detached.
+	Branch string `json:"branch,omitempty"`
+
+	// Dirty reports whether the working tree has uncommitted changes.
+	Dirty bool `json:"dirty,omitempty"`
+}
+
+// Detect returns the git details of dir, or nil when dir isn't in a git
+// repository with at least one commit, or git isn't installed.
+func Detect(ctx context.Context, dir string) *Info {
+	commit, err := run(ctx, dir, "rev-parse", "HEAD")
+	if err != nil || commit == "" {
+		return nil
+	}
+
+	info := &Info{Commit: commit}
+	if branch, err := run(ctx, dir, "rev-parse", "--abbrev-ref", "HEAD"); err == nil && branch != "HEAD" {
+		info.Branch = branch
+	}
+	// Untracked files, such as build output, don't make the tree dirty.
+	if status, err := run(ctx, dir, "status", "--porcelain", "--untracked-files=no"); err == nil {
+		info.Dirty = status != ""
+	}
+	return info
+}
+
+// ShortCommit returns the abbreviated commit SHA, suffixed with -dirty when the
+// working tree had uncommitted changes.
+func (i *Info) ShortCommit() string {
+	commit := i.Commit
+	if len(commit) > 7 {
+		commit = commit[:7]
+	}
+	if i.Dirty {
+		commit += "-dirty"
+	}
+	return commit
+}
+
+func run(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
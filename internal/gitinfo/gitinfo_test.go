@@ -0,0 +1,55 @@
+package gitinfo
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func TestDetect(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	assert.Nil(t, Detect(ctx, dir))
+
+	git(t, dir, "init", "-q", "-b", "main")
+	assert.Nil(t, Detect(ctx, dir), "no commits yet")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tracked"), []byte("x"), 0o644))
+	git(t, dir, "add", "tracked")
+	git(t, dir, "commit", "-q", "-m", "initial")
+	info := Detect(ctx, dir)
+	require.NotNil(t, info)
+	assert.Len(t, info.Commit, 40)
+	assert.Equal(t, "main", info.Branch)
+	assert.False(t, info.Dirty)
+	assert.Equal(t, info.Commit[:7], info.ShortCommit())
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "untracked"), []byte("x"), 0o644))
+	assert.False(t, Detect(ctx, dir).Dirty, "untracked files don't count")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tracked"), []byte("y"), 0o644))
+	info = Detect(ctx, dir)
+	assert.True(t, info.Dirty)
+	assert.Equal(t, info.Commit[:7]+"-dirty", info.ShortCommit())
+
+	git(t, dir, "checkout", "-q", "--detach")
+	assert.Empty(t, Detect(ctx, dir).Branch)
+}
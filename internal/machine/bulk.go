@@ -0,0 +1,113 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/sourcegraph/conc/pool"
+	fly "github.com/superfly/fly-go"
+)
+
+// MachineError records the failure of an operation on a single machine.
+type MachineError struct {
+	MachineID string
+	Err       error
+}
+
+func (e *MachineError) Error() string {
+	return fmt.Sprintf("%s: %v", e.MachineID, e.Err)
+}
+
+func (e *MachineError) Unwrap() error {
+	return e.Err
+}
+
+// BulkError is returned by RunConcurrently when the operation failed on at
+// least one machine. Failures are ordered as they happened, so the first is
+// the one that stopped the operation. Skipped counts the machines the
+// operation wasn't started on, or was canceled on, because of that failure.
+type BulkError struct {
+	Total    int
+	Skipped  int
+	Failures []*MachineError
+}
+
+func (e *BulkError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "failed on %d of %d machines", len(e.Failures), e.Total)
+	if e.Skipped > 0 {
+		fmt.Fprintf(&b, ", %d skipped", e.Skipped)
+	}
+	b.WriteString(":")
+	for _, f := range e.Failures {
+		fmt.Fprintf(&b, "\n  %s", f.Error())
+	}
+	return b.String()
+}
+
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		errs = append(errs, f)
+	}
+	return errs
+}
+
+// RunConcurrently runs op against every machine, with at most parallelism
+// operations in flight. It fails fast: the first failure cancels the context
+// of the operations in flight and no further machine is attempted, so a
+// rolling operation doesn't go on to take down the rest of the app. With a
+// parallelism of 1 it stops at the first failed machine. The failures are
+// combined into a *BulkError. Leases are not managed here: callers acquire
+// them beforehand (see AcquireLeases) and release them once RunConcurrently
+// returns.
+func RunConcurrently(ctx context.Context, machines []*fly.Machine, parallelism int, op func(context.Context, *fly.Machine) error) error {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	var (
+		mu      sync.Mutex
+		bulkErr = &BulkError{Total: len(machines)}
+		p       = pool.New().WithMaxGoroutines(parallelism).WithContext(ctx).WithCancelOnError()
+	)
+
+	for _, m := range machines {
+		p.Go(func(ctx context.Context) error {
+			if ctx.Err() != nil {
+				mu.Lock()
+				bulkErr.Skipped++
+				mu.Unlock()
+				return nil
+			}
+
+			err := op(ctx, m)
+			if err == nil {
+				return nil
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			// An operation that fails once an earlier failure canceled it
+			// is reported as skipped, not as a failure of its own.
+			if ctx.Err() != nil && len(bulkErr.Failures) > 0 {
+				bulkErr.Skipped++
+				return nil
+			}
+			bulkErr.Failures = append(bulkErr.Failures, &MachineError{MachineID: m.ID, Err: err})
+			return err
+		})
+	}
+	p.Wait()
+
+	if len(bulkErr.Failures) == 0 {
+		// Machines are only skipped without a failure if ctx was canceled.
+		if bulkErr.Skipped > 0 {
+			return ctx.Err()
+		}
+		return nil
+	}
+	return bulkErr
+}
@@ -0,0 +1,96 @@
+package machine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+)
+
+func TestRunConcurrently(t *testing.T) {
+	machines := []*fly.Machine{{ID: "m1"}, {ID: "m2"}, {ID: "m3"}, {ID: "m4"}, {ID: "m5"}}
+	errBoom := errors.New("boom")
+
+	var running, peak atomic.Int32
+	err := RunConcurrently(context.Background(), machines, 2, func(ctx context.Context, m *fly.Machine) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+
+	// m2 fails while m1 is in flight: m1 is canceled and, like the rest,
+	// reported as skipped.
+	var attempted []string
+	var mu sync.Mutex
+	m1Started := make(chan struct{})
+	err = RunConcurrently(context.Background(), machines, 2, func(ctx context.Context, m *fly.Machine) error {
+		mu.Lock()
+		attempted = append(attempted, m.ID)
+		mu.Unlock()
+		switch m.ID {
+		case "m1":
+			close(m1Started)
+			<-ctx.Done()
+			return ctx.Err()
+		case "m2":
+			<-m1Started
+			return errBoom
+		}
+		return nil
+	})
+
+	var bulkErr *BulkError
+	require.ErrorAs(t, err, &bulkErr)
+	assert.ElementsMatch(t, []string{"m1", "m2"}, attempted)
+	assert.Equal(t, 5, bulkErr.Total)
+	assert.Equal(t, 4, bulkErr.Skipped)
+	require.Len(t, bulkErr.Failures, 1)
+	assert.Equal(t, "m2", bulkErr.Failures[0].MachineID)
+	assert.ErrorIs(t, err, errBoom)
+	assert.NotErrorIs(t, err, context.Canceled)
+	assert.Equal(t, "failed on 1 of 5 machines, 4 skipped:\n  m2: boom", err.Error())
+
+	err = RunConcurrently(context.Background(), machines, 0, func(context.Context, *fly.Machine) error { return nil })
+	assert.NoError(t, err)
+}
+
+func TestRunConcurrentlySequentialStopsAtFailure(t *testing.T) {
+	machines := []*fly.Machine{{ID: "m1"}, {ID: "m2"}, {ID: "m3"}}
+
+	var attempted []string
+	err := RunConcurrently(context.Background(), machines, 1, func(ctx context.Context, m *fly.Machine) error {
+		attempted = append(attempted, m.ID)
+		if m.ID == "m2" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+	assert.Equal(t, []string{"m1", "m2"}, attempted)
+	assert.EqualError(t, err, "failed on 1 of 3 machines, 1 skipped:\n  m2: boom")
+}
+
+func TestRunConcurrentlyCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := RunConcurrently(ctx, []*fly.Machine{{ID: "m1"}}, 1, func(context.Context, *fly.Machine) error {
+		t.Fatal("no machine is attempted once canceled")
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
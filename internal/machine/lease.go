@@ -8,12 +8,63 @@ import (
 
 	"github.com/sourcegraph/conc/pool"
 	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/flapsutil"
 	"github.com/superfly/flyctl/iostreams"
 )
 
 const maxConcurrentLeases = 20
 
+// DefaultLeaseTTL is how long the leases AcquireLease takes last, unless the
+// context sets another TTL with WithLeaseTTL.
+const DefaultLeaseTTL = 120 * time.Second
+
+type leaseTTLKey struct{}
+
+// WithLeaseTTL returns a copy of ctx in which the leases AcquireLease takes
+// last for ttl.
+func WithLeaseTTL(ctx context.Context, ttl time.Duration) context.Context {
+	return context.WithValue(ctx, leaseTTLKey{}, ttl)
+}
+
+// LeaseTTLFromContext returns the TTL of the leases taken with ctx.
+func LeaseTTLFromContext(ctx context.Context) time.Duration {
+	if ttl, ok := ctx.Value(leaseTTLKey{}).(time.Duration); ok {
+		return ttl
+	}
+	return DefaultLeaseTTL
+}
+
+// LeaseTTLFlag returns the --lease-ttl flag of commands that lease machines.
+func LeaseTTLFlag() flag.String {
+	return flag.String{
+		Name:        "lease-ttl",
+		Description: "How long the leases the command takes on machines last, like 5m, to hold them longer during slow operations. Defaults to " + DefaultLeaseTTL.String(),
+	}
+}
+
+// WithLeaseTTLFlag returns a copy of ctx in which the leases AcquireLease
+// takes last for --lease-ttl, if it's set.
+func WithLeaseTTLFlag(ctx context.Context) (context.Context, error) {
+	if !flag.IsSpecified(ctx, "lease-ttl") {
+		return ctx, nil
+	}
+	ttl, err := ParseLeaseTTL(flag.GetString(ctx, "lease-ttl"), "lease-ttl")
+	if err != nil {
+		return nil, err
+	}
+	return WithLeaseTTL(ctx, ttl), nil
+}
+
+// ParseLeaseTTL parses the value of a lease TTL flag.
+func ParseLeaseTTL(v, flagName string) (time.Duration, error) {
+	ttl, err := time.ParseDuration(v)
+	if err != nil || ttl < time.Second {
+		return 0, fmt.Errorf("invalid value %q for --%s: it must be a duration of at least 1s, like 90s or 30m", v, flagName)
+	}
+	return ttl, nil
+}
+
 type releaseLeaseFunc func()
 
 // AcquireAllLeases works to acquire/attach a lease for each active machine.
@@ -103,7 +154,7 @@ func AcquireLease(ctx context.Context, machine *fly.Machine) (*fly.Machine, rele
 
 	flapsClient := flapsutil.ClientFromContext(ctx)
 
-	lease, err := flapsClient.AcquireLease(ctx, machine.ID, fly.IntPointer(120))
+	lease, err := flapsClient.AcquireLease(ctx, machine.ID, fly.IntPointer(int(LeaseTTLFromContext(ctx).Seconds())))
 	if err != nil {
 		return nil, func() {}, fmt.Errorf("failed to obtain lease: %w", err)
 	}
@@ -0,0 +1,70 @@
+package machine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/mock"
+)
+
+func TestAcquireLeaseTTL(t *testing.T) {
+	var ttls []int
+	ctx := flapsutil.NewContextWithClient(context.Background(), &mock.FlapsClient{
+		AcquireLeaseFunc: func(ctx context.Context, machineID string, ttl *int) (*fly.MachineLease, error) {
+			ttls = append(ttls, *ttl)
+			return &fly.MachineLease{Data: &fly.MachineLeaseData{Nonce: "nonce", Version: "v1"}}, nil
+		},
+	})
+
+	m, _, err := AcquireLease(ctx, &fly.Machine{ID: "m1", InstanceID: "v1"})
+	require.NoError(t, err)
+	assert.Equal(t, "nonce", m.LeaseNonce)
+
+	_, _, err = AcquireLease(WithLeaseTTL(ctx, 10*time.Minute), &fly.Machine{ID: "m1", InstanceID: "v1"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{120, 600}, ttls)
+}
+
+func TestParseLeaseTTL(t *testing.T) {
+	ttl, err := ParseLeaseTTL("45m", "ttl")
+	require.NoError(t, err)
+	assert.Equal(t, 45*time.Minute, ttl)
+
+	for _, v := range []string{"", "30", "500ms", "-1m", "forever"} {
+		_, err := ParseLeaseTTL(v, "lease-ttl")
+		assert.ErrorContains(t, err, "--lease-ttl", v)
+	}
+}
+
+func TestWithLeaseTTLFlag(t *testing.T) {
+	run := func(args ...string) (time.Duration, error) {
+		cmd := &cobra.Command{}
+		flag.Add(cmd, LeaseTTLFlag())
+		require.NoError(t, cmd.ParseFlags(args))
+
+		ctx, err := WithLeaseTTLFlag(flag.NewContext(context.Background(), cmd.Flags()))
+		if err != nil {
+			return 0, err
+		}
+		return LeaseTTLFromContext(ctx), nil
+	}
+
+	ttl, err := run()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultLeaseTTL, ttl)
+
+	ttl, err = run("--lease-ttl", "10m")
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, ttl)
+
+	_, err = run("--lease-ttl", "10")
+	assert.ErrorContains(t, err, "--lease-ttl")
+}
@@ -2,6 +2,9 @@ package machine
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/samber/lo"
 	fly "github.com/superfly/fly-go"
@@ -22,3 +25,28 @@ func ListActive(ctx context.Context) ([]*fly.Machine, error) {
 
 	return machines, nil
 }
+
+// FilterByProcessGroup returns the machines belonging to the named process
+// group. An empty group returns machines unchanged; a group no machine
+// belongs to is an error listing the groups that do exist.
+func FilterByProcessGroup(machines []*fly.Machine, group string) ([]*fly.Machine, error) {
+	if group == "" {
+		return machines, nil
+	}
+
+	filtered := lo.Filter(machines, func(m *fly.Machine, _ int) bool {
+		return m.ProcessGroup() == group
+	})
+	if len(filtered) > 0 {
+		return filtered, nil
+	}
+
+	groups := lo.Uniq(lo.FilterMap(machines, func(m *fly.Machine, _ int) (string, bool) {
+		return m.ProcessGroup(), m.ProcessGroup() != ""
+	}))
+	slices.Sort(groups)
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("process group %q not found, the app has no process groups", group)
+	}
+	return nil, fmt.Errorf("process group %q not found, available groups: %s", group, strings.Join(groups, ", "))
+}
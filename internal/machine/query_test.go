@@ -0,0 +1,33 @@
+package machine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+)
+
+func TestFilterByProcessGroup(t *testing.T) {
+	machineInGroup := func(id, group string) *fly.Machine {
+		return &fly.Machine{ID: id, Config: &fly.MachineConfig{
+			Metadata: map[string]string{fly.MachineConfigMetadataKeyFlyProcessGroup: group},
+		}}
+	}
+	machines := []*fly.Machine{
+		machineInGroup("1", "web"),
+		machineInGroup("2", "worker"),
+		machineInGroup("3", "web"),
+	}
+
+	got, err := FilterByProcessGroup(machines, "")
+	require.NoError(t, err)
+	assert.Len(t, got, 3)
+
+	got, err = FilterByProcessGroup(machines, "web")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "3"}, []string{got[0].ID, got[1].ID})
+
+	_, err = FilterByProcessGroup(machines, "cron")
+	assert.EqualError(t, err, `process group "cron" not found, available groups: web, worker`)
+}
@@ -27,6 +27,8 @@ func WaitForStartOrStop(ctx context.Context, machine *fly.Machine, action string
 		waitOnAction = "started"
 	case "stop":
 		waitOnAction = "stopped"
+	case "suspend":
+		waitOnAction = "suspended"
 	default:
 		return invalidAction
 	}
@@ -65,6 +67,61 @@ func WaitForStartOrStop(ctx context.Context, machine *fly.Machine, action string
 	}
 }
 
+// WaitForExit waits, for at most timeout or with no limit if it's zero, until
+// the main process of the machine exits after its latest start, and returns
+// its exit code. A non-zero exit code isn't an error; running out of time is
+// reported as a WaitTimeoutErr.
+func WaitForExit(ctx context.Context, machine *fly.Machine, timeout time.Duration) (int, error) {
+	flapsClient := flapsutil.ClientFromContext(ctx)
+
+	waitCtx, cancel := ctx, context.CancelFunc(func() {})
+	if timeout > 0 {
+		waitCtx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	defer cancel()
+
+	b := &backoff.Backoff{
+		Min:    500 * time.Millisecond,
+		Max:    2 * time.Second,
+		Factor: 2,
+		Jitter: false,
+	}
+	for {
+		m, err := flapsClient.Get(waitCtx, machine.ID)
+		if err == nil {
+			if exit := m.GetLatestEventOfTypeAfterType("exit", "start"); exit != nil {
+				if exit.Request == nil {
+					return 0, fmt.Errorf("could not determine exit code of machine %s: its exit event has no details", machine.ID)
+				}
+				exitCode, err := exit.Request.GetExitCode()
+				if err != nil {
+					return 0, fmt.Errorf("could not determine exit code of machine %s: %w", machine.ID, err)
+				}
+				return exitCode, nil
+			}
+		} else {
+			var flapsErr *flaps.FlapsError
+			if errors.As(err, &flapsErr) && flapsErr.ResponseStatusCode >= 400 && flapsErr.ResponseStatusCode < 500 {
+				return 0, fmt.Errorf("failed waiting for machine %s to exit: %w", machine.ID, err)
+			}
+		}
+
+		select {
+		case <-waitCtx.Done():
+		case <-time.After(b.Duration()):
+			continue
+		}
+		if errors.Is(waitCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+			return 0, WaitTimeoutErr{
+				machineID:    machine.ID,
+				timeout:      timeout,
+				desiredState: "exited",
+			}
+		}
+		return 0, ctx.Err()
+	}
+}
+
 type WaitTimeoutErr struct {
 	machineID    string
 	timeout      time.Duration
@@ -84,7 +141,7 @@ func (e WaitTimeoutErr) DesiredState() string {
 }
 
 var invalidAction flyerr.GenericErr = flyerr.GenericErr{
-	Err:      "action must be either start or stop",
+	Err:      "action must be one of start, stop or suspend",
 	Descript: "",
 	Suggest:  "This is a bug in wait function, please report this at https://community.fly.io",
 	DocUrl:   "",
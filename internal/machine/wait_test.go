@@ -0,0 +1,66 @@
+package machine
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/mock"
+)
+
+func TestWaitForExit(t *testing.T) {
+	start := &fly.MachineEvent{Type: "start"}
+	exit := func(code int) *fly.MachineEvent {
+		return &fly.MachineEvent{Type: "exit", Request: &fly.MachineRequest{ExitEvent: &fly.MachineExitEvent{ExitCode: code}}}
+	}
+	withGet := func(get func(n int32) (*fly.Machine, error)) context.Context {
+		var polls atomic.Int32
+		return flapsutil.NewContextWithClient(context.Background(), &mock.FlapsClient{
+			GetFunc: func(ctx context.Context, machineID string) (*fly.Machine, error) {
+				assert.Equal(t, "m1", machineID)
+				return get(polls.Add(1))
+			},
+		})
+	}
+
+	t.Run("returns the exit code", func(t *testing.T) {
+		ctx := withGet(func(n int32) (*fly.Machine, error) {
+			switch n {
+			case 1:
+				return &fly.Machine{ID: "m1", State: fly.MachineStateStarted, Events: []*fly.MachineEvent{start, exit(0)}}, nil
+			case 2:
+				return nil, &flaps.FlapsError{ResponseStatusCode: http.StatusServiceUnavailable}
+			default:
+				return &fly.Machine{ID: "m1", State: fly.MachineStateStopped, Events: []*fly.MachineEvent{exit(3), start, exit(0)}}, nil
+			}
+		})
+		code, err := WaitForExit(ctx, &fly.Machine{ID: "m1"}, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, 3, code)
+	})
+
+	t.Run("reports a timeout as such", func(t *testing.T) {
+		ctx := withGet(func(n int32) (*fly.Machine, error) {
+			return &fly.Machine{ID: "m1", State: fly.MachineStateStarted, Events: []*fly.MachineEvent{start}}, nil
+		})
+		_, err := WaitForExit(ctx, &fly.Machine{ID: "m1"}, 100*time.Millisecond)
+		var timeoutErr WaitTimeoutErr
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, "exited", timeoutErr.DesiredState())
+	})
+
+	t.Run("fails on client errors", func(t *testing.T) {
+		ctx := withGet(func(n int32) (*fly.Machine, error) {
+			return nil, &flaps.FlapsError{ResponseStatusCode: http.StatusNotFound}
+		})
+		_, err := WaitForExit(ctx, &fly.Machine{ID: "m1"}, time.Minute)
+		assert.ErrorContains(t, err, "failed waiting for machine m1 to exit")
+	})
+}
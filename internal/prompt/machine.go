@@ -0,0 +1,52 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	fly "github.com/superfly/fly-go"
+)
+
+// errMachineIDRequired is returned when a machine can't be prompted for. It
+// lists the candidates' IDs so the user doesn't need to look them up.
+func errMachineIDRequired(machines []*fly.Machine) error {
+	ids := make([]string, 0, len(machines))
+	for _, m := range machines {
+		ids = append(ids, m.ID)
+	}
+	return NonInteractiveError(fmt.Sprintf("a machine ID must be specified when not running interactively; pass one of: %s",
+		strings.Join(ids, ", ")))
+}
+
+// SelectMachine prompts for one of machines, described by the matching
+// entries of options.
+func SelectMachine(ctx context.Context, msg string, machines []*fly.Machine, options []string) (*fly.Machine, error) {
+	var index int
+	switch err := Select(ctx, &index, msg, "", options...); {
+	case err == nil:
+		return machines[index], nil
+	case IsNonInteractive(err):
+		return nil, errMachineIDRequired(machines)
+	default:
+		return nil, err
+	}
+}
+
+// MultiSelectMachines prompts for any number of machines, described by the
+// matching entries of options.
+func MultiSelectMachines(ctx context.Context, msg string, machines []*fly.Machine, options []string) ([]*fly.Machine, error) {
+	var indices []int
+	switch err := MultiSelect(ctx, &indices, msg, nil, options...); {
+	case err == nil:
+		selected := make([]*fly.Machine, 0, len(indices))
+		for _, i := range indices {
+			selected = append(selected, machines[i])
+		}
+		return selected, nil
+	case IsNonInteractive(err):
+		return nil, errMachineIDRequired(machines)
+	default:
+		return nil, err
+	}
+}
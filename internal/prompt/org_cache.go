@@ -0,0 +1,109 @@
+package prompt
+
+import (
+	"context"
+	"sync"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/internal/sort"
+)
+
+// orgCache holds the organizations resolved during a single invocation, by
+// slug, so that commands calling into each other don't look them up twice. It
+// also holds the user's organizations once listed, and the one picked when no
+// slug was given, so the user is only asked once.
+type orgCache struct {
+	mu       sync.Mutex
+	orgs     map[string]*fly.Organization
+	list     []fly.Organization
+	selected *fly.Organization
+}
+
+type orgCacheContextKey struct{}
+
+// NewContextWithOrgCache derives a context that remembers the organizations
+// resolved through it.
+func NewContextWithOrgCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, orgCacheContextKey{}, &orgCache{orgs: map[string]*fly.Organization{}})
+}
+
+// CachedOrg returns the organization with the given slug resolved earlier in
+// this invocation, if any. Without a slug there's no telling which of the
+// user's organizations is meant, so it returns nil.
+func CachedOrg(ctx context.Context, slug string) *fly.Organization {
+	c, ok := ctx.Value(orgCacheContextKey{}).(*orgCache)
+	if !ok || slug == "" {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.orgs[slug]
+}
+
+// CacheOrg records org as resolved in this invocation, under its slug.
+func CacheOrg(ctx context.Context, org *fly.Organization) {
+	c, ok := ctx.Value(orgCacheContextKey{}).(*orgCache)
+	if !ok || org == nil || org.Slug == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.orgs[org.Slug] = org
+}
+
+// organizations returns the user's organizations sorted by type and name,
+// listing them only once per invocation.
+func organizations(ctx context.Context) ([]fly.Organization, error) {
+	c, ok := ctx.Value(orgCacheContextKey{}).(*orgCache)
+	if ok {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if c.list != nil {
+			return c.list, nil
+		}
+	}
+
+	orgs, err := flyutil.ClientFromContext(ctx).GetOrganizations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.OrganizationsByTypeAndName(orgs)
+
+	if ok {
+		c.list = orgs
+	}
+	return orgs, nil
+}
+
+// selectedOrg returns the organization picked earlier in this invocation when
+// no slug was given, if any.
+func selectedOrg(ctx context.Context) *fly.Organization {
+	c, ok := ctx.Value(orgCacheContextKey{}).(*orgCache)
+	if !ok {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.selected
+}
+
+// rememberSelectedOrg records org as the one picked when no slug was given.
+func rememberSelectedOrg(ctx context.Context, org *fly.Organization) {
+	c, ok := ctx.Value(orgCacheContextKey{}).(*orgCache)
+	if !ok {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.selected = org
+}
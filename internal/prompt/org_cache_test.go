@@ -0,0 +1,105 @@
+package prompt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/internal/mock"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestOrgCache(t *testing.T) {
+	ctx := context.Background()
+
+	// Without a cache in the context nothing is remembered.
+	CacheOrg(ctx, &fly.Organization{Slug: "acme"})
+	assert.Nil(t, CachedOrg(ctx, ""))
+
+	ctx = NewContextWithOrgCache(ctx)
+	assert.Nil(t, CachedOrg(ctx, ""))
+
+	acme := &fly.Organization{Slug: "acme"}
+	other := &fly.Organization{Slug: "other"}
+	CacheOrg(ctx, acme)
+	CacheOrg(ctx, other)
+
+	assert.Nil(t, CachedOrg(ctx, ""), "without a slug any org could be meant")
+	assert.Same(t, acme, CachedOrg(ctx, "acme"))
+	assert.Same(t, other, CachedOrg(ctx, "other"))
+	assert.Nil(t, CachedOrg(ctx, "personal"))
+}
+
+func TestOrgResolvedOnce(t *testing.T) {
+	var calls int
+	client := &mock.Client{
+		GetOrganizationsFunc: func(ctx context.Context, filters ...fly.OrganizationFilter) ([]fly.Organization, error) {
+			calls++
+			return []fly.Organization{
+				{Slug: "acme", Name: "Acme", Type: "SHARED"},
+				{Slug: "personal", Name: "Personal", Type: "PERSONAL"},
+			}, nil
+		},
+	}
+
+	newCtx := func(slug string) context.Context {
+		ios, _, _, _ := iostreams.Test()
+		ctx := iostreams.NewContext(context.Background(), ios)
+		ctx = config.NewContext(ctx, &config.Config{Organization: slug})
+		ctx = flyutil.NewContextWithClient(ctx, client)
+		return NewContextWithOrgCache(ctx)
+	}
+
+	ctx := newCtx("acme")
+	org, err := Org(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", org.Slug)
+
+	org, err = Org(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", org.Slug)
+	assert.Equal(t, 1, calls)
+
+	// Ambiguous and non-interactive: list the orgs instead of prompting.
+	_, err = Org(newCtx(""))
+	assert.True(t, IsNonInteractive(err))
+	assert.ErrorContains(t, err, "one of: personal, acme")
+}
+
+func TestOrgWithoutSlugResolvedOnce(t *testing.T) {
+	var calls int
+	client := &mock.Client{
+		GetOrganizationsFunc: func(ctx context.Context, filters ...fly.OrganizationFilter) ([]fly.Organization, error) {
+			calls++
+			return []fly.Organization{{Slug: "personal", Name: "Jane Doe", Type: "PERSONAL"}}, nil
+		},
+	}
+
+	ios, _, _, errOut := iostreams.Test()
+	ctx := iostreams.NewContext(context.Background(), ios)
+	ctx = config.NewContext(ctx, &config.Config{})
+	ctx = flyutil.NewContextWithClient(ctx, client)
+	ctx = NewContextWithOrgCache(ctx)
+
+	// Without a slug the org is picked for the user, here automatically
+	// since it's their only one. Later calls reuse the pick.
+	org, err := Org(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "personal", org.Slug)
+
+	again, err := Org(ctx)
+	require.NoError(t, err)
+	assert.Same(t, org, again)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, "automatically selected personal organization: Jane Doe\n", errOut.String())
+
+	// Looking an org up by name reuses the list too.
+	org, err = FindOrg(ctx, "Jane Doe")
+	require.NoError(t, err)
+	assert.Equal(t, "personal", org.Slug)
+	assert.Equal(t, 1, calls)
+}
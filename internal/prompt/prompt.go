@@ -182,10 +182,15 @@ func ConfirmYes(ctx context.Context, message string) (confirm bool, err error) {
 }
 
 func ConfirmOverwrite(ctx context.Context, filename string) (confirm bool, err error) {
+	var opt survey.AskOpt
+	if opt, err = newSurveyIO(ctx); err != nil {
+		return
+	}
+
 	prompt := &survey.Confirm{
 		Message: fmt.Sprintf(`Overwrite "%s"?`, filename),
 	}
-	err = survey.AskOne(prompt, &confirm)
+	err = survey.AskOne(prompt, &confirm, opt)
 
 	return
 }
@@ -202,6 +207,17 @@ func (e NonInteractiveError) Error() string { return string(e) }
 
 func (NonInteractiveError) Unwrap() error { return ErrNonInteractive }
 
+// Require turns err, when it reports a prompt that couldn't be shown because
+// the session isn't interactive, into an error asking for what to be specified
+// instead, e.g. "--org flag" or "name argument". Other errors are returned
+// as they are.
+func Require(err error, what string) error {
+	if IsNonInteractive(err) {
+		return NonInteractiveError(what + " must be specified when not running interactively")
+	}
+	return err
+}
+
 func isInteractive(ctx context.Context) bool {
 	io := iostreams.FromContext(ctx)
 	return io.IsInteractive()
@@ -209,7 +225,7 @@ func isInteractive(ctx context.Context) bool {
 
 func newSurveyIO(ctx context.Context) (survey.AskOpt, error) {
 	io := iostreams.FromContext(ctx)
-	if !io.IsInteractive() {
+	if !io.CanPrompt() {
 		return nil, ErrNonInteractive
 	}
 
@@ -235,21 +251,49 @@ func newSurveyIO(ctx context.Context) (survey.AskOpt, error) {
 	return survey.WithStdio(in, out, io.ErrOut), nil
 }
 
-var errOrgSlugRequired = NonInteractiveError("org slug must be specified when not running interactively")
+// errOrgSlugRequired is returned when the organization can't be prompted for.
+// It lists the available slugs so the user doesn't need to look them up.
+func errOrgSlugRequired(orgs []fly.Organization) error {
+	slugs := make([]string, 0, len(orgs))
+	for _, org := range orgs {
+		slugs = append(slugs, org.Slug)
+	}
+	return NonInteractiveError(fmt.Sprintf("org slug must be specified when not running interactively; pass --org or set FLY_ORG to one of: %s",
+		strings.Join(slugs, ", ")))
+}
 
 // Org returns the Organization the user has passed in via flag or prompts the
-// user for one.
+// user for one. The organization is looked up, or prompted for, once per
+// invocation.
 func Org(ctx context.Context) (*fly.Organization, error) {
-	client := flyutil.ClientFromContext(ctx)
+	slug := config.FromContext(ctx).Organization
+	if slug == "" {
+		if org := selectedOrg(ctx); org != nil {
+			return org, nil
+		}
+	} else if org := CachedOrg(ctx, slug); org != nil {
+		return org, nil
+	}
+
+	org, err := resolveOrg(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
 
-	orgs, err := client.GetOrganizations(ctx)
+	CacheOrg(ctx, org)
+	if slug == "" {
+		rememberSelectedOrg(ctx, org)
+	}
+	return org, nil
+}
+
+func resolveOrg(ctx context.Context, slug string) (*fly.Organization, error) {
+	orgs, err := organizations(ctx)
 	if err != nil {
 		return nil, err
 	}
-	sort.OrganizationsByTypeAndName(orgs)
 
 	io := iostreams.FromContext(ctx)
-	slug := config.FromContext(ctx).Organization
 
 	switch {
 	case slug == "" && len(orgs) == 1 && orgs[0].Type == "PERSONAL":
@@ -258,25 +302,71 @@ func Org(ctx context.Context) (*fly.Organization, error) {
 
 		return &orgs[0], nil
 	case slug != "":
-		for _, org := range orgs {
-			if slug == org.Slug {
-				return &org, nil
-			}
+		return matchOrg(ctx, orgs, slug)
+	default:
+		switch org, err := SelectOrg(ctx, orgs); {
+		case err == nil:
+			return org, nil
+		case IsNonInteractive(err):
+			return nil, errOrgSlugRequired(orgs)
+		default:
+			return nil, err
 		}
+	}
+}
 
-		return nil, fmt.Errorf("organization %s not found", slug)
+// FindOrg returns the organization whose slug or name matches query,
+// prompting to disambiguate when several organizations share a name.
+func FindOrg(ctx context.Context, query string) (*fly.Organization, error) {
+	orgs, err := organizations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return matchOrg(ctx, orgs, query)
+}
+
+func matchOrg(ctx context.Context, orgs []fly.Organization, query string) (*fly.Organization, error) {
+	switch matches := MatchOrgs(orgs, query); len(matches) {
+	case 0:
+		return nil, fmt.Errorf("organization %s not found", query)
+	case 1:
+		return &matches[0], nil
 	default:
-		switch org, err := SelectOrg(ctx, orgs); {
+		switch org, err := SelectOrg(ctx, matches); {
 		case err == nil:
 			return org, nil
 		case IsNonInteractive(err):
-			return nil, errOrgSlugRequired
+			slugs := make([]string, 0, len(matches))
+			for _, org := range matches {
+				slugs = append(slugs, org.Slug)
+			}
+			return nil, NonInteractiveError(fmt.Sprintf("%q matches more than one organization; specify one of: %s",
+				query, strings.Join(slugs, ", ")))
 		default:
 			return nil, err
 		}
 	}
 }
 
+// MatchOrgs returns the organizations whose slug or display name match query,
+// ignoring case. An exact slug match takes precedence over name matches.
+func MatchOrgs(orgs []fly.Organization, query string) []fly.Organization {
+	for _, org := range orgs {
+		if org.Slug == query {
+			return []fly.Organization{org}
+		}
+	}
+
+	var matches []fly.Organization
+	for _, org := range orgs {
+		if strings.EqualFold(org.Slug, query) || strings.EqualFold(org.Name, query) {
+			matches = append(matches, org)
+		}
+	}
+	return matches
+}
+
 func SelectOrg(ctx context.Context, orgs []fly.Organization) (org *fly.Organization, err error) {
 	var options []string
 	for _, org := range orgs {
@@ -439,7 +529,7 @@ func Region(ctx context.Context, splitPaid bool, params RegionParams) (*fly.Regi
 			}
 		}
 
-		return nil, fmt.Errorf("region %s not found", slug)
+		return nil, validateRegionCode(slug, regions)
 	default:
 		var defaultRegionCode string
 		if defaultRegion != nil {
@@ -554,8 +644,6 @@ func SelectAppName(ctx context.Context) (name string, err error) {
 }
 
 func SelectAppNameWithMsg(ctx context.Context, msg string) (name string, err error) {
-	if err = String(ctx, &name, msg, "", false); IsNonInteractive(err) {
-		err = NonInteractiveError("name argument or flag must be specified when not running interactively")
-	}
+	err = Require(String(ctx, &name, msg, "", false), "name argument or flag")
 	return
 }
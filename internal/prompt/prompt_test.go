@@ -1,12 +1,16 @@
 package prompt
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"testing/quick"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/iostreams"
 )
 
 func TestIsNonInteractive(t *testing.T) {
@@ -32,3 +36,103 @@ func TestNonInteractiveError(t *testing.T) {
 	}
 	require.NoError(t, quick.Check(fn, nil))
 }
+
+func TestMatchOrgs(t *testing.T) {
+	orgs := []fly.Organization{
+		{Slug: "personal", Name: "Jane Doe"},
+		{Slug: "acme", Name: "Acme Corp"},
+		{Slug: "acme-corp", Name: "acme"},
+		{Slug: "staging", Name: "Acme Corp"},
+	}
+
+	slugs := func(orgs []fly.Organization) (s []string) {
+		for _, org := range orgs {
+			s = append(s, org.Slug)
+		}
+		return
+	}
+
+	// An exact slug wins even when a name also matches.
+	assert.Equal(t, []string{"acme"}, slugs(MatchOrgs(orgs, "acme")))
+	assert.Equal(t, []string{"personal"}, slugs(MatchOrgs(orgs, "jane doe")))
+	assert.Equal(t, []string{"acme", "staging"}, slugs(MatchOrgs(orgs, "acme corp")))
+	assert.Empty(t, MatchOrgs(orgs, "nope"))
+
+	ios, _, _, _ := iostreams.Test()
+	ctx := iostreams.NewContext(context.Background(), ios)
+
+	org, err := matchOrg(ctx, orgs, "Jane Doe")
+	require.NoError(t, err)
+	assert.Equal(t, "personal", org.Slug)
+
+	_, err = matchOrg(ctx, orgs, "Acme Corp")
+	assert.True(t, IsNonInteractive(err))
+	assert.ErrorContains(t, err, "specify one of: acme, staging")
+
+	_, err = matchOrg(ctx, orgs, "nope")
+	assert.EqualError(t, err, "organization nope not found")
+}
+
+func TestSelectMachineNonInteractive(t *testing.T) {
+	ios, _, _, _ := iostreams.Test()
+	ctx := iostreams.NewContext(context.Background(), ios)
+
+	machines := []*fly.Machine{{ID: "148ed123"}, {ID: "9080e456"}}
+	options := []string{"148ed123 web", "9080e456 worker"}
+
+	_, err := SelectMachine(ctx, "Select a machine:", machines, options)
+	assert.True(t, IsNonInteractive(err))
+	assert.EqualError(t, err, "a machine ID must be specified when not running interactively; pass one of: 148ed123, 9080e456")
+
+	_, err = MultiSelectMachines(ctx, "Select machines:", machines, options)
+	assert.True(t, IsNonInteractive(err))
+	assert.ErrorContains(t, err, "pass one of: 148ed123, 9080e456")
+}
+
+func TestRequire(t *testing.T) {
+	err := Require(ErrNonInteractive, "--org flag")
+	assert.True(t, IsNonInteractive(err))
+	assert.EqualError(t, err, "--org flag must be specified when not running interactively")
+
+	assert.Equal(t, assert.AnError, Require(assert.AnError, "--org flag"))
+	assert.NoError(t, Require(nil, "--org flag"))
+}
+
+func TestPromptsWithClosedStdin(t *testing.T) {
+	ios, _, _, err := iostreams.TestClosedStdin()
+	require.NoError(t, err)
+	defer ios.In.Close()
+	ctx := iostreams.NewContext(context.Background(), ios)
+
+	prompts := map[string]func() error{
+		"String": func() error {
+			var name string
+			return String(ctx, &name, "App name:", "", true)
+		},
+		"Select": func() error {
+			var index int
+			return Select(ctx, &index, "Builder:", "", "Dockerfile", "Buildpacks")
+		},
+		"Confirm": func() error {
+			_, err := Confirm(ctx, "Deploy now?")
+			return err
+		},
+		"ConfirmOverwrite": func() error {
+			confirm, err := ConfirmOverwrite(ctx, "Dockerfile")
+			assert.False(t, confirm)
+			return err
+		},
+	}
+
+	for name, ask := range prompts {
+		done := make(chan error, 1)
+		go func() { done <- ask() }()
+
+		select {
+		case err := <-done:
+			assert.True(t, IsNonInteractive(err), name)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s waited for input on a closed stdin", name)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+
+	fly "github.com/superfly/fly-go"
+)
+
+// ValidateRegion returns an error when code isn't one of the platform's
+// regions. The error suggests the closest valid code, if there is one.
+// An empty code is always valid.
+func ValidateRegion(ctx context.Context, code string) error {
+	if code == "" {
+		return nil
+	}
+
+	regionInfo, err := PlatformRegions(ctx).Get()
+	if err != nil {
+		return fmt.Errorf("failed retrieving regions: %w", err)
+	}
+
+	return validateRegionCode(code, regionInfo.Regions)
+}
+
+func validateRegionCode(code string, regions []fly.Region) error {
+	for _, region := range regions {
+		if region.Code == code {
+			return nil
+		}
+	}
+
+	if suggestion := closestRegionCode(code, regions); suggestion != "" {
+		return fmt.Errorf("region %s not found, did you mean %s? Run `fly platform regions` to see valid codes", code, suggestion)
+	}
+	return fmt.Errorf("region %s not found. Run `fly platform regions` to see valid codes", code)
+}
+
+// closestRegionCode returns the region code with the smallest edit distance
+// to code, as long as it is close enough to plausibly be a typo.
+func closestRegionCode(code string, regions []fly.Region) string {
+	const maxDistance = 2
+
+	var (
+		best     string
+		bestDist = maxDistance + 1
+	)
+	for _, region := range regions {
+		if d := editDistance(code, region.Code); d < bestDist {
+			best, bestDist = region.Code, d
+		}
+	}
+	return best
+}
+
+// editDistance computes the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
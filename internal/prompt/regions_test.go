@@ -0,0 +1,18 @@
+package prompt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	fly "github.com/superfly/fly-go"
+)
+
+func TestValidateRegionCode(t *testing.T) {
+	regions := []fly.Region{{Code: "iad"}, {Code: "ord"}, {Code: "syd"}}
+
+	assert.NoError(t, validateRegionCode("ord", regions))
+	assert.EqualError(t, validateRegionCode("iat", regions),
+		"region iat not found, did you mean iad? Run `fly platform regions` to see valid codes")
+	assert.EqualError(t, validateRegionCode("zzzzzz", regions),
+		"region zzzzzz not found. Run `fly platform regions` to see valid codes")
+}
@@ -0,0 +1,323 @@
+package render
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/flagnames"
+)
+
+// ListOptions controls how List renders rows. The zero value renders a
+// plain table.
+type ListOptions struct {
+	// Format, when set, is evaluated once per row instead of rendering a
+	// table.
+	Format *template.Template
+
+	// CSV renders the columns as a header row followed by one row per
+	// record.
+	CSV bool
+
+	// Columns, when set, selects and orders the columns to render. Names
+	// are matched case-insensitively, ignoring spaces.
+	Columns []string
+
+	// Sort orders rows by the given columns before anything else is
+	// applied, so rows may be sorted by columns that aren't shown.
+	Sort []SortKey
+}
+
+// SortKey is a column to sort list rows by.
+type SortKey struct {
+	Column     string
+	Descending bool
+}
+
+// Tabular reports whether List renders a table for opts. Commands use it to
+// skip the decoration they print around tables, which would otherwise end up
+// in machine-readable output.
+func (opts ListOptions) Tabular() bool {
+	return opts.Format == nil && !opts.CSV
+}
+
+// ListOptionsFromContext builds ListOptions from the list output flags ctx
+// carries, checking the column names they refer to against cols, the columns
+// of the list. Flags the command doesn't define are ignored. Commands should
+// call it before fetching any data so invalid flags fail fast.
+func ListOptionsFromContext(ctx context.Context, cols ...string) (opts ListOptions, err error) {
+	if flag.IsSpecified(ctx, flagnames.Columns) {
+		opts.Columns = flag.GetNonEmptyStringSlice(ctx, flagnames.Columns)
+		for _, name := range opts.Columns {
+			if _, err = columnIndex(cols, name); err != nil {
+				return
+			}
+		}
+	}
+
+	if flag.IsSpecified(ctx, flagnames.Sort) {
+		if opts.Sort, err = parseSortKeys(flag.GetNonEmptyStringSlice(ctx, flagnames.Sort)); err != nil {
+			return
+		}
+		for _, key := range opts.Sort {
+			if _, err = columnIndex(cols, key.Column); err != nil {
+				return
+			}
+		}
+	}
+
+	opts.CSV = flag.IsSpecified(ctx, flagnames.CSV) && flag.GetBool(ctx, flagnames.CSV)
+	if opts.CSV && config.FromContext(ctx).JSONOutput {
+		return opts, fmt.Errorf("--%s and --%s are mutually exclusive", flagnames.CSV, flagnames.JSONOutput)
+	}
+
+	if !flag.IsSpecified(ctx, flagnames.Format) {
+		return
+	}
+	if opts.CSV {
+		return opts, fmt.Errorf("--%s and --%s are mutually exclusive", flagnames.CSV, flagnames.Format)
+	}
+	if config.FromContext(ctx).JSONOutput {
+		return opts, fmt.Errorf("--%s and --%s are mutually exclusive", flagnames.Format, flagnames.JSONOutput)
+	}
+
+	if opts.Format, err = template.New("format").Option("missingkey=error").Parse(flag.GetString(ctx, flagnames.Format)); err != nil {
+		return opts, fmt.Errorf("invalid --%s template: %w", flagnames.Format, err)
+	}
+	return
+}
+
+// List renders rows into w as described by opts, falling back to a table
+// with the given title and columns.
+func List(w io.Writer, opts ListOptions, title string, rows [][]string, cols ...string) error {
+	if len(opts.Sort) > 0 {
+		var err error
+		if rows, err = sortRows(rows, cols, opts.Sort); err != nil {
+			return err
+		}
+	}
+
+	if len(opts.Columns) > 0 {
+		var err error
+		if rows, cols, err = selectColumns(rows, cols, opts.Columns); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case opts.Format != nil:
+		return formattedRows(w, opts.Format, rows, cols)
+	case opts.CSV:
+		return csvRows(w, rows, cols)
+	}
+	return Table(w, title, rows, cols...)
+}
+
+// formattedRows executes tmpl for every row, exposing each value under its
+// column name both as is (for index) and with spaces removed, so that
+// "Latest Deploy" is available as {{.LatestDeploy}}. Nothing is written
+// unless every row renders.
+func formattedRows(w io.Writer, tmpl *template.Template, rows [][]string, cols []string) error {
+	var buf bytes.Buffer
+	for _, row := range rows {
+		data := make(map[string]string, 2*len(cols))
+		for i, col := range cols {
+			if i >= len(row) {
+				break
+			}
+			data[col] = row[i]
+			data[strings.ReplaceAll(col, " ", "")] = row[i]
+		}
+
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return fmt.Errorf("failed rendering --%s template: %w", flagnames.Format, err)
+		}
+		buf.WriteByte('\n')
+	}
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// csvRows writes cols as a header followed by rows, quoting values where CSV
+// requires it.
+func csvRows(w io.Writer, rows [][]string, cols []string) error {
+	cw := csv.NewWriter(w)
+	if len(cols) > 0 {
+		if err := cw.Write(cols); err != nil {
+			return err
+		}
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed writing CSV: %w", err)
+	}
+	return nil
+}
+
+// columnKey normalizes a column name for matching against --columns.
+func columnKey(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, " ", ""))
+}
+
+// columnIndex returns the index of the column name refers to.
+func columnIndex(cols []string, name string) (int, error) {
+	key := columnKey(name)
+	for i, col := range cols {
+		if columnKey(col) == key {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("unknown column %q, valid columns are: %s", name, strings.Join(cols, ", "))
+}
+
+// selectColumns narrows rows and cols down to selected, in its order.
+func selectColumns(rows [][]string, cols, selected []string) ([][]string, []string, error) {
+	indexes := make([]int, 0, len(selected))
+	for _, name := range selected {
+		i, err := columnIndex(cols, name)
+		if err != nil {
+			return nil, nil, err
+		}
+		indexes = append(indexes, i)
+	}
+
+	newCols := make([]string, len(indexes))
+	for j, i := range indexes {
+		newCols[j] = cols[i]
+	}
+
+	newRows := make([][]string, len(rows))
+	for r, row := range rows {
+		newRow := make([]string, len(indexes))
+		for j, i := range indexes {
+			if i < len(row) {
+				newRow[j] = row[i]
+			}
+		}
+		newRows[r] = newRow
+	}
+	return newRows, newCols, nil
+}
+
+// parseSortKeys parses --sort values of the form column[:asc|:desc].
+func parseSortKeys(values []string) ([]SortKey, error) {
+	keys := make([]SortKey, 0, len(values))
+	for _, v := range values {
+		column, order, _ := strings.Cut(v, ":")
+		key := SortKey{Column: strings.TrimSpace(column)}
+
+		switch strings.ToLower(strings.TrimSpace(order)) {
+		case "", "asc":
+		case "desc":
+			key.Descending = true
+		default:
+			return nil, fmt.Errorf("invalid --%s order %q for column %q, expected asc or desc", flagnames.Sort, order, key.Column)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// sortRows returns a copy of rows stably sorted by keys. Values that both
+// look like numbers compare numerically, anything else compares as text.
+func sortRows(rows [][]string, cols []string, keys []SortKey) ([][]string, error) {
+	indexes := make([]int, len(keys))
+	for k, key := range keys {
+		i, err := columnIndex(cols, key.Column)
+		if err != nil {
+			return nil, err
+		}
+		indexes[k] = i
+	}
+
+	sorted := slices.Clone(rows)
+	slices.SortStableFunc(sorted, func(a, b []string) int {
+		for k, key := range keys {
+			c := compareValues(cell(a, indexes[k]), cell(b, indexes[k]))
+			if key.Descending {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return sorted, nil
+}
+
+func cell(row []string, i int) string {
+	if i < len(row) {
+		return row[i]
+	}
+	return ""
+}
+
+func compareValues(a, b string) int {
+	if c, ok := compareGuestSizes(a, b); ok {
+		return c
+	}
+	if x, ok := numericValue(a); ok {
+		if y, ok := numericValue(b); ok {
+			return cmp.Compare(x, y)
+		}
+	}
+	return cmp.Compare(a, b)
+}
+
+var sizeUnits = map[string]float64{
+	"":   1,
+	"kb": 1 << 10,
+	"mb": 1 << 20,
+	"gb": 1 << 30,
+	"tb": 1 << 40,
+}
+
+// numericValue parses values that look like numbers: plain numbers,
+// versions such as "v12" and sizes such as "256MB" or "10GB".
+func numericValue(s string) (float64, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+
+	end := strings.LastIndexAny(s, "0123456789") + 1
+	if end == 0 {
+		return 0, false
+	}
+	unit, ok := sizeUnits[strings.ToLower(strings.TrimSpace(s[end:]))]
+	if !ok {
+		return 0, false
+	}
+
+	f, err := strconv.ParseFloat(s[:end], 64)
+	return f * unit, err == nil
+}
+
+// guestSizePattern matches machine sizes as lists show them, the preset with
+// its memory, such as "shared-cpu-2x:512MB".
+var guestSizePattern = regexp.MustCompile(`^[a-z0-9-]*?(\d+)x:(\d+\s*[a-zA-Z]*)$`)
+
+// compareGuestSizes compares two machine sizes by memory, then by CPU count.
+// It reports false unless both values are machine sizes.
+func compareGuestSizes(a, b string) (int, bool) {
+	ma, mb := guestSizePattern.FindStringSubmatch(a), guestSizePattern.FindStringSubmatch(b)
+	if ma == nil || mb == nil {
+		return 0, false
+	}
+	memA, okA := numericValue(ma[2])
+	memB, okB := numericValue(mb[2])
+	cpusA, errA := strconv.Atoi(ma[1])
+	cpusB, errB := strconv.Atoi(mb[1])
+	if !okA || !okB || errA != nil || errB != nil {
+		return 0, false
+	}
+	return cmp.Or(cmp.Compare(memA, memB), cmp.Compare(cpusA, cpusB), cmp.Compare(a, b)), true
+}
@@ -0,0 +1,158 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+)
+
+func listContext(t *testing.T, args ...string) context.Context {
+	t.Helper()
+
+	flags := pflag.NewFlagSet("list", pflag.ContinueOnError)
+	flags.String("format", "", "")
+	flags.Bool("csv", false, "")
+	flags.StringSlice("columns", nil, "")
+	flags.StringSlice("sort", nil, "")
+	require.NoError(t, flags.Parse(args))
+
+	ctx := flag.NewContext(context.Background(), flags)
+	return config.NewContext(ctx, &config.Config{})
+}
+
+var (
+	listRows = [][]string{
+		{"app-a", "personal", "deployed", "1h ago"},
+		{"app-b", "acme", "suspended", ""},
+	}
+	listCols = []string{"Name", "Owner", "Status", "Latest Deploy"}
+)
+
+func TestListFormat(t *testing.T) {
+	opts, err := ListOptionsFromContext(listContext(t, "--format", `{{.Name}} {{.Owner}} {{.LatestDeploy}}|{{index . "Latest Deploy"}}`))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, List(&buf, opts, "", listRows, listCols...))
+	assert.Equal(t, "app-a personal 1h ago|1h ago\napp-b acme |\n", buf.String())
+}
+
+func TestListFormatErrors(t *testing.T) {
+	_, err := ListOptionsFromContext(listContext(t, "--format", "{{.Name"))
+	assert.ErrorContains(t, err, "invalid --format template")
+
+	opts, err := ListOptionsFromContext(listContext(t, "--format", "{{.Nope}}"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.ErrorContains(t, List(&buf, opts, "", listRows, listCols...), "failed rendering --format template")
+	assert.Empty(t, buf.String())
+}
+
+func TestListDefaultsToTable(t *testing.T) {
+	opts, err := ListOptionsFromContext(listContext(t))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, List(&buf, opts, "", listRows, listCols...))
+	assert.Contains(t, buf.String(), "LATEST DEPLOY")
+	assert.Contains(t, buf.String(), "app-b")
+}
+
+func TestListCSV(t *testing.T) {
+	opts, err := ListOptionsFromContext(listContext(t, "--csv"))
+	require.NoError(t, err)
+	assert.False(t, opts.Tabular())
+
+	rows := append(listRows, []string{"app-c", "acme, inc", `say "hi"`, ""})
+
+	var buf bytes.Buffer
+	require.NoError(t, List(&buf, opts, "ignored title", rows, listCols...))
+	assert.Equal(t, `Name,Owner,Status,Latest Deploy
+app-a,personal,deployed,1h ago
+app-b,acme,suspended,
+app-c,"acme, inc","say ""hi""",
+`, buf.String())
+}
+
+func TestListCSVExclusive(t *testing.T) {
+	ctx := listContext(t, "--csv")
+	config.FromContext(ctx).JSONOutput = true
+	_, err := ListOptionsFromContext(ctx)
+	assert.ErrorContains(t, err, "--csv and --json are mutually exclusive")
+
+	_, err = ListOptionsFromContext(listContext(t, "--csv", "--format", "{{.Name}}"))
+	assert.ErrorContains(t, err, "--csv and --format are mutually exclusive")
+
+	ctx = listContext(t, "--format", "{{.Name}}")
+	config.FromContext(ctx).JSONOutput = true
+	_, err = ListOptionsFromContext(ctx)
+	assert.ErrorContains(t, err, "--format and --json are mutually exclusive")
+}
+
+func TestListColumns(t *testing.T) {
+	opts, err := ListOptionsFromContext(listContext(t, "--csv", "--columns", "latestdeploy, Name"), listCols...)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, List(&buf, opts, "", listRows, listCols...))
+	assert.Equal(t, "Latest Deploy,Name\n1h ago,app-a\n,app-b\n", buf.String())
+
+	// Unknown columns are reported before the command fetches anything.
+	_, err = ListOptionsFromContext(listContext(t, "--columns", "Name,Region"), listCols...)
+	assert.EqualError(t, err, `unknown column "Region", valid columns are: Name, Owner, Status, Latest Deploy`)
+}
+
+func TestListSort(t *testing.T) {
+	rows := [][]string{
+		{"b", "v10", "1GB"},
+		{"a", "v9", "512MB"},
+		{"c", "v10", "10GB"},
+	}
+	cols := []string{"Name", "Version", "Size"}
+
+	render := func(args ...string) string {
+		opts, err := ListOptionsFromContext(listContext(t, append([]string{"--csv", "--columns", "Name"}, args...)...), cols...)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, List(&buf, opts, "", rows, cols...))
+		return buf.String()
+	}
+
+	assert.Equal(t, "Name\na\nb\nc\n", render("--sort", "version"))
+	assert.Equal(t, "Name\nc\nb\na\n", render("--sort", "version:desc,name:desc"))
+	assert.Equal(t, "Name\na\nb\nc\n", render("--sort", "Size"))
+	assert.Equal(t, "Name\nc\nb\na\n", render("--sort", "Name:DESC"))
+
+	_, err := ListOptionsFromContext(listContext(t, "--sort", "name:sideways"), cols...)
+	assert.ErrorContains(t, err, `invalid --sort order "sideways"`)
+
+	_, err = ListOptionsFromContext(listContext(t, "--sort", "region"), cols...)
+	assert.ErrorContains(t, err, `unknown column "region"`)
+}
+
+func TestListSortMachineSizes(t *testing.T) {
+	rows := [][]string{
+		{"a", "shared-cpu-1x:1024MB"},
+		{"b", "performance-16x:32768MB"},
+		{"c", "shared-cpu-1x:256MB"},
+		{"d", "shared-cpu-2x:256MB"},
+		{"e", "performance-2x:4096MB"},
+	}
+	cols := []string{"Name", "Size"}
+
+	opts, err := ListOptionsFromContext(listContext(t, "--csv", "--columns", "Name", "--sort", "size"), cols...)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, List(&buf, opts, "", rows, cols...))
+	// By memory, then by CPU count.
+	assert.Equal(t, "Name\nc\nd\na\ne\nb\n", buf.String())
+}
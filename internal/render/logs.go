@@ -2,8 +2,10 @@ package render
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/logrusorgru/aurora"
@@ -17,6 +19,10 @@ type LogOptions struct {
 	RemoveNewlines bool
 	HideRegion     bool
 	HideAllocID    bool
+	JSON           bool
+	MinLevel       string
+	Since          time.Time
+	Until          time.Time
 }
 
 // LogOption is a func type that returns a LogOption.
@@ -43,12 +49,90 @@ func HideAllocID() LogOption {
 	}
 }
 
+// JSONLines renders each log entry as a single line JSON object, for tools
+// to consume. The objects have the fields of a logs.LogEntry.
+func JSONLines() LogOption {
+	return func(o *LogOptions) {
+		o.JSON = true
+	}
+}
+
+// MinLogLevel drops the log entries below level. Entries with a level that
+// isn't recognized are kept.
+func MinLogLevel(level string) LogOption {
+	return func(o *LogOptions) {
+		o.MinLevel = level
+	}
+}
+
+// LogTimeRange drops the log entries from before since or after until. A zero
+// time leaves that end of the range open.
+func LogTimeRange(since, until time.Time) LogOption {
+	return func(o *LogOptions) {
+		o.Since = since
+		o.Until = until
+	}
+}
+
+// outsideTimeRange reports whether the timestamp of entry is known and out of
+// the time range of options.
+func outsideTimeRange(entry logs.LogEntry, options *LogOptions) bool {
+	if options.Since.IsZero() && options.Until.IsZero() {
+		return false
+	}
+	ts, err := time.Parse(time.RFC3339Nano, entry.Timestamp)
+	if err != nil {
+		return false
+	}
+	return (!options.Since.IsZero() && ts.Before(options.Since)) ||
+		(!options.Until.IsZero() && ts.After(options.Until))
+}
+
+// logLevels ranks the log levels entries carry, lowest first.
+var logLevels = map[string]int{
+	"trace":    0,
+	"debug":    1,
+	"info":     2,
+	"notice":   3,
+	"warn":     4,
+	"warning":  4,
+	"error":    5,
+	"critical": 6,
+	"fatal":    6,
+	"panic":    6,
+}
+
+// ValidateLogLevel returns an error when level isn't a known log level.
+func ValidateLogLevel(level string) error {
+	if _, ok := logLevels[strings.ToLower(level)]; !ok {
+		return fmt.Errorf("unknown log level %q, must be one of: trace, debug, info, notice, warn, error, fatal", level)
+	}
+	return nil
+}
+
+// belowLogLevel reports whether level is known and ranks lower than min.
+func belowLogLevel(level, min string) bool {
+	rank, ok := logLevels[strings.ToLower(level)]
+	return ok && rank < logLevels[strings.ToLower(min)]
+}
+
 func LogEntry(w io.Writer, entry logs.LogEntry, opts ...LogOption) (err error) {
 	options := &LogOptions{}
 	for _, opt := range opts {
 		opt(options)
 	}
 
+	if options.MinLevel != "" && belowLogLevel(entry.Level, options.MinLevel) {
+		return nil
+	}
+	if outsideTimeRange(entry, options) {
+		return nil
+	}
+
+	if options.JSON {
+		return logEntryJSON(w, entry)
+	}
+
 	var ts time.Time
 	if ts, err = time.Parse(time.RFC3339Nano, entry.Timestamp); err != nil {
 		err = fmt.Errorf("failed parsing timestamp %q: %w", entry.Timestamp, err)
@@ -105,6 +189,13 @@ func LogEntry(w io.Writer, entry logs.LogEntry, opts ...LogOption) (err error) {
 	return err
 }
 
+// logEntryJSON writes entry as a single line JSON object, with the same
+// fields as the JSON of a logs.LogEntry.
+func logEntryJSON(w io.Writer, entry logs.LogEntry) error {
+	// Encode writes a newline after the object, making a JSON lines stream.
+	return json.NewEncoder(w).Encode(entry)
+}
+
 func printFieldIfPresent(w io.Writer, name string, value interface{}) (present bool) {
 	switch v := value.(type) {
 	case string:
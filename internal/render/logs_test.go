@@ -0,0 +1,88 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/logs"
+)
+
+func TestLogEntryJSONLines(t *testing.T) {
+	plain := logs.LogEntry{
+		Timestamp: "2024-05-01T10:00:00.123Z",
+		Level:     "info",
+		Instance:  "148e21ea7e6989",
+		Region:    "ord",
+		Message:   "listening on 0.0.0.0:8080",
+	}
+	request := plain
+	request.Message = "GET /"
+	request.Meta.Event.Provider = "proxy"
+	request.Meta.HTTP.Request.Method = "GET"
+	request.Meta.HTTP.Request.Version = "1.1"
+	request.Meta.HTTP.Response.StatusCode = 200
+
+	var buf bytes.Buffer
+	require.NoError(t, LogEntry(&buf, plain, JSONLines()))
+	require.NoError(t, LogEntry(&buf, request, JSONLines(), HideAllocID()))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+
+	// Each line has the fields of a log entry, like the JSON of one.
+	for i, entry := range []logs.LogEntry{plain, request} {
+		want, err := json.Marshal(entry)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(want), lines[i])
+	}
+
+	var got logs.LogEntry
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &got))
+	assert.Equal(t, request, got)
+	assert.Contains(t, lines[1], `"Version":"1.1"`)
+}
+
+func TestLogEntryMinLevel(t *testing.T) {
+	var buf bytes.Buffer
+	for _, level := range []string{"debug", "info", "warn", "WARNING", "error", "custom", "fatal"} {
+		entry := logs.LogEntry{Timestamp: "2024-05-01T10:00:00Z", Level: level, Message: "level=" + level}
+		require.NoError(t, LogEntry(&buf, entry, MinLogLevel("warn"), JSONLines()))
+	}
+
+	var got []string
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		got = append(got, entry["level"].(string))
+	}
+	assert.Equal(t, []string{"warn", "WARNING", "error", "custom", "fatal"}, got)
+
+	assert.NoError(t, ValidateLogLevel("Error"))
+	assert.Error(t, ValidateLogLevel("loud"))
+}
+
+func TestLogEntryTimeRange(t *testing.T) {
+	since := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	until := since.Add(time.Hour)
+
+	var buf bytes.Buffer
+	for _, ts := range []string{"2024-05-01T09:59:59.999Z", "2024-05-01T10:00:00Z", "2024-05-01T10:30:00.5Z", "2024-05-01T11:00:00Z", "2024-05-01T11:00:01Z", "not a time"} {
+		entry := logs.LogEntry{Timestamp: ts, Level: "info", Message: ts}
+		require.NoError(t, LogEntry(&buf, entry, LogTimeRange(since, until), JSONLines()))
+	}
+	entry := logs.LogEntry{Timestamp: "2024-05-01T12:00:00Z", Level: "info", Message: "open ended"}
+	require.NoError(t, LogEntry(&buf, entry, LogTimeRange(since, time.Time{}), JSONLines()))
+
+	var got []string
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		got = append(got, entry["message"].(string))
+	}
+	assert.Equal(t, []string{"2024-05-01T10:00:00Z", "2024-05-01T10:30:00.5Z", "2024-05-01T11:00:00Z", "not a time", "open ended"}, got)
+}
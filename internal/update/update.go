@@ -37,7 +37,7 @@ func Check() bool {
 	switch {
 	case env.IsTruthy("FLY_UPDATE_CHECK"):
 		return true
-	case env.IsTruthy("FLY_NO_UPDATE_CHECK"):
+	case CheckDisabled():
 		return false
 	case env.IsSet("CODESPACES"):
 		return false
@@ -50,6 +50,12 @@ func Check() bool {
 	}
 }
 
+// CheckDisabled reports whether the user turned update checks off with
+// FLY_NO_UPDATE_CHECK, and didn't force them on with FLY_UPDATE_CHECK.
+func CheckDisabled() bool {
+	return env.IsTruthy("FLY_NO_UPDATE_CHECK") && !env.IsTruthy("FLY_UPDATE_CHECK")
+}
+
 type InvalidReleaseError struct {
 	status int
 	msg    string
@@ -0,0 +1,20 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckDisabled(t *testing.T) {
+	t.Setenv("FLY_NO_UPDATE_CHECK", "")
+	t.Setenv("FLY_UPDATE_CHECK", "")
+	assert.False(t, CheckDisabled())
+
+	t.Setenv("FLY_NO_UPDATE_CHECK", "1")
+	assert.True(t, CheckDisabled())
+	assert.False(t, Check())
+
+	t.Setenv("FLY_UPDATE_CHECK", "1")
+	assert.False(t, CheckDisabled())
+}
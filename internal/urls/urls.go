@@ -0,0 +1,34 @@
+package urls
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const baseURL = "https://fly.io/apps/"
+
+// App returns the dashboard URL for appName, optionally for one of its
+// sub-pages (e.g. "metrics" or "machines/<id>").
+func App(appName string, subpath ...string) string {
+	u := baseURL + url.PathEscape(appName)
+	if len(subpath) > 0 {
+		u += "/" + strings.Join(subpath, "/")
+	}
+	return u
+}
+
+// Monitoring returns the URL of the app's monitoring page, filtered to a
+// single machine when machineID is set.
+func Monitoring(appName, machineID string) string {
+	u := App(appName, "monitoring")
+	if machineID != "" {
+		u += fmt.Sprintf("?region=&instance=%s", machineID)
+	}
+	return u
+}
+
+// Machine returns the dashboard URL of a single machine.
+func Machine(appName, machineID string) string {
+	return App(appName, "machines", machineID)
+}
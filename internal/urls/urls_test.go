@@ -0,0 +1,15 @@
+package urls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestURLs(t *testing.T) {
+	assert.Equal(t, "https://fly.io/apps/my-app", App("my-app"))
+	assert.Equal(t, "https://fly.io/apps/my-app/metrics", App("my-app", "metrics"))
+	assert.Equal(t, "https://fly.io/apps/my-app/monitoring", Monitoring("my-app", ""))
+	assert.Equal(t, "https://fly.io/apps/my-app/monitoring?region=&instance=abc123", Monitoring("my-app", "abc123"))
+	assert.Equal(t, "https://fly.io/apps/my-app/machines/abc123", Machine("my-app", "abc123"))
+}
@@ -48,6 +48,8 @@ type IOStreams struct {
 
 	neverPrompt bool
 
+	quiet bool
+
 	TempFileOverride *os.File
 }
 
@@ -226,6 +228,49 @@ func (s *IOStreams) SetNeverPrompt(v bool) {
 	s.neverPrompt = v
 }
 
+// SetQuiet toggles quiet mode. Quiet mode disables progress indicators and
+// colored output, leaving only essential results and errors.
+func (s *IOStreams) SetQuiet(quiet bool) {
+	s.quiet = quiet
+	if quiet {
+		s.progressIndicatorEnabled = false
+		s.colorEnabled = false
+	}
+}
+
+// IsQuiet reports whether quiet mode is enabled.
+func (s *IOStreams) IsQuiet() bool {
+	return s.quiet
+}
+
+// ProgressIndicatorEnabled reports whether animated output, such as
+// spinners and rewritten lines, can be written to the terminal.
+func (s *IOStreams) ProgressIndicatorEnabled() bool {
+	return s.progressIndicatorEnabled
+}
+
+// StartProgressIndicatorOrLog starts a progress indicator showing msg when
+// progress indicators are enabled. Otherwise msg is written as a plain line to
+// ErrOut, unless quiet mode is on.
+func (s *IOStreams) StartProgressIndicatorOrLog(msg string) {
+	switch {
+	case s.progressIndicatorEnabled:
+		s.StartProgressIndicatorMsg(msg)
+	case !s.quiet:
+		fmt.Fprint(s.ErrOut, appendMissingCharacter(msg, newLine))
+	}
+}
+
+// StopProgressIndicatorOrLog is the counterpart of StartProgressIndicatorOrLog.
+func (s *IOStreams) StopProgressIndicatorOrLog(msg string) {
+	switch {
+	case s.progressIndicator != nil:
+		s.StopProgressIndicatorMsg(msg)
+	case !s.quiet && msg != "":
+		fmt.Fprint(s.ErrOut, appendMissingCharacter(msg, newLine))
+	}
+}
+
 func (s *IOStreams) StartProgressIndicator() {
 	s.StartProgressIndicatorMsg("")
 }
@@ -401,6 +446,25 @@ func Test() (*IOStreams, *bytes.Buffer, *bytes.Buffer, *bytes.Buffer) {
 	}, in, out, errOut
 }
 
+// TestClosedStdin returns streams like Test, except that stdin is a pipe that's
+// already closed, as for a command run in CI with no input attached. Stdout
+// claims to be a terminal, so the closed stdin alone is what rules out prompts.
+func TestClosedStdin() (*IOStreams, *bytes.Buffer, *bytes.Buffer, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := w.Close(); err != nil {
+		r.Close()
+		return nil, nil, nil, err
+	}
+
+	ios, _, out, errOut := Test()
+	ios.In = r
+	ios.SetStdoutTTY(true)
+	return ios, out, errOut, nil
+}
+
 func isTerminal(f *os.File) bool {
 	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
 }
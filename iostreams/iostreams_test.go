@@ -0,0 +1,77 @@
+package iostreams
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuietSuppressesANSI(t *testing.T) {
+	errOut := &bytes.Buffer{}
+	streams := &IOStreams{
+		Out:                      &bytes.Buffer{},
+		ErrOut:                   errOut,
+		colorEnabled:             true,
+		progressIndicatorEnabled: true,
+	}
+
+	streams.SetQuiet(true)
+	assert.True(t, streams.IsQuiet())
+
+	// No spinner is started, so nothing can be drawn in the background.
+	streams.StartProgressIndicatorMsg("Building image")
+	assert.Nil(t, streams.progressIndicator)
+	streams.StopProgressIndicatorMsg("done")
+	errOut.WriteString(streams.ColorScheme().Green("success"))
+
+	assert.NotContains(t, errOut.String(), "\x1b")
+	assert.Equal(t, "success", errOut.String())
+}
+
+func TestProgressIndicatorOrLogWithoutTTY(t *testing.T) {
+	streams, _, _, errOut := Test()
+
+	streams.StartProgressIndicatorOrLog("Waiting for remote builder fly-builder...")
+	streams.StopProgressIndicatorOrLog("Remote builder fly-builder ready")
+
+	assert.NotContains(t, errOut.String(), "\x1b")
+	assert.Equal(t, "Waiting for remote builder fly-builder...\nRemote builder fly-builder ready\n", errOut.String())
+}
+
+func TestProgressIndicatorOrLogQuiet(t *testing.T) {
+	streams, _, _, errOut := Test()
+	streams.SetQuiet(true)
+
+	streams.StartProgressIndicatorOrLog("Waiting for remote builder fly-builder...")
+	streams.StopProgressIndicatorOrLog("Remote builder fly-builder ready")
+
+	assert.Empty(t, errOut.String())
+}
+
+func TestCanPrompt(t *testing.T) {
+	ios, _, _, _ := Test()
+	assert.False(t, ios.CanPrompt())
+
+	ios.SetStdinTTY(true)
+	ios.SetStdoutTTY(true)
+	assert.True(t, ios.CanPrompt())
+
+	ios.SetNeverPrompt(true)
+	assert.False(t, ios.CanPrompt())
+}
+
+func TestClosedStdinCannotPrompt(t *testing.T) {
+	streams, _, _, err := TestClosedStdin()
+	require.NoError(t, err)
+	defer streams.In.Close()
+
+	assert.True(t, streams.IsStdoutTTY())
+	assert.False(t, streams.IsStdinTTY())
+	assert.False(t, streams.CanPrompt())
+
+	_, err = streams.In.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, io.EOF)
+}
@@ -0,0 +1,102 @@
+package scanner
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Scanner detects a kind of project in a source directory. Scan returns nil
+// when the directory isn't one.
+type Scanner interface {
+	Name() string
+	Scan(sourceDir string, config *ScannerConfig) (*SourceInfo, error)
+}
+
+// The priorities of the built-in scanners. Scanners are tried by increasing
+// priority, and in the order they were registered within a priority, so that
+// the most specific ones get the first chance to match.
+const (
+	// PriorityFramework is for framework scanners, which might mix
+	// languages or come with a Dockerfile that doesn't work with Fly.
+	PriorityFramework = 100
+	// PriorityDockerfile is for the scanner of existing Dockerfiles.
+	PriorityDockerfile = 200
+	// PriorityLanguage is for language scanners, and the frameworks they
+	// have to be tried after.
+	PriorityLanguage = 300
+)
+
+type registeredScanner struct {
+	Scanner
+	priority int
+}
+
+var registry []registeredScanner
+
+// Register adds a scanner to the ones Scan tries, with the given priority.
+// It panics if a scanner of the same name is already registered.
+func Register(s Scanner, priority int) {
+	if slices.ContainsFunc(registry, func(r registeredScanner) bool { return r.Name() == s.Name() }) {
+		panic(fmt.Sprintf("scanner %q is already registered", s.Name()))
+	}
+	i := slices.IndexFunc(registry, func(r registeredScanner) bool { return r.priority > priority })
+	if i < 0 {
+		i = len(registry)
+	}
+	registry = slices.Insert(registry, i, registeredScanner{Scanner: s, priority: priority})
+}
+
+// Scanners returns the registered scanners, in the order Scan tries them.
+func Scanners() []Scanner {
+	scanners := make([]Scanner, 0, len(registry))
+	for _, r := range registry {
+		scanners = append(scanners, r.Scanner)
+	}
+	return scanners
+}
+
+// funcScanner is a Scanner made of a sourceScanner function.
+type funcScanner struct {
+	name string
+	scan sourceScanner
+}
+
+func (s funcScanner) Name() string { return s.name }
+
+func (s funcScanner) Scan(sourceDir string, config *ScannerConfig) (*SourceInfo, error) {
+	return s.scan(sourceDir, config)
+}
+
+func init() {
+	for _, s := range []funcScanner{
+		{"django", configureDjango},
+		{"laravel", configureLaravel},
+		{"phoenix", configurePhoenix},
+		{"rails", configureRails},
+		{"redwood", configureRedwood},
+		{"js-framework", configureJsFramework},
+	} {
+		Register(s, PriorityFramework)
+	}
+
+	Register(funcScanner{"dockerfile", configureDockerfile}, PriorityDockerfile)
+
+	for _, s := range []funcScanner{
+		{"bridgetown", configureBridgetown},
+		{"lucky", configureLucky},
+		{"ruby", configureRuby},
+		{"go", configureGo},
+		{"elixir", configureElixir},
+		{"flask", configureFlask},
+		{"python", configurePython},
+		{"deno", configureDeno},
+		{"nuxt", configureNuxt},
+		{"nextjs", configureNextJs},
+		{"node", configureNode},
+		{"static", configureStatic},
+		{"dotnet", configureDotnet},
+		{"rust", configureRust},
+	} {
+		Register(s, PriorityLanguage)
+	}
+}
@@ -0,0 +1,58 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scannerNames() []string {
+	var names []string
+	for _, s := range Scanners() {
+		names = append(names, s.Name())
+	}
+	return names
+}
+
+func TestRegister(t *testing.T) {
+	defer func(r []registeredScanner) { registry = r }(slices.Clone(registry))
+
+	names := scannerNames()
+	assert.Less(t, slices.Index(names, "rails"), slices.Index(names, "dockerfile"))
+	assert.Less(t, slices.Index(names, "dockerfile"), slices.Index(names, "ruby"))
+	assert.Less(t, slices.Index(names, "nextjs"), slices.Index(names, "node"))
+
+	none := func(string, *ScannerConfig) (*SourceInfo, error) { return nil, nil }
+	Register(funcScanner{"hanami", none}, PriorityFramework)
+	Register(funcScanner{"catch-all", none}, PriorityLanguage+1)
+	Register(funcScanner{"first", none}, 0)
+
+	names = scannerNames()
+	assert.Equal(t, "first", names[0])
+	assert.Equal(t, "catch-all", names[len(names)-1])
+	assert.Equal(t, slices.Index(names, "js-framework")+1, slices.Index(names, "hanami"))
+	assert.Equal(t, slices.Index(names, "hanami")+1, slices.Index(names, "dockerfile"))
+
+	assert.PanicsWithValue(t, `scanner "rails" is already registered`, func() {
+		Register(funcScanner{"rails", none}, PriorityFramework)
+	})
+}
+
+func TestScanWith(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM nginx\nEXPOSE 80\n"), 0o644))
+
+	si, err := ScanWith("dockerfile", dir, &ScannerConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, "Dockerfile", si.Family)
+
+	_, err = ScanWith("rails", dir, &ScannerConfig{})
+	assert.ErrorContains(t, err, "the rails scanner doesn't detect a project it supports")
+
+	_, err = ScanWith("cobol", dir, &ScannerConfig{})
+	assert.ErrorContains(t, err, `unknown scanner "cobol", the scanners are: django, laravel,`)
+}
@@ -2,6 +2,7 @@ package scanner
 
 import (
 	"embed"
+	"fmt"
 	"io/fs"
 	"path/filepath"
 	"strings"
@@ -111,36 +112,11 @@ type GitHubActionsStruct struct {
 	Files   []SourceFile
 }
 
+// Scan tries the registered scanners on sourceDir in order, and returns the
+// source info of the first one that matches, or nil if none does.
 func Scan(sourceDir string, config *ScannerConfig) (*SourceInfo, error) {
-	scanners := []sourceScanner{
-		configureDjango,
-		configureLaravel,
-		configurePhoenix,
-		configureRails,
-		configureRedwood,
-		configureJsFramework,
-		/* frameworks scanners are placed before generic scanners,
-		   since they might mix languages or have a Dockerfile that
-			 doesn't work with Fly */
-		configureDockerfile,
-		configureBridgetown,
-		configureLucky,
-		configureRuby,
-		configureGo,
-		configureElixir,
-		configureFlask,
-		configurePython,
-		configureDeno,
-		configureNuxt,
-		configureNextJs,
-		configureNode,
-		configureStatic,
-		configureDotnet,
-		configureRust,
-	}
-
-	for _, scanner := range scanners {
-		si, err := scanner(sourceDir, config)
+	for _, scanner := range Scanners() {
+		si, err := scanner.Scan(sourceDir, config)
 		if err != nil {
 			return nil, err
 		}
@@ -153,6 +129,30 @@ func Scan(sourceDir string, config *ScannerConfig) (*SourceInfo, error) {
 	return nil, nil
 }
 
+// ScanWith runs the registered scanner of the given name on sourceDir,
+// whether or not another scanner would detect the project first. It fails
+// if there's no such scanner, or if it doesn't match sourceDir.
+func ScanWith(name string, sourceDir string, config *ScannerConfig) (*SourceInfo, error) {
+	var names []string
+	for _, scanner := range Scanners() {
+		if scanner.Name() != name {
+			names = append(names, scanner.Name())
+			continue
+		}
+
+		si, err := scanner.Scan(sourceDir, config)
+		if err != nil {
+			return nil, err
+		}
+		if si == nil {
+			return nil, fmt.Errorf("the %s scanner doesn't detect a project it supports in %s", name, sourceDir)
+		}
+		github_actions(sourceDir, &si.GitHubActions)
+		return si, nil
+	}
+	return nil, fmt.Errorf("unknown scanner %q, the scanners are: %s", name, strings.Join(names, ", "))
+}
+
 type sourceScanner func(sourceDir string, config *ScannerConfig) (*SourceInfo, error)
 
 // templates recursively returns files from the templates directory within the named directory
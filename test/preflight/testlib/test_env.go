@@ -284,11 +284,16 @@ func (f *FlyctlTestEnv) DebugPrintHistory() {
 func (f *FlyctlTestEnv) verifyTestOrgExists() {
 	result := f.Fly("orgs list --json")
 	result.AssertSuccessfulExit()
-	var orgMap map[string]string
-	result.StdOutJSON(&orgMap)
-	if _, present := orgMap[f.orgSlug]; !present {
-		f.Fatalf("could not find org with name '%s' in `%s` output: %s", f.orgSlug, result.cmdStr, result.stdOut.String())
+	var orgs []struct {
+		Slug string `json:"slug"`
 	}
+	result.StdOutJSON(&orgs)
+	for _, org := range orgs {
+		if org.Slug == f.orgSlug {
+			return
+		}
+	}
+	f.Fatalf("could not find org with name '%s' in `%s` output: %s", f.orgSlug, result.cmdStr, result.stdOut.String())
 }
 
 func (f *FlyctlTestEnv) CreateRandomAppName() string {
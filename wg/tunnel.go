@@ -180,14 +180,40 @@ func (t *Tunnel) LookupAAAA(ctx context.Context, name string) ([]net.IP, error)
 		return nil, err
 	}
 
-	results := make([]net.IP, 0, len(r.Answer))
+	return answerIPs(r.Answer, dns.TypeAAAA), nil
+}
 
-	for _, a := range r.Answer {
-		ip := a.(*dns.AAAA).AAAA
-		results = append(results, ip)
+func (t *Tunnel) LookupA(ctx context.Context, name string) ([]net.IP, error) {
+	var m dns.Msg
+	_ = m.SetQuestion(dns.Fqdn(name), dns.TypeA)
+
+	r, err := t.queryDNS(ctx, &m)
+	if err != nil {
+		return nil, err
 	}
 
-	return results, nil
+	return answerIPs(r.Answer, dns.TypeA), nil
+}
+
+// answerIPs returns the addresses of the answers of type qtype, skipping any
+// other records (such as CNAMEs) the server included.
+func answerIPs(answers []dns.RR, qtype uint16) []net.IP {
+	results := make([]net.IP, 0, len(answers))
+
+	for _, a := range answers {
+		switch rr := a.(type) {
+		case *dns.AAAA:
+			if qtype == dns.TypeAAAA {
+				results = append(results, rr.AAAA)
+			}
+		case *dns.A:
+			if qtype == dns.TypeA {
+				results = append(results, rr.A)
+			}
+		}
+	}
+
+	return results
 }
 
 func (t *Tunnel) queryDNS(ctx context.Context, msg *dns.Msg) (*dns.Msg, error) {
@@ -0,0 +1,22 @@
+package wg
+
+import (
+	"net"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnswerIPs(t *testing.T) {
+	answers := []dns.RR{
+		&dns.CNAME{Target: "app.internal."},
+		&dns.A{A: net.ParseIP("10.0.0.1")},
+		&dns.AAAA{AAAA: net.ParseIP("fdaa::1")},
+		&dns.AAAA{AAAA: net.ParseIP("fdaa::2")},
+	}
+
+	assert.Equal(t, []net.IP{net.ParseIP("fdaa::1"), net.ParseIP("fdaa::2")}, answerIPs(answers, dns.TypeAAAA))
+	assert.Equal(t, []net.IP{net.ParseIP("10.0.0.1")}, answerIPs(answers, dns.TypeA))
+	assert.Empty(t, answerIPs(nil, dns.TypeAAAA))
+}